@@ -31,9 +31,13 @@ import (
 	"reflect"
 	"regexp"
 	"runtime/debug"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"golang.org/x/mod/modfile"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
@@ -74,6 +78,10 @@ type ignore struct {
 	start        int
 	end          int
 	suppressions map[string][]issue.SuppressionInfo
+	// expired holds the suppressions of the directives past their until
+	// date, which no longer apply but are reported with the issues.
+	expired    map[string][]issue.SuppressionInfo
+	directives []*noSecDirective
 }
 
 type ignores map[string][]ignore
@@ -97,23 +105,33 @@ func (i ignores) parseLine(line string) (int, int) {
 	return start, end
 }
 
-func (i ignores) add(file string, line string, suppressions map[string]issue.SuppressionInfo) {
+func (i ignores) add(file string, line string, suppressions map[string]issue.SuppressionInfo, directive *noSecDirective, expired bool) {
 	is := []ignore{}
 	if _, ok := i[file]; ok {
 		is = i[file]
 	}
 	found := false
 	start, end := i.parseLine(line)
-	for _, ig := range is {
+	for idx, ig := range is {
 		if ig.start <= start && ig.end >= end {
 			found = true
+			target := ig.suppressions
+			if expired {
+				if ig.expired == nil {
+					is[idx].expired = map[string][]issue.SuppressionInfo{}
+				}
+				target = is[idx].expired
+			}
 			for r, s := range suppressions {
-				ss, ok := ig.suppressions[r]
+				ss, ok := target[r]
 				if !ok {
 					ss = []issue.SuppressionInfo{}
 				}
 				ss = append(ss, s)
-				ig.suppressions[r] = ss
+				target[r] = ss
+			}
+			if directive != nil {
+				is[idx].directives = append(ig.directives, directive)
 			}
 			break
 		}
@@ -124,23 +142,39 @@ func (i ignores) add(file string, line string, suppressions map[string]issue.Sup
 			end:          end,
 			suppressions: map[string][]issue.SuppressionInfo{},
 		}
+		target := ig.suppressions
+		if expired {
+			ig.expired = map[string][]issue.SuppressionInfo{}
+			target = ig.expired
+		}
 		for r, s := range suppressions {
-			ig.suppressions[r] = []issue.SuppressionInfo{s}
+			target[r] = []issue.SuppressionInfo{s}
+		}
+		if directive != nil {
+			ig.directives = []*noSecDirective{directive}
 		}
 		is = append(is, ig)
 	}
 	i[file] = is
 }
 
-func (i ignores) get(file string, line string) map[string][]issue.SuppressionInfo {
+// find returns the ignore covering the lines, or nil if there is none.
+func (i ignores) find(file string, line string) *ignore {
 	start, end := i.parseLine(line)
 	if is, ok := i[file]; ok {
-		for _, i := range is {
+		for idx, i := range is {
 			if i.start <= start && i.end >= end || start <= i.start && end >= i.end {
-				return i.suppressions
+				return &is[idx]
 			}
 		}
 	}
+	return nil
+}
+
+func (i ignores) get(file string, line string) map[string][]issue.SuppressionInfo {
+	if ig := i.find(file, line); ig != nil {
+		return ig.suppressions
+	}
 	return map[string][]issue.SuppressionInfo{}
 }
 
@@ -175,10 +209,27 @@ func (ctx *Context) NewIssue(node ast.Node, ruleID, desc string,
 
 // Metrics used when reporting information about a scanning run.
 type Metrics struct {
-	NumFiles int `json:"files"`
-	NumLines int `json:"lines"`
-	NumNosec int `json:"nosec"`
-	NumFound int `json:"found"`
+	NumFiles     int                `json:"files"`
+	NumLines     int                `json:"lines"`
+	NumNosec     int                `json:"nosec"`
+	NumFound     int                `json:"found"`
+	NumTruncated *TruncationMetrics `json:"truncated,omitempty"`
+}
+
+// TruncationMetrics counts the functions whose taint analysis stopped at a
+// limit, by limit. Findings may be missing from those functions.
+type TruncationMetrics struct {
+	Depth   int `json:"depth"`
+	Visited int `json:"visited"`
+	Budget  int `json:"budget"`
+}
+
+// Total returns the number of truncated functions.
+func (t *TruncationMetrics) Total() int {
+	if t == nil {
+		return 0
+	}
+	return t.Depth + t.Visited + t.Budget
 }
 
 // Merge merges the metrics from another Metrics object into this one.
@@ -190,6 +241,14 @@ func (m *Metrics) Merge(other *Metrics) {
 	m.NumLines += other.NumLines
 	m.NumNosec += other.NumNosec
 	m.NumFound += other.NumFound
+	if other.NumTruncated.Total() > 0 {
+		if m.NumTruncated == nil {
+			m.NumTruncated = &TruncationMetrics{}
+		}
+		m.NumTruncated.Depth += other.NumTruncated.Depth
+		m.NumTruncated.Visited += other.NumTruncated.Visited
+		m.NumTruncated.Budget += other.NumTruncated.Budget
+	}
 }
 
 // Analyzer object is the main object of gosec. It has methods to load and analyze
@@ -219,6 +278,30 @@ type Analyzer struct {
 	trackSuppressions bool
 	concurrency       int
 	analyzerSet       *analyzers.AnalyzerSet
+	facts             FactStore
+	// scope holds the settings of config.
+	scope *packageScope
+	// packageScopes maps package directories to the settings of the
+	// configurations which replace config when checking them.
+	packageScopes map[string]*packageScope
+}
+
+// packageScope holds the settings in effect while checking a package.
+type packageScope struct {
+	config      Config
+	ignoreNosec bool
+	showIgnored bool
+	// selected reports whether a rule is enabled by the include and exclude
+	// options of a package configuration. It is nil when all the loaded rules
+	// are enabled.
+	selected func(ruleID string) bool
+	// scores overrides the severity and confidence of the issues.
+	scores *ScoreOverrides
+}
+
+// deselected reports whether the package configuration disables a rule.
+func (s *packageScope) deselected(ruleID string) bool {
+	return s != nil && s.selected != nil && !s.selected(ruleID)
 }
 
 // NewAnalyzer builds a new analyzer.
@@ -234,7 +317,7 @@ func NewAnalyzer(conf Config, tests bool, excludeGenerated bool, trackSuppressio
 	if logger == nil {
 		logger = log.New(os.Stderr, "[gosec]", log.LstdFlags)
 	}
-	return &Analyzer{
+	gosec := &Analyzer{
 		ignoreNosec:       ignoreNoSec,
 		showIgnored:       showIgnored,
 		ruleset:           NewRuleSet(),
@@ -249,12 +332,22 @@ func NewAnalyzer(conf Config, tests bool, excludeGenerated bool, trackSuppressio
 		excludeGenerated:  excludeGenerated,
 		trackSuppressions: trackSuppressions,
 		analyzerSet:       analyzers.NewAnalyzerSet(),
+		facts:             NewFactStore(),
 	}
+	gosec.scope = gosec.defaultScope()
+	return gosec
+}
+
+// SetFactStore replaces the store of the facts exported by the analyzers,
+// e.g. to share them with a driver which runs gosec package by package.
+func (gosec *Analyzer) SetFactStore(facts FactStore) {
+	gosec.facts = facts
 }
 
 // SetConfig updates the analyzer configuration
 func (gosec *Analyzer) SetConfig(conf Config) {
 	gosec.config = conf
+	gosec.scope = gosec.defaultScope()
 }
 
 // Config returns the current configuration
@@ -262,6 +355,80 @@ func (gosec *Analyzer) Config() Config {
 	return gosec.config
 }
 
+// SetPackageConfig sets the configuration used instead of the analyzer one
+// for the package in the given directory. Besides the settings of the rules
+// and the #nosec options, its include and exclude global options select the
+// rules checked in the package among the loaded ones.
+func (gosec *Analyzer) SetPackageConfig(dir string, conf Config) {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	if gosec.packageScopes == nil {
+		gosec.packageScopes = make(map[string]*packageScope)
+	}
+	scope := &packageScope{config: conf, selected: ruleSelector(conf), scores: gosec.scoreOverrides(conf)}
+	scope.ignoreNosec, _ = conf.IsGlobalEnabled(Nosec)
+	scope.showIgnored, _ = conf.IsGlobalEnabled(ShowIgnored)
+	gosec.packageScopes[dir] = scope
+}
+
+// defaultScope returns the settings of the analyzer configuration.
+func (gosec *Analyzer) defaultScope() *packageScope {
+	return &packageScope{
+		config:      gosec.config,
+		ignoreNosec: gosec.ignoreNosec,
+		showIgnored: gosec.showIgnored,
+		scores:      gosec.scoreOverrides(gosec.config),
+	}
+}
+
+// scopeFor returns the settings in effect for a package, taken from the
+// configuration of its directory when there is one.
+func (gosec *Analyzer) scopeFor(pkg *packages.Package) *packageScope {
+	if pkg != nil && len(pkg.GoFiles) > 0 {
+		if scope, ok := gosec.packageScopes[filepath.Dir(pkg.GoFiles[0])]; ok {
+			return scope
+		}
+	}
+	return gosec.scope
+}
+
+// scoreOverrides returns the severity and confidence overrides of conf. The
+// invalid overrides are logged and ignored.
+func (gosec *Analyzer) scoreOverrides(conf Config) *ScoreOverrides {
+	overrides, err := conf.GetScoreOverrides()
+	if err == nil && len(overrides) > 0 {
+		var scores *ScoreOverrides
+		if scores, err = NewScoreOverrides(overrides); err == nil {
+			return scores
+		}
+	}
+	if err != nil {
+		gosec.logger.Printf("Ignoring the score overrides: %v", err)
+	}
+	return nil
+}
+
+// ruleSelector returns whether a rule is enabled by the include and exclude
+// global options of conf.
+func ruleSelector(conf Config) func(ruleID string) bool {
+	ruleIDs := func(option GlobalOption) map[string]bool {
+		value, _ := conf.GetGlobal(option)
+		ids := make(map[string]bool)
+		for _, id := range strings.Split(value, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids[id] = true
+			}
+		}
+		return ids
+	}
+	include := ruleIDs(IncludeRules)
+	exclude := ruleIDs(ExcludeRules)
+	return func(ruleID string) bool {
+		return (len(include) == 0 || include[ruleID]) && !exclude[ruleID]
+	}
+}
+
 // LoadRules instantiates all the rules to be used when analyzing source
 // packages
 func (gosec *Analyzer) LoadRules(ruleDefinitions map[string]RuleBuilder, ruleSuppressed map[string]bool) {
@@ -282,11 +449,17 @@ func (gosec *Analyzer) LoadRules(ruleDefinitions map[string]RuleBuilder, ruleSup
 // package walk: because each concurrent worker calls buildPackageRuleset
 // independently, every goroutine gets its own rule instances with their own
 // internal state (maps, caches, etc.), so rules require no synchronisation.
-func (gosec *Analyzer) buildPackageRuleset() RuleSet {
+func (gosec *Analyzer) buildPackageRuleset(scope *packageScope) RuleSet {
 	rs := NewRuleSet()
 	for id, def := range gosec.ruleBuilders {
-		r, nodes := def(id, gosec.config)
-		rs.Register(r, gosec.ruleSuppressed[id], nodes...)
+		// The rules disabled by the package configuration are kept as
+		// suppressed ones when the suppressions are tracked.
+		deselected := scope.deselected(id)
+		if deselected && !gosec.trackSuppressions {
+			continue
+		}
+		r, nodes := def(id, scope.config)
+		rs.Register(r, gosec.ruleSuppressed[id] || deselected, nodes...)
 	}
 	return rs
 }
@@ -317,8 +490,27 @@ func (gosec *Analyzer) Process(buildTags []string, packagePaths ...string) error
 	results := make(chan result, len(packagePaths)) // Buffer for all potential results
 	jobs := make(chan string, len(packagePaths))
 
-	// Fill jobs channel and close it to signal no more work
+	// Packages are analyzed after the packages they import, so that the facts
+	// exported by the analyzers on the latter are available. Jobs are queued
+	// in dependency order, hence a worker only waits for packages which were
+	// already picked up by other workers.
+	deps := gosec.packageDependencies(buildTags, packagePaths)
+	imported := make(map[string]bool)
+	for _, pkgDeps := range deps {
+		for _, dep := range pkgDeps {
+			imported[dep] = true
+		}
+	}
+	pending := make(map[string]*sync.WaitGroup, len(packagePaths))
 	for _, pkgPath := range packagePaths {
+		if pending[pkgPath] == nil {
+			pending[pkgPath] = &sync.WaitGroup{}
+		}
+		pending[pkgPath].Add(1)
+	}
+
+	// Fill jobs channel and close it to signal no more work
+	for _, pkgPath := range orderPackages(packagePaths, deps) {
 		jobs <- pkgPath
 	}
 	close(jobs)
@@ -333,14 +525,19 @@ func (gosec *Analyzer) Process(buildTags []string, packagePaths ...string) error
 				if !ok {
 					return nil // Jobs drained, worker done
 				}
+				for _, dep := range deps[pkgPath] {
+					pending[dep].Wait()
+				}
 
 				pkgs, err := gosec.load(pkgPath, buildTags)
 				if err != nil {
 					results <- result{pkgPath: pkgPath, err: err}
+					pending[pkgPath].Done()
 					continue
 				}
 
 				var funcIssues []*issue.Issue
+				var funcIgnores []ignores
 				funcStats := &Metrics{}
 				funcErrors := make(map[string][]Error)
 
@@ -355,6 +552,7 @@ func (gosec *Analyzer) Process(buildTags []string, packagePaths ...string) error
 							pkgPath: pkgPath,
 							err:     fmt.Errorf("parsing errors in pkg %q: %w", pkg.Name, err),
 						}
+						pending[pkgPath].Done()
 						return nil // Parsing error in worker stops this package
 					}
 					// Collect parsing errors if any
@@ -369,10 +567,15 @@ func (gosec *Analyzer) Process(buildTags []string, packagePaths ...string) error
 					funcIssues = append(funcIssues, issues...)
 					funcStats.Merge(stats)
 
-					// Run SSA-based analyzers (stateless)
-					ssaIssues, ssaStats := gosec.checkAnalyzers(pkg, allIgnores)
+					// Run SSA-based analyzers (stateless), exporting the facts
+					// needed by the other analyzed packages
+					ssaIssues, ssaStats := gosec.checkAnalyzers(pkg, allIgnores, imported[pkgPath])
 					funcIssues = append(funcIssues, ssaIssues...)
 					funcStats.Merge(ssaStats)
+					funcIgnores = append(funcIgnores, allIgnores)
+				}
+				if len(pkgs) > 0 {
+					funcIssues = append(funcIssues, gosec.unusedNoSecIssues(funcIgnores, gosec.scopeFor(pkgs[0]))...)
 				}
 
 				results <- result{
@@ -383,6 +586,7 @@ func (gosec *Analyzer) Process(buildTags []string, packagePaths ...string) error
 					errors:  funcErrors,
 					err:     nil,
 				}
+				pending[pkgPath].Done()
 			case <-ctx.Done():
 				return ctx.Err() // Early shutdown
 			}
@@ -417,6 +621,103 @@ func (gosec *Analyzer) Process(buildTags []string, packagePaths ...string) error
 	return g.Wait() // Return any aggregated error from workers
 }
 
+// packageDependencies maps each of the package paths to the paths among them
+// of the packages it imports. It is only needed when the analyzers exchange
+// facts between packages, and is left empty when it cannot be resolved.
+func (gosec *Analyzer) packageDependencies(buildTags []string, packagePaths []string) map[string][]string {
+	deps := make(map[string][]string)
+	if len(packagePaths) < 2 || !gosec.usesFacts() {
+		return deps
+	}
+
+	byModule := make(map[string][]string)
+	dirPaths := make(map[string]string)
+	for _, pkgPath := range packagePaths {
+		abspath, err := GetPkgAbsPath(pkgPath)
+		if err != nil {
+			continue
+		}
+		if modRoot := FindModuleRoot(abspath); modRoot != "" {
+			byModule[modRoot] = append(byModule[modRoot], abspath)
+			dirPaths[abspath] = pkgPath
+		}
+	}
+
+	var pkgs []*packages.Package
+	for modRoot, dirs := range byModule {
+		conf := &packages.Config{
+			Mode:       packages.NeedName | packages.NeedFiles | packages.NeedImports,
+			BuildFlags: CLIBuildTags(buildTags),
+			Dir:        modRoot,
+		}
+		loaded, err := packages.Load(conf, dirs...)
+		if err != nil {
+			gosec.logger.Printf("Unable to resolve the dependencies between packages: %s", err)
+			continue
+		}
+		pkgs = append(pkgs, loaded...)
+	}
+
+	importPaths := make(map[string]string)
+	for _, pkg := range pkgs {
+		if len(pkg.GoFiles) > 0 {
+			if pkgPath, ok := dirPaths[filepath.Dir(pkg.GoFiles[0])]; ok {
+				importPaths[pkg.PkgPath] = pkgPath
+			}
+		}
+	}
+	for _, pkg := range pkgs {
+		pkgPath, ok := importPaths[pkg.PkgPath]
+		if !ok {
+			continue
+		}
+		for imported := range pkg.Imports {
+			if dep, ok := importPaths[imported]; ok && dep != pkgPath {
+				deps[pkgPath] = append(deps[pkgPath], dep)
+			}
+		}
+	}
+	return deps
+}
+
+// usesFacts reports whether any of the loaded analyzers exchanges facts.
+func (gosec *Analyzer) usesFacts() bool {
+	for _, analyzer := range gosec.analyzerSet.Analyzers {
+		if len(analyzer.FactTypes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// orderPackages sorts the package paths so that every package comes after the
+// packages it depends on, keeping the original order otherwise.
+func orderPackages(packagePaths []string, deps map[string][]string) []string {
+	occurrences := make(map[string]int, len(packagePaths))
+	for _, pkgPath := range packagePaths {
+		occurrences[pkgPath]++
+	}
+	ordered := make([]string, 0, len(packagePaths))
+	visited := make(map[string]bool, len(packagePaths))
+	var visit func(pkgPath string)
+	visit = func(pkgPath string) {
+		if visited[pkgPath] {
+			return
+		}
+		visited[pkgPath] = true
+		for _, dep := range deps[pkgPath] {
+			visit(dep)
+		}
+		for range occurrences[pkgPath] {
+			ordered = append(ordered, pkgPath)
+		}
+	}
+	for _, pkgPath := range packagePaths {
+		visit(pkgPath)
+	}
+	return ordered
+}
+
 func (gosec *Analyzer) load(pkgPath string, buildTags []string) ([]*packages.Package, error) {
 	abspath, err := GetPkgAbsPath(pkgPath)
 	if err != nil {
@@ -482,6 +783,7 @@ func (gosec *Analyzer) CheckRules(pkg *packages.Package) {
 // checkRules runs analysis on the given package (Stateless API).
 func (gosec *Analyzer) checkRules(pkg *packages.Package) ([]*issue.Issue, *Metrics, ignores) {
 	gosec.logger.Println("Checking package:", pkg.Name)
+	scope := gosec.scopeFor(pkg)
 	stats := &Metrics{}
 	allIgnores := newIgnores()
 
@@ -498,7 +800,7 @@ func (gosec *Analyzer) checkRules(pkg *packages.Package) ([]*issue.Issue, *Metri
 	// the shared ruleset when builders are unavailable (direct CheckRules path).
 	var pkgRuleset *RuleSet
 	if len(gosec.ruleBuilders) > 0 {
-		rs := gosec.buildPackageRuleset()
+		rs := gosec.buildPackageRuleset(scope)
 		pkgRuleset = &rs
 	}
 
@@ -507,8 +809,7 @@ func (gosec *Analyzer) checkRules(pkg *packages.Package) ([]*issue.Issue, *Metri
 		ruleset:           pkgRuleset,
 		issues:            make([]*issue.Issue, 0, 16),
 		stats:             stats,
-		ignoreNosec:       gosec.ignoreNosec,
-		showIgnored:       gosec.showIgnored,
+		scope:             scope,
 		trackSuppressions: gosec.trackSuppressions,
 	}
 
@@ -532,7 +833,7 @@ func (gosec *Analyzer) checkRules(pkg *packages.Package) ([]*issue.Issue, *Metri
 		gosec.logger.Println("Checking file:", checkedFile)
 		ctx := &Context{
 			FileSet:      pkg.Fset,
-			Config:       gosec.config,
+			Config:       scope.config,
 			Comments:     ast.NewCommentMap(pkg.Fset, file, file.Comments),
 			Root:         file,
 			Info:         pkg.TypesInfo,
@@ -563,13 +864,14 @@ func (gosec *Analyzer) checkRules(pkg *packages.Package) ([]*issue.Issue, *Metri
 // CheckAnalyzers runs analyzers on a given package.
 func (gosec *Analyzer) CheckAnalyzers(pkg *packages.Package) {
 	// Rely on gosec.context.Ignores being populated by CheckRules
-	issues, stats := gosec.checkAnalyzers(pkg, gosec.context.Ignores)
+	issues, stats := gosec.checkAnalyzers(pkg, gosec.context.Ignores, false)
 	gosec.issues = append(gosec.issues, issues...)
 	gosec.stats.Merge(stats)
 }
 
-// checkAnalyzers runs analyzers on a given package (Stateless API).
-func (gosec *Analyzer) checkAnalyzers(pkg *packages.Package, allIgnores ignores) ([]*issue.Issue, *Metrics) {
+// checkAnalyzers runs analyzers on a given package (Stateless API). The facts
+// of the package are only exported when exportFacts is set.
+func (gosec *Analyzer) checkAnalyzers(pkg *packages.Package, allIgnores ignores, exportFacts bool) ([]*issue.Issue, *Metrics) {
 	// significant performance improvement if no analyzers are loaded
 	if len(gosec.analyzerSet.Analyzers) == 0 {
 		return nil, &Metrics{}
@@ -590,24 +892,33 @@ func (gosec *Analyzer) checkAnalyzers(pkg *packages.Package, allIgnores ignores)
 		gosec.logger.Print(errMessage)
 		return nil, &Metrics{}
 	}
-	return gosec.checkAnalyzersWithSSA(pkg, ssaResult, allIgnores)
+	return gosec.checkAnalyzersWithSSA(pkg, ssaResult, allIgnores, exportFacts)
 }
 
 // CheckAnalyzersWithSSA runs analyzers on a given package using an existing SSA result.
 func (gosec *Analyzer) CheckAnalyzersWithSSA(pkg *packages.Package, ssaResult *buildssa.SSA) {
-	issues, stats := gosec.checkAnalyzersWithSSA(pkg, ssaResult, gosec.context.Ignores)
+	issues, stats := gosec.checkAnalyzersWithSSA(pkg, ssaResult, gosec.context.Ignores, false)
 	gosec.issues = append(gosec.issues, issues...)
 	gosec.stats.Merge(stats)
 }
 
 // checkAnalyzersWithSSA runs analyzers on a given package using an existing SSA result (Stateless API).
-func (gosec *Analyzer) checkAnalyzersWithSSA(pkg *packages.Package, ssaResult *buildssa.SSA, allIgnores ignores) ([]*issue.Issue, *Metrics) {
+// The facts of the package are only exported when exportFacts is set.
+func (gosec *Analyzer) checkAnalyzersWithSSA(pkg *packages.Package, ssaResult *buildssa.SSA, allIgnores ignores, exportFacts bool) ([]*issue.Issue, *Metrics) {
+	scope := gosec.scopeFor(pkg)
 	sharedCache := ssautil.NewPackageAnalysisCache(ssaResult)
 	ssaAnalyzerResult := &ssautil.SSAAnalyzerResult{
-		Config: gosec.Config(),
-		Logger: gosec.logger,
-		SSA:    ssaResult,
-		Shared: sharedCache,
+		Config:      scope.config,
+		Logger:      gosec.logger,
+		SSA:         ssaResult,
+		Shared:      sharedCache,
+		Truncations: &ssautil.TruncationCounter{},
+		ExportFacts: exportFacts,
+	}
+
+	if store, ok := gosec.facts.(*memoryFactStore); ok && pkg.Types != nil {
+		store.setPackagePath(pkg.Types, packageImportPath(pkg))
+		defer store.setPackagePath(pkg.Types, "")
 	}
 
 	generatedFiles := gosec.generatedFiles(pkg)
@@ -619,28 +930,11 @@ func (gosec *Analyzer) checkAnalyzersWithSSA(pkg *packages.Package, ssaResult *b
 	runner.SetLimit(max(gosec.concurrency, 1))
 
 	for index, analyzer := range gosec.analyzerSet.Analyzers {
+		if scope.deselected(analyzer.Name) && !gosec.trackSuppressions {
+			continue
+		}
 		runner.Go(func() error {
-			pass := &analysis.Pass{
-				Analyzer:     analyzer,
-				Fset:         pkg.Fset,
-				Files:        pkg.Syntax,
-				OtherFiles:   pkg.OtherFiles,
-				IgnoredFiles: pkg.IgnoredFiles,
-				Pkg:          pkg.Types,
-				TypesInfo:    pkg.TypesInfo,
-				TypesSizes:   pkg.TypesSizes,
-				ResultOf: map[*analysis.Analyzer]any{
-					buildssa.Analyzer: ssaAnalyzerResult,
-				},
-				Report:            func(d analysis.Diagnostic) {},
-				ImportObjectFact:  nil,
-				ExportObjectFact:  nil,
-				ImportPackageFact: nil,
-				ExportPackageFact: nil,
-				AllObjectFacts:    nil,
-				AllPackageFacts:   nil,
-			}
-
+			pass := gosec.newAnalysisPass(analyzer, pkg, ssaAnalyzerResult)
 			result, err := pass.Analyzer.Run(pass)
 			if err != nil {
 				gosec.logger.Printf("Error running analyzer %s: %s\n", analyzer.Name, err)
@@ -662,6 +956,9 @@ func (gosec *Analyzer) checkAnalyzersWithSSA(pkg *packages.Package, ssaResult *b
 	if err := runner.Wait(); err != nil {
 		gosec.logger.Printf("Error waiting for analyzers: %s\n", err)
 	}
+	if depth, visited, budget := ssaAnalyzerResult.Truncations.Counts(); depth+visited+budget > 0 {
+		stats.NumTruncated = &TruncationMetrics{Depth: depth, Visited: visited, Budget: budget}
+	}
 
 	for _, passIssues := range analyzerRuns {
 		for _, iss := range passIssues {
@@ -672,12 +969,101 @@ func (gosec *Analyzer) checkAnalyzersWithSSA(pkg *packages.Package, ssaResult *b
 			}
 
 			// issue filtering logic
-			issues = gosec.updateIssues(iss, issues, stats, allIgnores)
+			issues = gosec.updateIssues(iss, issues, stats, allIgnores, scope)
 		}
 	}
 	return issues, stats
 }
 
+// ExportFactsWithSSA runs the analyzers exchanging facts on a given package
+// using an existing SSA result, only to export the facts needed by the
+// packages importing it. No issue is looked for.
+func (gosec *Analyzer) ExportFactsWithSSA(pkg *packages.Package, ssaResult *buildssa.SSA) {
+	scope := gosec.scopeFor(pkg)
+	ssaAnalyzerResult := &ssautil.SSAAnalyzerResult{
+		Config:      scope.config,
+		Logger:      gosec.logger,
+		SSA:         ssaResult,
+		Shared:      ssautil.NewPackageAnalysisCache(ssaResult),
+		FactsOnly:   true,
+		ExportFacts: true,
+	}
+
+	if store, ok := gosec.facts.(*memoryFactStore); ok && pkg.Types != nil {
+		store.setPackagePath(pkg.Types, packageImportPath(pkg))
+		defer store.setPackagePath(pkg.Types, "")
+	}
+
+	runner := errgroup.Group{}
+	runner.SetLimit(max(gosec.concurrency, 1))
+	for _, analyzer := range gosec.analyzerSet.Analyzers {
+		if len(analyzer.FactTypes) == 0 {
+			continue
+		}
+		runner.Go(func() error {
+			if _, err := analyzer.Run(gosec.newAnalysisPass(analyzer, pkg, ssaAnalyzerResult)); err != nil {
+				gosec.logger.Printf("Error exporting the facts of analyzer %s: %s\n", analyzer.Name, err)
+			}
+			return nil
+		})
+	}
+	if err := runner.Wait(); err != nil {
+		gosec.logger.Printf("Error waiting for analyzers: %s\n", err)
+	}
+}
+
+// newAnalysisPass creates the pass running an analyzer on a package, whose
+// facts are kept in the fact store of gosec.
+func (gosec *Analyzer) newAnalysisPass(analyzer *analysis.Analyzer, pkg *packages.Package, ssaAnalyzerResult *ssautil.SSAAnalyzerResult) *analysis.Pass {
+	return &analysis.Pass{
+		Analyzer:     analyzer,
+		Fset:         pkg.Fset,
+		Files:        pkg.Syntax,
+		OtherFiles:   pkg.OtherFiles,
+		IgnoredFiles: pkg.IgnoredFiles,
+		Pkg:          pkg.Types,
+		TypesInfo:    pkg.TypesInfo,
+		TypesSizes:   pkg.TypesSizes,
+		ResultOf: map[*analysis.Analyzer]any{
+			buildssa.Analyzer: ssaAnalyzerResult,
+		},
+		Report: func(d analysis.Diagnostic) {},
+		ImportObjectFact: func(obj types.Object, fact analysis.Fact) bool {
+			return gosec.facts.ImportObjectFact(analyzer, obj, fact)
+		},
+		ExportObjectFact: func(obj types.Object, fact analysis.Fact) {
+			gosec.facts.ExportObjectFact(analyzer, obj, fact)
+		},
+		ImportPackageFact: nil,
+		ExportPackageFact: nil,
+		AllObjectFacts:    nil,
+		AllPackageFacts:   nil,
+	}
+}
+
+// packageImportPath returns the import path of a package, resolving it from
+// the enclosing module for the packages loaded from a list of files.
+func packageImportPath(pkg *packages.Package) string {
+	if pkg.PkgPath != "command-line-arguments" || len(pkg.GoFiles) == 0 {
+		return pkg.PkgPath
+	}
+	dir := filepath.Dir(pkg.GoFiles[0])
+	modRoot := FindModuleRoot(dir)
+	if modRoot == "" {
+		return pkg.PkgPath
+	}
+	data, err := os.ReadFile(filepath.Clean(filepath.Join(modRoot, "go.mod")))
+	if err != nil {
+		return pkg.PkgPath
+	}
+	modPath := modfile.ModulePath(data)
+	rel, err := filepath.Rel(modRoot, dir)
+	if modPath == "" || err != nil {
+		return pkg.PkgPath
+	}
+	return path.Join(modPath, filepath.ToSlash(rel))
+}
+
 func (gosec *Analyzer) generatedFiles(pkg *packages.Package) map[string]bool {
 	generatedFiles := map[string]bool{}
 	for _, file := range pkg.Syntax {
@@ -877,8 +1263,7 @@ type astVisitor struct {
 	context           *Context
 	issues            []*issue.Issue
 	stats             *Metrics
-	ignoreNosec       bool
-	showIgnored       bool
+	scope             *packageScope
 	trackSuppressions bool
 }
 
@@ -904,7 +1289,7 @@ func (v *astVisitor) Visit(n ast.Node) ast.Visitor {
 			file = path.Base(file)
 			v.gosec.logger.Printf("Rule error: %v => %s (%s:%d)\n", reflect.TypeOf(rule), err, file, line)
 		}
-		v.issues = v.gosec.updateIssues(issue, v.issues, v.stats, v.context.Ignores)
+		v.issues = v.gosec.updateIssues(issue, v.issues, v.stats, v.context.Ignores, v.scope)
 	}
 	return v
 }
@@ -918,7 +1303,7 @@ func (v *astVisitor) updateIgnores() {
 
 // updateIgnoredRulesForNode parses comments for a specific node and updates ignored rules.
 func (v *astVisitor) updateIgnoredRulesForNode(n ast.Node) {
-	ignoredRules, group := v.ignore(n)
+	ignoredRules, group, directive, expired := v.ignore(n)
 	if len(ignoredRules) > 0 {
 		if v.context.Ignores == nil {
 			v.context.Ignores = newIgnores()
@@ -948,36 +1333,43 @@ func (v *astVisitor) updateIgnoredRulesForNode(n ast.Node) {
 			v.context.FileSet.File(startPos).Name(),
 			line,
 			ignoredRules,
+			directive,
+			expired,
 		)
 	}
 }
 
-// ignore checks if a node is tagged with a nosec comment and returns the suppressed rules.
-func (v *astVisitor) ignore(n ast.Node) (map[string]issue.SuppressionInfo, *ast.CommentGroup) {
-	if v.ignoreNosec {
-		return nil, nil
+// ignore checks if a node is tagged with a nosec comment and returns the
+// suppressed rules, and the directive tracking their uses. The suppressions of
+// a directive past its until date are returned as expired, without directive.
+func (v *astVisitor) ignore(n ast.Node) (map[string]issue.SuppressionInfo, *ast.CommentGroup, *noSecDirective, bool) {
+	if v.scope.ignoreNosec {
+		return nil, nil, nil, false
 	}
 	groups, ok := v.context.Comments[n]
 	if !ok {
-		return nil, nil
+		return nil, nil, nil, false
 	}
 
-	noSecDefaultTag, err := v.gosec.config.GetGlobal(Nosec)
+	noSecDefaultTag, err := v.context.Config.GetGlobal(Nosec)
 	if err != nil {
 		noSecDefaultTag = NoSecTag(string(Nosec))
 	} else {
 		noSecDefaultTag = NoSecTag(noSecDefaultTag)
 	}
-	noSecAlternativeTag, err := v.gosec.config.GetGlobal(NoSecAlternative)
+	noSecAlternativeTag, err := v.context.Config.GetGlobal(NoSecAlternative)
 	if err != nil {
 		noSecAlternativeTag = noSecDefaultTag
 	} else {
 		noSecAlternativeTag = NoSecTag(noSecAlternativeTag)
 	}
 
-	requireRules, _ := v.gosec.config.IsGlobalEnabled(NoSecRequireRules)
-	requireJustification, _ := v.gosec.config.IsGlobalEnabled(NoSecRequireJustification)
+	requireRules, _ := v.context.Config.IsGlobalEnabled(NoSecRequireRules)
+	requireJustification, _ := v.context.Config.IsGlobalEnabled(NoSecRequireJustification)
+	requireTicket, _ := v.context.Config.IsGlobalEnabled(NoSecRequireTicket)
 
+	var expiredIgnores map[string]issue.SuppressionInfo
+	var expiredGroup *ast.CommentGroup
 	for _, group := range groups {
 		found, args := findNoSecDirective(group, noSecDefaultTag, noSecAlternativeTag)
 		if !found {
@@ -993,11 +1385,19 @@ func (v *astVisitor) ignore(n ast.Node) (map[string]issue.SuppressionInfo, *ast.
 			args = args[:idx]
 		}
 
+		metadata, err := parseSuppressionMetadata(justification)
+		if err != nil {
+			v.reportInvalidDirective(group, err.Error())
+			continue
+		}
+
 		directive := strings.TrimSpace(args)
 		ignores := make(map[string]issue.SuppressionInfo)
 		suppression := issue.SuppressionInfo{
 			Kind:          "inSource",
 			Justification: justification,
+			Ticket:        metadata.ticket,
+			Until:         metadata.until,
 		}
 
 		// Manually parse identifiers starting with 'G' followed by 3 digits.
@@ -1035,13 +1435,31 @@ func (v *astVisitor) ignore(n ast.Node) (map[string]issue.SuppressionInfo, *ast.
 			v.reportInvalidDirective(group, "missing justification (expected `-- <reason>`); required by -nosec-require-justification")
 			continue
 		}
+		if requireTicket && metadata.ticket == "" {
+			v.reportInvalidDirective(group, "missing ticket (expected `-- ticket=<id>`); required by -nosec-require-ticket")
+			continue
+		}
 
 		if naked {
 			ignores[aliasOfAllRules] = suppression
 		}
-		return ignores, group
+		if metadata.expired(time.Now()) {
+			// The findings of the line are reported again, along with the
+			// expired suppression.
+			pos := v.context.FileSet.Position(group.Pos())
+			v.gosec.logger.Printf("Expired nosec directive at %s:%d (until %s)", pos.Filename, pos.Line, metadata.until)
+			if expiredIgnores == nil {
+				expiredIgnores, expiredGroup = ignores, group
+			}
+			continue
+		}
+		tracked := newNoSecDirective(v.context.FileSet.File(group.Pos()), group, ignores, noSecDefaultTag, noSecAlternativeTag)
+		return ignores, group, tracked, false
 	}
-	return nil, nil
+	if expiredIgnores != nil {
+		return expiredIgnores, expiredGroup, nil, true
+	}
+	return nil, nil, nil, false
 }
 
 // reportInvalidDirective records an error for a malformed nosec directive so
@@ -1056,20 +1474,63 @@ func (v *astVisitor) reportInvalidDirective(group *ast.CommentGroup, reason stri
 		fmt.Errorf("invalid nosec directive: %s", reason))
 }
 
+// suppressionMetadata is the structured metadata of the justification of a
+// nosec directive, such as "-- until=2026-12-31 ticket=SEC-123 legacy code".
+type suppressionMetadata struct {
+	until  string
+	ticket string
+}
+
+// parseSuppressionMetadata extracts the until= and ticket= fields of a
+// justification.
+func parseSuppressionMetadata(justification string) (suppressionMetadata, error) {
+	var metadata suppressionMetadata
+	for _, field := range strings.Fields(justification) {
+		key, value, found := strings.Cut(field, "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "until":
+			if _, err := time.Parse(time.DateOnly, value); err != nil {
+				return metadata, fmt.Errorf("invalid until date %q (expected YYYY-MM-DD)", value)
+			}
+			metadata.until = value
+		case "ticket":
+			if value == "" {
+				return metadata, errors.New("empty ticket (expected `ticket=<id>`)")
+			}
+			metadata.ticket = value
+		}
+	}
+	return metadata, nil
+}
+
+// expired reports whether the until date of the suppression is past. The
+// suppression applies until the end of that day.
+func (m suppressionMetadata) expired(now time.Time) bool {
+	return m.until != "" && now.Format(time.DateOnly) > m.until
+}
+
 // updateIssues updates the issues list with the given issue, handling suppressions.
-func (gosec *Analyzer) updateIssues(issue *issue.Issue, issues []*issue.Issue, stats *Metrics, allIgnores ignores) []*issue.Issue {
+func (gosec *Analyzer) updateIssues(issue *issue.Issue, issues []*issue.Issue, stats *Metrics, allIgnores ignores, scope *packageScope) []*issue.Issue {
 	if issue != nil {
-		suppressions, ignored := getSuppressions(allIgnores, issue.File, issue.Line, issue.RuleID, gosec.ruleset, gosec.analyzerSet)
-		if gosec.showIgnored {
+		// The scores are final before the issues are filtered by them.
+		scope.scores.Apply(issue)
+		suppressions, ignored := getSuppressions(allIgnores, issue.File, issue.Line, issue.RuleID, gosec.ruleset, gosec.analyzerSet, scope)
+		if !ignored {
+			issue.ExpiredSuppressions = getExpiredSuppressions(allIgnores, issue.File, issue.Line, issue.RuleID)
+		}
+		if scope.showIgnored {
 			issue.NoSec = ignored
 		}
-		if !ignored || !gosec.showIgnored {
+		if !ignored || !scope.showIgnored {
 			stats.NumFound++
 		}
 		if ignored && gosec.trackSuppressions {
 			issue.WithSuppressions(suppressions)
 			issues = append(issues, issue)
-		} else if !ignored || gosec.showIgnored || gosec.ignoreNosec {
+		} else if !ignored || scope.showIgnored || scope.ignoreNosec {
 			issues = append(issues, issue)
 		}
 	}
@@ -1077,15 +1538,20 @@ func (gosec *Analyzer) updateIssues(issue *issue.Issue, issues []*issue.Issue, s
 }
 
 // getSuppressions returns the suppressions for a given issue location and rule ID.
-func getSuppressions(ignores ignores, file, line, ruleID string, ruleset RuleSet, analyzerSet *analyzers.AnalyzerSet) ([]issue.SuppressionInfo, bool) {
+func getSuppressions(ignores ignores, file, line, ruleID string, ruleset RuleSet, analyzerSet *analyzers.AnalyzerSet, scope *packageScope) ([]issue.SuppressionInfo, bool) {
 	ignoredRules := ignores.get(file, line)
 	generalSuppressions, generalIgnored := ignoredRules[aliasOfAllRules]
 	ruleSuppressions, ruleIgnored := ignoredRules[ruleID]
 	ignored := generalIgnored || ruleIgnored
 	suppressions := append(generalSuppressions, ruleSuppressions...)
+	if ignored {
+		for _, directive := range ignores.find(file, line).directives {
+			directive.markUsed(ruleID)
+		}
+	}
 
 	// Track external suppressions of this rule.
-	if ruleset.IsRuleSuppressed(ruleID) || analyzerSet.IsSuppressed(ruleID) {
+	if ruleset.IsRuleSuppressed(ruleID) || analyzerSet.IsSuppressed(ruleID) || scope.deselected(ruleID) {
 		ignored = true
 		suppressions = append(suppressions, issue.SuppressionInfo{
 			Kind:          "external",
@@ -1095,8 +1561,19 @@ func getSuppressions(ignores ignores, file, line, ruleID string, ruleset RuleSet
 	return suppressions, ignored
 }
 
-// Report returns the current issues discovered and the metrics about the scan
+// getExpiredSuppressions returns the suppressions of the expired directives
+// which would otherwise apply to a given issue location and rule ID.
+func getExpiredSuppressions(ignores ignores, file, line, ruleID string) []issue.SuppressionInfo {
+	ig := ignores.find(file, line)
+	if ig == nil {
+		return nil
+	}
+	return append(slices.Clone(ig.expired[aliasOfAllRules]), ig.expired[ruleID]...)
+}
+
+// Report returns the current issues discovered, with their fingerprints set, and the metrics about the scan
 func (gosec *Analyzer) Report() ([]*issue.Issue, *Metrics, map[string][]Error) {
+	issue.NewFingerprinter().SetFingerprints(gosec.issues)
 	return gosec.issues, gosec.stats, gosec.errors
 }
 
@@ -1109,4 +1586,5 @@ func (gosec *Analyzer) Reset() {
 	gosec.ruleBuilders = nil
 	gosec.ruleSuppressed = nil
 	gosec.analyzerSet = analyzers.NewAnalyzerSet()
+	gosec.facts = NewFactStore()
 }
@@ -30,7 +30,7 @@ func BenchmarkTaintPackageAnalyzers_SharedCache(b *testing.B) {
 
 	b.ResetTimer()
 	for range b.N {
-		issues, stats := analyzer.checkAnalyzersWithSSA(pkg, ssaResult, nil)
+		issues, stats := analyzer.checkAnalyzersWithSSA(pkg, ssaResult, nil, false)
 		if stats == nil {
 			b.Fatal("stats is nil")
 		}
@@ -5,12 +5,14 @@ import (
 	"go/types"
 	"io"
 	"log"
+	"path/filepath"
 	"testing"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/packages"
 
+	"github.com/securego/gosec/v2/internal/ssautil"
 	"github.com/securego/gosec/v2/issue"
 )
 
@@ -18,7 +20,7 @@ func TestCheckAnalyzersShortCircuitsWithoutAnalyzers(t *testing.T) {
 	t.Parallel()
 
 	a := NewAnalyzer(NewConfig(), false, false, false, 1, log.New(io.Discard, "", 0))
-	issues, stats := a.checkAnalyzers(nil, nil)
+	issues, stats := a.checkAnalyzers(nil, nil, false)
 
 	if issues != nil {
 		t.Fatalf("expected nil issues when no analyzers are loaded")
@@ -38,7 +40,7 @@ func TestCheckAnalyzersHandlesSSABuildFailure(t *testing.T) {
 	a.analyzerSet.Register(&analysis.Analyzer{Name: "dummy", Run: func(*analysis.Pass) (any, error) { return nil, nil }}, false)
 
 	pkg := &packages.Package{Name: "broken"}
-	issues, stats := a.checkAnalyzers(pkg, nil)
+	issues, stats := a.checkAnalyzers(pkg, nil, false)
 
 	if len(issues) != 0 {
 		t.Fatalf("expected no issues when SSA build fails")
@@ -78,6 +80,72 @@ func TestCheckAnalyzersWithSSAWrapperMergesIssues(t *testing.T) {
 	}
 }
 
+type testFact struct{}
+
+func (*testFact) AFact() {}
+
+func TestExportFactsWithSSARunsOnlyTheAnalyzersWithFacts(t *testing.T) {
+	t.Parallel()
+
+	a := NewAnalyzer(NewConfig(), false, false, false, 1, log.New(io.Discard, "", 0))
+	var factsOnly, ranWithoutFacts bool
+	a.analyzerSet.Register(&analysis.Analyzer{
+		Name:      "withfacts",
+		FactTypes: []analysis.Fact{new(testFact)},
+		Run: func(pass *analysis.Pass) (any, error) {
+			result, ok := pass.ResultOf[buildssa.Analyzer].(*ssautil.SSAAnalyzerResult)
+			factsOnly = ok && result.FactsOnly
+			return []*issue.Issue{{RuleID: "T998"}}, nil
+		},
+	}, false)
+	a.analyzerSet.Register(&analysis.Analyzer{
+		Name: "withoutfacts",
+		Run: func(*analysis.Pass) (any, error) {
+			ranWithoutFacts = true
+			return nil, nil
+		},
+	}, false)
+
+	a.ExportFactsWithSSA(&packages.Package{Name: "pkg"}, &buildssa.SSA{})
+	issues, _, _ := a.Report()
+
+	if !factsOnly {
+		t.Fatalf("expected the analyzer with facts to run for its facts only")
+	}
+	if ranWithoutFacts {
+		t.Fatalf("unexpected run of the analyzer without facts")
+	}
+	if len(issues) != 0 {
+		t.Fatalf("unexpected issues: %v", issues)
+	}
+}
+
+func TestScopeForCompilesTheScoreOverridesOnce(t *testing.T) {
+	t.Parallel()
+
+	conf := NewConfig()
+	conf.SetScoreOverrides([]ScoreOverride{{Rule: "G104", Paths: []string{"**/main.go"}, Severity: "low"}})
+	a := NewAnalyzer(conf, false, false, false, 1, log.New(io.Discard, "", 0))
+	a.SetPackageConfig("pkg", conf)
+	dir, err := filepath.Abs("pkg")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pkg := range []*packages.Package{nil, {GoFiles: []string{filepath.Join(dir, "main.go")}}} {
+		scope := a.scopeFor(pkg)
+		if scope.scores == nil {
+			t.Fatalf("expected the score overrides to be compiled")
+		}
+		if again := a.scopeFor(pkg); again.scores != scope.scores {
+			t.Fatalf("expected the score overrides to be compiled once")
+		}
+	}
+	if a.scopeFor(nil) == a.scopeFor(&packages.Package{GoFiles: []string{filepath.Join(dir, "main.go")}}) {
+		t.Fatalf("expected the package configuration to have its own scope")
+	}
+}
+
 func TestBuildSSANilPackage(t *testing.T) {
 	t.Parallel()
 
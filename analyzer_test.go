@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"go/build"
 	"log"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -190,6 +192,157 @@ func main() {
 			Expect(metrics.NumFiles).To(Equal(2))
 		})
 
+		It("should follow taint flows into the other analyzed packages", func() {
+			analyzer.LoadAnalyzers(analyzers.Generate(false, analyzers.NewAnalyzerFilter(false, "G706")).AnalyzersInfo())
+			module := GinkgoT().TempDir()
+			files := map[string]string{
+				"go.mod": "module example.com/crosspkg\n\ngo 1.22\n",
+				"lib/lib.go": `
+				package lib
+				import "log"
+				func Audit(msg string) {
+					log.Println("audit:", msg)
+				}`,
+				"app/app.go": `
+				package app
+				import (
+					"net/http"
+					"example.com/crosspkg/lib"
+				)
+				func Handler(w http.ResponseWriter, r *http.Request) {
+					lib.Audit(r.URL.Query().Get("name"))
+				}`,
+			}
+			for name, content := range files {
+				Expect(os.MkdirAll(filepath.Dir(filepath.Join(module, name)), 0o750)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(module, name), []byte(content), 0o600)).To(Succeed())
+			}
+
+			// The importing package comes first to check that the packages are
+			// analyzed in dependency order.
+			err := analyzer.Process(buildTags, filepath.Join(module, "app"), filepath.Join(module, "lib"))
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := analyzer.Report()
+			Expect(issues).To(HaveLen(1))
+			Expect(issues[0].RuleID).To(Equal("G706"))
+			Expect(issues[0].File).To(HaveSuffix(filepath.Join("app", "app.go")))
+		})
+
+		It("should count the functions truncated by the taint analysis limits", func() {
+			config := gosec.NewConfig()
+			config[gosec.TaintLimitsKey] = map[string]any{"max-visited": 1}
+			analyzer.SetConfig(config)
+			analyzer.LoadAnalyzers(analyzers.Generate(false, analyzers.NewAnalyzerFilter(false, "G706")).AnalyzersInfo())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("main.go", `
+				package main
+				import (
+					"log"
+					"net/http"
+				)
+				func handler(w http.ResponseWriter, r *http.Request) {
+					log.Println(r.URL.Query().Get("name"))
+				}
+				func main() {
+					http.HandleFunc("/", handler)
+				}`)
+			Expect(pkg.Build()).To(Succeed())
+			Expect(analyzer.Process(buildTags, pkg.Path)).To(Succeed())
+			issues, metrics, _ := analyzer.Report()
+			Expect(issues).To(BeEmpty())
+			Expect(metrics.NumTruncated).NotTo(BeNil())
+			Expect(metrics.NumTruncated.Visited).To(BeNumerically(">", 0))
+		})
+
+		It("should report the critical taint findings with the critical severity", func() {
+			analyzer.LoadAnalyzers(analyzers.Generate(false, analyzers.NewAnalyzerFilter(false, "G702")).AnalyzersInfo())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("main.go", testutils.SampleCodeG702[0].Code[0])
+			Expect(pkg.Build()).To(Succeed())
+			Expect(analyzer.Process(buildTags, pkg.Path)).To(Succeed())
+			issues, _, _ := analyzer.Report()
+			Expect(issues).To(HaveLen(1))
+			Expect(issues[0].Severity).To(Equal(issue.Critical))
+		})
+
+		It("should override the scores of the rules and the taint analyzers", func() {
+			config := gosec.NewConfig()
+			config.SetScoreOverrides([]gosec.ScoreOverride{
+				{Rule: "G401", Severity: "low", Confidence: "low"},
+				{Rule: "G706", Paths: []string{"**/main.go"}, Severity: "low"},
+				{Rule: "G706", Paths: []string{"**/other.go"}, Severity: "high"},
+			})
+			analyzer.SetConfig(config)
+			analyzer.LoadRules(rules.Generate(false, rules.NewRuleFilter(false, "G401")).RulesInfo())
+			analyzer.LoadAnalyzers(analyzers.Generate(false, analyzers.NewAnalyzerFilter(false, "G706")).AnalyzersInfo())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("main.go", `
+				package main
+				import (
+					"crypto/md5"
+					"log"
+					"net/http"
+				)
+				func handler(w http.ResponseWriter, r *http.Request) {
+					log.Println(r.URL.Query().Get("name"))
+					md5.Sum([]byte("data"))
+				}
+				func main() {
+					http.HandleFunc("/", handler)
+				}`)
+			Expect(pkg.Build()).To(Succeed())
+			Expect(analyzer.Process(buildTags, pkg.Path)).To(Succeed())
+			issues, _, _ := analyzer.Report()
+			Expect(issues).To(ContainElement(And(HaveField("RuleID", "G401"), HaveField("Severity", issue.Low), HaveField("Confidence", issue.Low))))
+			Expect(issues).To(ContainElement(And(HaveField("RuleID", "G706"), HaveField("Severity", issue.Low), HaveField("Confidence", issue.High))))
+		})
+
+		It("should apply the configuration set for a package directory", func() {
+			source := testutils.SampleCodeG401[0].Code[0]
+			analyzer.LoadRules(rules.Generate(false, rules.NewRuleFilter(false, "G401", "G101")).RulesInfo())
+			legacy := testutils.NewTestPackage()
+			defer legacy.Close()
+			legacy.AddFile("md5.go", source)
+			Expect(legacy.Build()).To(Succeed())
+			control := testutils.NewTestPackage()
+			defer control.Close()
+			control.AddFile("md5.go", source)
+			Expect(control.Build()).To(Succeed())
+
+			config := gosec.NewConfig()
+			config.SetGlobal(gosec.ExcludeRules, "G401")
+			analyzer.SetPackageConfig(legacy.Path, config)
+			Expect(analyzer.Process(buildTags, legacy.Path, control.Path)).To(Succeed())
+			issues, _, _ := analyzer.Report()
+			Expect(issues).NotTo(BeEmpty())
+			for _, issue := range issues {
+				Expect(issue.RuleID).To(Equal("G401"))
+				Expect(issue.File).To(HavePrefix(control.Path))
+			}
+		})
+
+		It("should report the rules disabled for a package as suppressed when tracking suppressions", func() {
+			analyzer = gosec.NewAnalyzer(nil, tests, false, true, 1, logger)
+			analyzer.LoadRules(rules.Generate(true, rules.NewRuleFilter(false, "G401")).RulesInfo())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("md5.go", testutils.SampleCodeG401[0].Code[0])
+			Expect(pkg.Build()).To(Succeed())
+
+			config := gosec.NewConfig()
+			config.SetGlobal(gosec.IncludeRules, "G101")
+			analyzer.SetPackageConfig(pkg.Path, config)
+			Expect(analyzer.Process(buildTags, pkg.Path)).To(Succeed())
+			issues, _, _ := analyzer.Report()
+			Expect(issues).NotTo(BeEmpty())
+			for _, issue := range issues {
+				Expect(issue.Suppressions).To(ContainElement(HaveField("Kind", "external")))
+			}
+		})
+
 		It("should find errors when nosec is not in use", func() {
 			sample := testutils.SampleCodeG401[0]
 			source := sample.Code[0]
@@ -1922,6 +2075,29 @@ func main() {
 			Expect(issues[0].Suppressions[0].Justification).To(Equal("Justification"))
 		})
 
+		It("should report the ticket and the expiry date of the suppression", func() {
+			sample := testutils.SampleCodeG401[0]
+			source := sample.Code[0]
+			analyzer.LoadRules(rules.Generate(false, rules.NewRuleFilter(false, "G401")).RulesInfo())
+
+			nosecPackage := testutils.NewTestPackage()
+			defer nosecPackage.Close()
+			nosecSource := strings.Replace(source, "h := md5.New()", "h := md5.New() //#nosec G401 -- until=2999-12-31 ticket=SEC-123 Justification", 1)
+			nosecPackage.AddFile("md5.go", nosecSource)
+			err := nosecPackage.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			err = analyzer.Process(buildTags, nosecPackage.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := analyzer.Report()
+			Expect(issues).To(HaveLen(sample.Errors))
+			Expect(issues[0].Suppressions).To(Equal([]issue.SuppressionInfo{{
+				Kind:          "inSource",
+				Justification: "until=2999-12-31 ticket=SEC-123 Justification",
+				Ticket:        "SEC-123",
+				Until:         "2999-12-31",
+			}}))
+		})
+
 		It("should not report an error if the violation is suppressed", func() {
 			sample := testutils.SampleCodeG401[0]
 			source := sample.Code[0]
@@ -2701,6 +2877,56 @@ func main() {
 			Expect(errCount(errs)).To(Equal(0))
 		})
 
+		It("suppresses directive until its expiry date", func() {
+			issues, errs := runAnalyzer("//#nosec G401 -- until=2999-12-31 migration pending", nil)
+			Expect(issues).Should(BeEmpty())
+			Expect(errCount(errs)).To(Equal(0))
+		})
+
+		It("does not suppress directive past its expiry date", func() {
+			issues, errs := runAnalyzer("//#nosec G401 -- until=2020-01-31 migration pending", nil)
+			Expect(issues).ShouldNot(BeEmpty())
+			Expect(errCount(errs)).To(Equal(0))
+		})
+
+		It("reports the expired directive with the findings it no longer suppresses", func() {
+			issues, _ := runAnalyzer("//#nosec G401 -- until=2020-01-31 ticket=SEC-123 migration pending", nil)
+			Expect(issues).To(ContainElement(And(
+				HaveField("Suppressions", BeEmpty()),
+				HaveField("ExpiredSuppressions", ConsistOf(issue.SuppressionInfo{
+					Kind:          "inSource",
+					Justification: "until=2020-01-31 ticket=SEC-123 migration pending",
+					Ticket:        "SEC-123",
+					Until:         "2020-01-31",
+				})),
+			)))
+
+			issues, _ = runAnalyzer("//#nosec G401 -- until=2999-12-31 ticket=SEC-123 migration pending", nil)
+			Expect(issues).To(BeEmpty())
+		})
+
+		It("does not suppress directive with an invalid expiry date, and reports an error", func() {
+			issues, errs := runAnalyzer("//#nosec G401 -- until=31/12/2999", nil)
+			Expect(issues).ShouldNot(BeEmpty())
+			Expect(errCount(errs)).To(Equal(1))
+		})
+
+		It("does not suppress directive without ticket when require-ticket is enabled", func() {
+			issues, errs := runAnalyzer("//#nosec G401 -- false positive in test", map[gosec.GlobalOption]string{
+				gosec.NoSecRequireTicket: "true",
+			})
+			Expect(issues).ShouldNot(BeEmpty())
+			Expect(errCount(errs)).To(Equal(1))
+		})
+
+		It("suppresses directive with ticket when require-ticket is enabled", func() {
+			issues, errs := runAnalyzer("//#nosec G401 -- ticket=SEC-123 false positive in test", map[gosec.GlobalOption]string{
+				gosec.NoSecRequireTicket: "true",
+			})
+			Expect(issues).Should(BeEmpty())
+			Expect(errCount(errs)).To(Equal(0))
+		})
+
 		It("enforces the same rules for the //gosec:disable form", func() {
 			issues, errs := runAnalyzer("//gosec:disable", map[gosec.GlobalOption]string{
 				gosec.NoSecRequireRules: "true",
@@ -2709,4 +2935,120 @@ func main() {
 			Expect(errCount(errs)).To(Equal(1))
 		})
 	})
+
+	Context("when reporting the fixes of the file permissions", func() {
+		It("should restrict the literal modes to the configured mode", func() {
+			customAnalyzer := gosec.NewAnalyzer(gosec.NewConfig(), tests, false, false, 1, logger)
+			customAnalyzer.LoadRules(rules.Generate(false, rules.NewRuleFilter(false, "G306")).RulesInfo())
+
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("perms.go", `package main
+
+import "os"
+
+func main() {
+	_ = os.WriteFile("f", []byte("x"), 0o644)
+	_ = os.WriteFile("g", []byte("x"), os.ModePerm)
+}
+`)
+			Expect(pkg.Build()).Should(Succeed())
+			Expect(customAnalyzer.Process(buildTags, pkg.Path)).Should(Succeed())
+			issues, _, _ := customAnalyzer.Report()
+
+			Expect(issues).To(HaveLen(2))
+			Expect(issues[0].Line).To(Equal("6"))
+			Expect(issues[0].Col).To(Equal("6"))
+			Expect(issues[0].EndCol).To(Equal("43"))
+			Expect(issues[0].Fixes).To(Equal([]issue.Fix{{
+				Description:  "Restrict the permissions to 0600",
+				Replacements: []issue.Replacement{{StartLine: 6, StartColumn: 37, EndLine: 6, EndColumn: 42, Text: "0600"}},
+			}}))
+			Expect(issues[1].Line).To(Equal("7"))
+			Expect(issues[1].Fixes).To(BeEmpty())
+		})
+	})
+
+	Context("when reporting unused nosec directives", func() {
+		// runAnalyzer runs G401 on its sample, whose md5 line and defer line
+		// carry the given directive comments.
+		runAnalyzer := func(md5Directive, deferDirective string, ruleIDs []string, opts map[gosec.GlobalOption]string) []*issue.Issue {
+			source := testutils.SampleCodeG401[0].Code[0]
+
+			cfg := gosec.NewConfig()
+			for k, v := range opts {
+				cfg.SetGlobal(k, v)
+			}
+			customAnalyzer := gosec.NewAnalyzer(cfg, tests, false, false, 1, logger)
+			customAnalyzer.LoadRules(rules.Generate(false, rules.NewRuleFilter(false, ruleIDs...)).RulesInfo())
+
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			src := strings.Replace(source, "h := md5.New()", "h := md5.New() "+md5Directive, 1)
+			src = strings.Replace(src, "defer f.Close()", "defer f.Close() "+deferDirective, 1)
+			pkg.AddFile("md5.go", src)
+			Expect(pkg.Build()).Should(Succeed())
+			Expect(customAnalyzer.Process(buildTags, pkg.Path)).Should(Succeed())
+			issues, _, _ := customAnalyzer.Report()
+
+			var unused []*issue.Issue
+			for _, iss := range issues {
+				if iss.RuleID == gosec.UnusedNoSecRuleID {
+					unused = append(unused, iss)
+				}
+			}
+			return unused
+		}
+
+		// reportUnused enables the diagnostics, along with the given options.
+		reportUnused := func(opts map[gosec.GlobalOption]string) map[gosec.GlobalOption]string {
+			enabled := map[gosec.GlobalOption]string{gosec.NoSecReportUnused: "true"}
+			for k, v := range opts {
+				enabled[k] = v
+			}
+			return enabled
+		}
+
+		It("should not report the directives by default", func() {
+			unused := runAnalyzer("//#nosec G401", "//#nosec", []string{"G401"}, nil)
+			Expect(unused).To(BeEmpty())
+		})
+
+		It("should report the directives suppressing no issue", func() {
+			unused := runAnalyzer("//#nosec G401", "//#nosec", []string{"G401"}, reportUnused(nil))
+			Expect(unused).To(HaveLen(1))
+			Expect(unused[0].Line).To(Equal("17"))
+			Expect(unused[0].What).To(Equal("Unused nosec directive: no issue to suppress"))
+			Expect(unused[0].Fixes).To(Equal([]issue.Fix{{
+				Description:  "Remove the unused nosec directive",
+				Replacements: []issue.Replacement{{StartLine: 17, StartColumn: 17, EndLine: 17, EndColumn: 26}},
+			}}))
+		})
+
+		It("should report the rules of a directive reporting no issue on its line", func() {
+			unused := runAnalyzer("//#nosec G401, G501 -- legacy code", "//#nosec G401", []string{"G401", "G501"}, reportUnused(nil))
+			Expect(unused).To(HaveLen(2))
+			Expect(unused[0].What).To(Equal("Unused nosec directive: no G401 issue to suppress"))
+			Expect(unused[1].What).To(Equal("Unused nosec directive: no G501 issue to suppress"))
+			Expect(unused[1].Fixes[0].Description).To(Equal("Remove G501 from the nosec directive"))
+			Expect(unused[1].Fixes[0].Replacements[0].Text).To(Equal("//#nosec G401 -- legacy code"))
+		})
+
+		It("should not report the rules which do not run", func() {
+			unused := runAnalyzer("//#nosec G401 G304", "//#nosec G304", []string{"G401"}, reportUnused(nil))
+			Expect(unused).To(BeEmpty())
+
+			unused = runAnalyzer("//#nosec G401", "//#nosec", []string{"G401"}, reportUnused(map[gosec.GlobalOption]string{
+				gosec.ExcludeRules: "G104",
+			}))
+			Expect(unused).To(BeEmpty())
+		})
+
+		It("should not report the directives when the diagnostics are excluded", func() {
+			unused := runAnalyzer("//#nosec G401", "//#nosec", []string{"G401"}, reportUnused(map[gosec.GlobalOption]string{
+				gosec.ExcludeRules: gosec.UnusedNoSecRuleID,
+			}))
+			Expect(unused).To(BeEmpty())
+		})
+	})
 })
@@ -174,10 +174,22 @@ var defaultAnalyzers = []AnalyzerDefinition{
 
 // Generate the list of analyzers to use
 func Generate(trackSuppressions bool, filters ...AnalyzerFilter) *AnalyzerList {
+	return GenerateWith(trackSuppressions, nil, filters...)
+}
+
+// GenerateWith generates the list of analyzers to use, including the additional
+// analyzer definitions (e.g. user-defined taint rules) alongside the defaults.
+// An additional definition with the ID of a default analyzer replaces it.
+// The filters are applied to both the default and the additional analyzers.
+func GenerateWith(trackSuppressions bool, additional []AnalyzerDefinition, filters ...AnalyzerFilter) *AnalyzerList {
 	analyzerMap := make(map[string]AnalyzerDefinition)
 	analyzerSuppressedMap := make(map[string]bool)
 
-	for _, analyzer := range defaultAnalyzers {
+	definitions := make([]AnalyzerDefinition, 0, len(defaultAnalyzers)+len(additional))
+	definitions = append(definitions, defaultAnalyzers...)
+	definitions = append(definitions, additional...)
+
+	for _, analyzer := range definitions {
 		analyzerSuppressedMap[analyzer.ID] = false
 		addToAnalyzerList := true
 		for _, filter := range filters {
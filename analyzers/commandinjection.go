@@ -23,7 +23,8 @@ import (
 // CommandInjection returns a configuration for detecting command injection vulnerabilities.
 func CommandInjection() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
+		Class: "command",
+		Sources: append([]taint.Source{
 			// Type sources: tainted when received as parameters
 			{Package: "net/http", Name: "Request", Pointer: true},
 			{Package: "bufio", Name: "Reader", Pointer: true},
@@ -32,7 +33,7 @@ func CommandInjection() taint.Config {
 			// Function sources
 			{Package: "os", Name: "Args", IsFunc: true},
 			{Package: "os", Name: "Getenv", IsFunc: true},
-		},
+		}, frameworkSources()...),
 		Sinks: []taint.Sink{
 			// Detect at command creation, not execution (avoids double detection)
 			{Package: "os/exec", Method: "Command"},
@@ -46,6 +47,7 @@ func CommandInjection() taint.Config {
 			// No general-purpose stdlib sanitizer for command injection.
 			// The proper fix is to use exec.Command with separate args, not shell strings.
 		},
+		Guards: regexpGuards(),
 	}
 }
 
@@ -47,6 +47,7 @@ func runCORSBypassPatternAnalysis(pass *analysis.Pass) (any, error) {
 		return nil, err
 	}
 
+	maxDepth := passMaxDepth(pass)
 	issuesByPos := make(map[token.Pos]*issue.Issue)
 
 	for _, fn := range collectAnalyzerFunctions(ssaResult.SSA.SrcFuncs) {
@@ -73,14 +74,14 @@ func runCORSBypassPatternAnalysis(pass *analysis.Pass) (any, error) {
 				}
 
 				patternArg := common.Args[1]
-				if pattern, ok := extractStringValue(patternArg, 0); ok {
+				if pattern, ok := extractStringValue(patternArg, 0, maxDepth); ok {
 					if isOverbroadBypassPattern(pattern) {
 						addG121Issue(issuesByPos, pass, instr.Pos(), msgOverbroadBypassPattern, issue.High, issue.High)
 					}
 					continue
 				}
 
-				if requestParam != nil && valueDependsOn(patternArg, requestParam, 0) {
+				if requestParam != nil && valueDependsOn(patternArg, requestParam, 0, maxDepth) {
 					addG121Issue(issuesByPos, pass, instr.Pos(), msgRequestBypassPattern, issue.High, issue.Medium)
 				}
 			}
@@ -155,8 +156,8 @@ func isCrossOriginProtectionType(t types.Type) bool {
 	return pkg != nil && pkg.Path() == "net/http"
 }
 
-func extractStringValue(v ssa.Value, depth int) (string, bool) {
-	if v == nil || depth > MaxDepth {
+func extractStringValue(v ssa.Value, depth int, maxDepth int) (string, bool) {
+	if v == nil || depth > maxDepth {
 		return "", false
 	}
 
@@ -166,18 +167,18 @@ func extractStringValue(v ssa.Value, depth int) (string, bool) {
 
 	switch x := v.(type) {
 	case *ssa.ChangeType:
-		return extractStringValue(x.X, depth+1)
+		return extractStringValue(x.X, depth+1, maxDepth)
 	case *ssa.MakeInterface:
-		return extractStringValue(x.X, depth+1)
+		return extractStringValue(x.X, depth+1, maxDepth)
 	case *ssa.TypeAssert:
-		return extractStringValue(x.X, depth+1)
+		return extractStringValue(x.X, depth+1, maxDepth)
 	case *ssa.Phi:
 		if len(x.Edges) == 0 {
 			return "", false
 		}
 		var candidate string
 		for _, edge := range x.Edges {
-			val, ok := extractStringValue(edge, depth+1)
+			val, ok := extractStringValue(edge, depth+1, maxDepth)
 			if !ok {
 				return "", false
 			}
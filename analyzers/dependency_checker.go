@@ -24,12 +24,14 @@ type dependencyKey struct {
 type dependencyChecker struct {
 	memo     map[dependencyKey]bool
 	visiting map[dependencyKey]struct{}
+	maxDepth int
 }
 
-func newDependencyChecker() *dependencyChecker {
+func newDependencyChecker(maxDepth int) *dependencyChecker {
 	return &dependencyChecker{
 		memo:     make(map[dependencyKey]bool),
 		visiting: make(map[dependencyKey]struct{}),
+		maxDepth: maxDepth,
 	}
 }
 
@@ -38,7 +40,7 @@ func (c *dependencyChecker) dependsOn(value ssa.Value, target ssa.Value) bool {
 }
 
 func (c *dependencyChecker) dependsOnDepth(value ssa.Value, target ssa.Value, depth int) bool {
-	if value == nil || target == nil || depth > MaxDepth {
+	if value == nil || target == nil || depth > c.maxDepth {
 		return false
 	}
 	if value == target {
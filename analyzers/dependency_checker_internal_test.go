@@ -11,7 +11,7 @@ import (
 func TestDependencyCheckerHandlesPhiCycleWithoutTarget(t *testing.T) {
 	t.Parallel()
 
-	checker := newDependencyChecker()
+	checker := newDependencyChecker(MaxDepth)
 	target := ssa.NewConst(constant.MakeInt64(42), types.Typ[types.Int])
 
 	phiA := &ssa.Phi{}
@@ -27,7 +27,7 @@ func TestDependencyCheckerHandlesPhiCycleWithoutTarget(t *testing.T) {
 func TestDependencyCheckerFindsTargetInPhiCycle(t *testing.T) {
 	t.Parallel()
 
-	checker := newDependencyChecker()
+	checker := newDependencyChecker(MaxDepth)
 	target := ssa.NewConst(constant.MakeInt64(7), types.Typ[types.Int])
 
 	phiA := &ssa.Phi{}
@@ -54,7 +54,7 @@ func TestValueDependsOnHandlesPhiCycleWithoutTarget(t *testing.T) {
 	phiA.Edges = []ssa.Value{phiB}
 	phiB.Edges = []ssa.Value{phiA}
 
-	if valueDependsOn(phiA, target, 0) {
+	if valueDependsOn(phiA, target, 0, MaxDepth) {
 		t.Fatal("expected false for Phi cycle with no path to target")
 	}
 }
@@ -69,11 +69,11 @@ func TestValueDependsOnFindsTargetInPhiCycle(t *testing.T) {
 	phiA.Edges = []ssa.Value{phiB, target}
 	phiB.Edges = []ssa.Value{phiA}
 
-	if !valueDependsOn(phiA, target, 0) {
+	if !valueDependsOn(phiA, target, 0, MaxDepth) {
 		t.Fatal("expected true when cycle has a path to target")
 	}
 
-	if !valueDependsOn(phiA, target, 0) {
+	if !valueDependsOn(phiA, target, 0, MaxDepth) {
 		t.Fatal("expected stable result on repeated call")
 	}
 }
@@ -86,7 +86,7 @@ func TestValueDependsOnSelfReferentialPhi(t *testing.T) {
 	phi := &ssa.Phi{}
 	phi.Edges = []ssa.Value{phi}
 
-	if valueDependsOn(phi, target, 0) {
+	if valueDependsOn(phi, target, 0, MaxDepth) {
 		t.Fatal("expected false for self-referential Phi with no path to target")
 	}
 }
@@ -28,6 +28,7 @@ import (
 // standard library (see net/http.Request.ParseForm documentation).
 func FormParsingLimits() taint.Config {
 	return taint.Config{
+		Class: "form",
 		Sources: []taint.Source{
 			{Package: "net/http", Name: "Request", Pointer: true},
 		},
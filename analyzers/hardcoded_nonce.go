@@ -349,7 +349,7 @@ func (s *analysisState) raiseIssue(val ssa.Value, issueDescription string, fromI
 // isHardcoded determines if a value is derived from a hardcoded constant
 // or specific patterns (e.g. "slicelit" comment on Alloc).
 func (s *analysisState) isHardcoded(val ssa.Value) bool {
-	if s.Depth > MaxDepth {
+	if s.Depth > s.maxDepth() {
 		return false
 	}
 	s.Depth++
@@ -433,7 +433,7 @@ func (s *analysisState) analyzeUsage(val ssa.Value) uint8 {
 	if val == nil {
 		return 0
 	}
-	if s.Depth > MaxDepth {
+	if s.Depth > s.maxDepth() {
 		return statusDyn // assume dynamic avoid infinite recursion
 	}
 	if res, ok := s.usageCache[val]; ok {
@@ -114,7 +114,7 @@ func (s *insecureCookieState) trackCookieFieldStore(store *ssa.Store) {
 		return
 	}
 
-	root := cookieRoot(fieldAddr.X, 0)
+	root := cookieRoot(fieldAddr.X, 0, s.maxDepth())
 	if root == nil {
 		return
 	}
@@ -230,8 +230,8 @@ func httpCookieFieldName(fieldAddr *ssa.FieldAddr) (string, bool) {
 }
 
 // cookieRoot traces a value back to its http.Cookie allocation root.
-func cookieRoot(v ssa.Value, depth int) ssa.Value {
-	if v == nil || depth > MaxDepth {
+func cookieRoot(v ssa.Value, depth int, maxDepth int) ssa.Value {
+	if v == nil || depth > maxDepth {
 		return nil
 	}
 	if isHTTPCookiePointerType(v.Type()) {
@@ -239,18 +239,18 @@ func cookieRoot(v ssa.Value, depth int) ssa.Value {
 	}
 	switch value := v.(type) {
 	case *ssa.ChangeType:
-		return cookieRoot(value.X, depth+1)
+		return cookieRoot(value.X, depth+1, maxDepth)
 	case *ssa.MakeInterface:
-		return cookieRoot(value.X, depth+1)
+		return cookieRoot(value.X, depth+1, maxDepth)
 	case *ssa.TypeAssert:
-		return cookieRoot(value.X, depth+1)
+		return cookieRoot(value.X, depth+1, maxDepth)
 	case *ssa.UnOp:
-		return cookieRoot(value.X, depth+1)
+		return cookieRoot(value.X, depth+1, maxDepth)
 	case *ssa.FieldAddr:
-		return cookieRoot(value.X, depth+1)
+		return cookieRoot(value.X, depth+1, maxDepth)
 	case *ssa.Phi:
 		if len(value.Edges) > 0 {
-			return cookieRoot(value.Edges[0], depth+1)
+			return cookieRoot(value.Edges[0], depth+1, maxDepth)
 		}
 	}
 	return nil
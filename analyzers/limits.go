@@ -0,0 +1,85 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/securego/gosec/v2/internal/ssautil"
+)
+
+// LimitsConfigKey is the key of the limits of the SSA analyzers in the gosec
+// configuration. The taint rules have their own limits.
+const LimitsConfigKey = "analyzer-limits"
+
+// Limits bounds the work of the SSA analyzers. A zero MaxDepth selects the
+// default depth, MaxDepth.
+type Limits struct {
+	// MaxDepth is the maximum recursion depth when following SSA values
+	MaxDepth int
+}
+
+// limitsConfig is the configuration form of Limits.
+type limitsConfig struct {
+	MaxDepth int `json:"max-depth"`
+}
+
+// ParseLimits reads the limits from the value of the "analyzer-limits" entry
+// of the gosec configuration, e.g.
+//
+//	{"max-depth": 40}
+//
+// A nil value yields the default limits.
+func ParseLimits(value any) (Limits, error) {
+	if value == nil {
+		return Limits{}, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return Limits{}, fmt.Errorf("%s: %w", LimitsConfigKey, err)
+	}
+	var config limitsConfig
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return Limits{}, fmt.Errorf("%s: %w", LimitsConfigKey, err)
+	}
+	if config.MaxDepth < 0 {
+		return Limits{}, fmt.Errorf("%s: max-depth %d cannot be negative", LimitsConfigKey, config.MaxDepth)
+	}
+	return Limits(config), nil
+}
+
+// passMaxDepth returns the maximum recursion depth of the analysis of the
+// package of a pass. Invalid limits, which are rejected when the
+// configuration is loaded, select the default depth.
+func passMaxDepth(pass *analysis.Pass) int {
+	if pass == nil {
+		return MaxDepth
+	}
+	ssaResult, err := ssautil.GetSSAResult(pass)
+	if err != nil {
+		return MaxDepth
+	}
+	limits, err := ParseLimits(ssaResult.Config[LimitsConfigKey])
+	if err != nil || limits.MaxDepth == 0 {
+		return MaxDepth
+	}
+	return limits.MaxDepth
+}
@@ -23,7 +23,8 @@ import (
 // LogInjection returns a configuration for detecting log injection vulnerabilities.
 func LogInjection() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
+		Class: "log",
+		Sources: append([]taint.Source{
 			// Type sources: tainted when received as parameters
 			{Package: "net/http", Name: "Request", Pointer: true},
 			{Package: "net/url", Name: "URL", Pointer: true},
@@ -35,7 +36,7 @@ func LogInjection() taint.Config {
 			// I/O sources
 			{Package: "bufio", Name: "Reader", Pointer: true},
 			{Package: "bufio", Name: "Scanner", Pointer: true},
-		},
+		}, frameworkSources()...),
 		Sinks: []taint.Sink{
 			{Package: "log", Method: "Print"},
 			{Package: "log", Method: "Printf"},
@@ -83,6 +84,7 @@ func LogInjection() taint.Config {
 			{Package: "strconv", Method: "FormatInt"},
 			{Package: "strconv", Method: "FormatFloat"},
 		},
+		Guards: regexpGuards(),
 	}
 }
 
@@ -25,20 +25,21 @@ import (
 // See CWE-601.
 func OpenRedirect() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
+		Class: "redirect",
+		Sources: append([]taint.Source{
 			// Type sources: tainted when received as parameters from external callers.
 			// Any read from a *http.Request (FormValue, URL.Query().Get, Cookie, etc.)
 			// propagates taint through the existing receiver-based logic in isTainted.
 			{Package: "net/http", Name: "Request", Pointer: true},
 			{Package: "net/url", Name: "URL", Pointer: true},
 			{Package: "net/url", Name: "Values"},
-		},
-		Sinks: []taint.Sink{
+		}, frameworkSources()...),
+		Sinks: append([]taint.Sink{
 			// http.Redirect(w, r, url, code): only the URL string (arg index 2)
 			// is the redirect target. Skipping arg 1 (*http.Request) prevents the
 			// receiver itself from being treated as a tainted sink argument.
 			{Package: "net/http", Method: "Redirect", CheckArgs: []int{2}},
-		},
+		}, frameworkRedirectSinks()...),
 		Sanitizers: []taint.Sanitizer{
 			// url.PathEscape / QueryEscape neutralize untrusted path or query
 			// fragments embedded into a hard-coded base URL.
@@ -53,6 +54,7 @@ func OpenRedirect() taint.Config {
 			{Package: "strconv", Method: "FormatInt"},
 			{Package: "strconv", Method: "FormatUint"},
 		},
+		Guards: regexpGuards(),
 	}
 }
 
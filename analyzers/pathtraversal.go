@@ -23,7 +23,8 @@ import (
 // PathTraversal returns a configuration for detecting path traversal vulnerabilities.
 func PathTraversal() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
+		Class: "path",
+		Sources: append([]taint.Source{
 			// Type sources: tainted when received as function parameters
 			{Package: "net/http", Name: "Request", Pointer: true},
 			{Package: "net/url", Name: "URL", Pointer: true},
@@ -40,7 +41,7 @@ func PathTraversal() taint.Config {
 			// is what the sink checks. If someone opens a file from user input and
 			// then reads from it, the taint flows through the path argument, not the
 			// File type itself.
-		},
+		}, frameworkSources()...),
 		Sinks: []taint.Sink{
 			{Package: "os", Method: "Open"},
 			{Package: "os", Method: "OpenFile"},
@@ -91,6 +92,11 @@ func PathTraversal() taint.Config {
 			{Package: "strconv", Method: "ParseFloat"},
 			{Package: "strconv", Method: "ParseBool"},
 		},
+		Guards: append(regexpGuards(),
+			// filepath.IsLocal rejects absolute paths, ".." elements and
+			// reserved names, so the path stays within the base directory
+			taint.Guard{Package: "path/filepath", Method: "IsLocal"},
+		),
 	}
 }
 
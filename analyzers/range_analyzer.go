@@ -60,6 +60,7 @@ type RangeAnalyzer struct {
 	RangeCache     map[rangeCacheKey]*rangeResult
 	ResultPool     []*rangeResult
 	Depth          int
+	DepthLimit     int // Maximum Depth, MaxDepth when zero
 	BlockMap       map[*ssa.BasicBlock]bool
 	ValueMap       map[ssa.Value]bool
 	ByteRangeCache map[ssa.Value]ByteRange
@@ -110,9 +111,18 @@ func NewRangeAnalyzer() *RangeAnalyzer {
 // Release returns the RangeAnalyzer to the pool after clearing its caches.
 func (ra *RangeAnalyzer) Release() {
 	ra.ResetCache()
+	ra.DepthLimit = 0
 	rangeAnalyzerPool.Put(ra)
 }
 
+// maxDepth returns the maximum recursion depth of the analysis.
+func (ra *RangeAnalyzer) maxDepth() int {
+	if ra.DepthLimit > 0 {
+		return ra.DepthLimit
+	}
+	return MaxDepth
+}
+
 func (ra *RangeAnalyzer) ResetCache() {
 	for _, res := range ra.RangeCache {
 		res.shared = false
@@ -176,7 +186,7 @@ func (ra *RangeAnalyzer) ResolveRange(v ssa.Value, block *ssa.BasicBlock) *range
 		ra.releaseResult(res)
 	}
 
-	if ra.Depth > MaxDepth {
+	if ra.Depth > ra.maxDepth() {
 		result.shared = true
 		ra.RangeCache[key] = result
 		return result
@@ -1101,7 +1111,7 @@ func (ra *RangeAnalyzer) ResolveByteRange(val ssa.Value) (ByteRange, bool) {
 		return r, true
 	}
 
-	if ra.Depth > MaxDepth {
+	if ra.Depth > ra.maxDepth() {
 		return ByteRange{}, false
 	}
 	ra.Depth++
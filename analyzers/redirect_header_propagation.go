@@ -54,6 +54,7 @@ func runRedirectHeaderPropagationAnalysis(pass *analysis.Pass) (any, error) {
 		return nil, err
 	}
 
+	maxDepth := passMaxDepth(pass)
 	issuesByPos := make(map[token.Pos]*issue.Issue)
 	for _, fn := range collectAnalyzerFunctions(ssaResult.SSA.SrcFuncs) {
 		reqParam, hasVia := findRedirectLikeParams(fn)
@@ -65,7 +66,7 @@ func runRedirectHeaderPropagationAnalysis(pass *analysis.Pass) (any, error) {
 			for _, instr := range block.Instrs {
 				switch v := instr.(type) {
 				case *ssa.Store:
-					if isRequestHeaderStore(v, reqParam) {
+					if isRequestHeaderStore(v, reqParam, maxDepth) {
 						addRedirectIssue(issuesByPos, pass, v.Pos(), msgUnsafeRedirectHeaderCopy, issue.High, issue.High)
 					}
 				case *ssa.Call:
@@ -75,7 +76,7 @@ func runRedirectHeaderPropagationAnalysis(pass *analysis.Pass) (any, error) {
 					if len(v.Call.Args) < 2 {
 						continue
 					}
-					if !isRequestHeaderValue(v.Call.Args[0], reqParam) {
+					if !isRequestHeaderValue(v.Call.Args[0], reqParam, maxDepth) {
 						continue
 					}
 					headerName := extractStringConst(v.Call.Args[1])
@@ -192,7 +193,7 @@ func isRequestSliceType(t types.Type) bool {
 	return isHTTPRequestPointerType(slice.Elem())
 }
 
-func isRequestHeaderStore(store *ssa.Store, reqParam *ssa.Parameter) bool {
+func isRequestHeaderStore(store *ssa.Store, reqParam *ssa.Parameter, maxDepth int) bool {
 	fieldAddr, ok := store.Addr.(*ssa.FieldAddr)
 	if !ok {
 		return false
@@ -204,14 +205,14 @@ func isRequestHeaderStore(store *ssa.Store, reqParam *ssa.Parameter) bool {
 	if !isHTTPHeaderType(fieldType) {
 		return false
 	}
-	return valueDependsOn(fieldAddr.X, reqParam, 0)
+	return valueDependsOn(fieldAddr.X, reqParam, 0, maxDepth)
 }
 
-func isRequestHeaderValue(val ssa.Value, reqParam *ssa.Parameter) bool {
+func isRequestHeaderValue(val ssa.Value, reqParam *ssa.Parameter, maxDepth int) bool {
 	if val == nil {
 		return false
 	}
-	if isHTTPHeaderType(val.Type()) && valueDependsOn(val, reqParam, 0) {
+	if isHTTPHeaderType(val.Type()) && valueDependsOn(val, reqParam, 0, maxDepth) {
 		return true
 	}
 	return false
@@ -260,7 +261,7 @@ func extractStringConst(v ssa.Value) string {
 	return constant.StringVal(c.Value)
 }
 
-func valueDependsOn(value ssa.Value, target ssa.Value, depth int) bool {
-	checker := newDependencyChecker()
+func valueDependsOn(value ssa.Value, target ssa.Value, depth int, maxDepth int) bool {
+	checker := newDependencyChecker(maxDepth)
 	return checker.dependsOnDepth(value, target, depth)
 }
@@ -23,7 +23,8 @@ import (
 // SMTPInjection returns a configuration for detecting SMTP command/header injection vulnerabilities.
 func SMTPInjection() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
+		Class: "smtp",
+		Sources: append([]taint.Source{
 			// Type sources: tainted when received as parameters
 			{Package: "net/http", Name: "Request", Pointer: true},
 			{Package: "net/url", Name: "URL", Pointer: true},
@@ -34,7 +35,7 @@ func SMTPInjection() taint.Config {
 			// Function sources
 			{Package: "os", Name: "Args", IsFunc: true},
 			{Package: "os", Name: "Getenv", IsFunc: true},
-		},
+		}, frameworkSources()...),
 		Sinks: []taint.Sink{
 			// net/smtp.SendMail(addr, auth, from, to, msg)
 			// Check sender and recipient envelope fields.
@@ -53,6 +54,7 @@ func SMTPInjection() taint.Config {
 			{Package: "net/mail", Receiver: "AddressParser", Method: "Parse", Pointer: true},
 			{Package: "net/mail", Receiver: "AddressParser", Method: "ParseList", Pointer: true},
 		},
+		Guards: regexpGuards(),
 	}
 }
 
@@ -15,6 +15,9 @@
 package analyzers
 
 import (
+	"maps"
+	"slices"
+
 	"golang.org/x/tools/go/analysis"
 
 	"github.com/securego/gosec/v2/taint"
@@ -23,7 +26,8 @@ import (
 // SQLInjection returns a configuration for detecting SQL injection vulnerabilities.
 func SQLInjection() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
+		Class: "sql",
+		Sources: append([]taint.Source{
 			// Type sources: tainted when received as parameters
 			{Package: "net/http", Name: "Request", Pointer: true},
 			{Package: "net/url", Name: "URL", Pointer: true},
@@ -34,8 +38,8 @@ func SQLInjection() taint.Config {
 			// Function sources
 			{Package: "os", Name: "Args", IsFunc: true},
 			{Package: "os", Name: "Getenv", IsFunc: true},
-		},
-		Sinks: []taint.Sink{
+		}, frameworkSources()...),
+		Sinks: append([]taint.Sink{
 			// For SQL methods, Args[0] is receiver, Args[1] is query string
 			// Only check query string argument; prepared statement params are safe
 			{Package: "database/sql", Receiver: "DB", Method: "Query", Pointer: true, CheckArgs: []int{1}},
@@ -54,11 +58,16 @@ func SQLInjection() taint.Config {
 			{Package: "database/sql", Receiver: "Tx", Method: "ExecContext", Pointer: true, CheckArgs: []int{2}},
 			{Package: "database/sql", Receiver: "Tx", Method: "Prepare", Pointer: true, CheckArgs: []int{1}},
 			{Package: "database/sql", Receiver: "Tx", Method: "PrepareContext", Pointer: true, CheckArgs: []int{2}},
-		},
+			{Package: "database/sql", Receiver: "Conn", Method: "QueryContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "database/sql", Receiver: "Conn", Method: "QueryRowContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "database/sql", Receiver: "Conn", Method: "ExecContext", Pointer: true, CheckArgs: []int{2}},
+			{Package: "database/sql", Receiver: "Conn", Method: "PrepareContext", Pointer: true, CheckArgs: []int{2}},
+		}, databaseLibrarySinks()...),
 		Sanitizers: []taint.Sanitizer{
 			// No stdlib sanitizers for SQL — use parameterized queries instead.
 			// The CheckArgs configuration already excludes prepared statement params.
 		},
+		Guards: regexpGuards(),
 	}
 }
 
@@ -71,3 +80,73 @@ func newSQLInjectionAnalyzer(id string, description string) *analysis.Analyzer {
 	rule.Description = description
 	return taint.NewGosecAnalyzer(&rule, &config)
 }
+
+// Import paths of the database libraries known to the SQL injection analyzer.
+const (
+	pgxPackage      = "github.com/jackc/pgx/v5"
+	pgxpoolPackage  = "github.com/jackc/pgx/v5/pgxpool"
+	sqlxPackage     = "github.com/jmoiron/sqlx"
+	gormPackage     = "gorm.io/gorm"
+	squirrelPackage = "github.com/Masterminds/squirrel"
+)
+
+// databaseLibrarySinks returns the raw SQL entry points of the popular database
+// libraries. Only the SQL string is checked, never the bound parameters. Query
+// builder methods taking either a SQL fragment or structured conditions, such as
+// gorm's Where, are only sinks when passed a string.
+func databaseLibrarySinks() []taint.Sink {
+	sinks := []taint.Sink{
+		// pgx: Query(ctx, sql, args...), the receiver is Args[0]
+		{Package: pgxPackage, Receiver: "Conn", Method: "Query", Pointer: true, CheckArgs: []int{2}},
+		{Package: pgxPackage, Receiver: "Conn", Method: "QueryRow", Pointer: true, CheckArgs: []int{2}},
+		{Package: pgxPackage, Receiver: "Conn", Method: "Exec", Pointer: true, CheckArgs: []int{2}},
+		{Package: pgxpoolPackage, Receiver: "Pool", Method: "Query", Pointer: true, CheckArgs: []int{2}},
+		{Package: pgxpoolPackage, Receiver: "Pool", Method: "QueryRow", Pointer: true, CheckArgs: []int{2}},
+		{Package: pgxpoolPackage, Receiver: "Pool", Method: "Exec", Pointer: true, CheckArgs: []int{2}},
+		// pgx.Tx is an interface: the arguments of interface calls start at 0
+		{Package: pgxPackage, Receiver: "Tx", Method: "Query", CheckArgs: []int{1}},
+		{Package: pgxPackage, Receiver: "Tx", Method: "QueryRow", CheckArgs: []int{1}},
+		{Package: pgxPackage, Receiver: "Tx", Method: "Exec", CheckArgs: []int{1}},
+
+		// squirrel: Expr(sql, args...) builds a raw SQL fragment
+		{Package: squirrelPackage, Method: "Expr", CheckArgs: []int{0}},
+	}
+
+	// sqlx: the methods of DB and Tx, the database/sql methods they embed are
+	// already sinks.
+	sqlxMethods := map[string]int{
+		"Queryx": 1, "QueryxContext": 2, "QueryRowx": 1, "QueryRowxContext": 2,
+		"MustExec": 1, "MustExecContext": 2, "Preparex": 1, "PreparexContext": 2,
+		"Select": 2, "SelectContext": 3, "Get": 2, "GetContext": 3,
+		"NamedExec": 1, "NamedExecContext": 2, "NamedQuery": 1, "NamedQueryContext": 2,
+	}
+	for _, receiver := range []string{"DB", "Tx"} {
+		for _, method := range slices.Sorted(maps.Keys(sqlxMethods)) {
+			sinks = append(sinks, taint.Sink{Package: sqlxPackage, Receiver: receiver, Method: method, Pointer: true, CheckArgs: []int{sqlxMethods[method]}})
+		}
+	}
+
+	// gorm: Raw and Exec take SQL, Where, Or, Not and Order take either SQL or
+	// structured conditions.
+	for _, method := range []string{"Raw", "Exec"} {
+		sinks = append(sinks, taint.Sink{Package: gormPackage, Receiver: "DB", Method: method, Pointer: true, CheckArgs: []int{1}})
+	}
+	for _, method := range []string{"Where", "Or", "Not", "Order"} {
+		sinks = append(sinks, taint.Sink{
+			Package: gormPackage, Receiver: "DB", Method: method, Pointer: true,
+			CheckArgs: []int{1}, ArgTypeGuards: map[int]string{1: "string"},
+		})
+	}
+
+	// squirrel builders: Where takes SQL or structured conditions, Prefix,
+	// Suffix and OrderBy take SQL.
+	for _, receiver := range []string{"SelectBuilder", "UpdateBuilder", "DeleteBuilder"} {
+		sinks = append(sinks,
+			taint.Sink{Package: squirrelPackage, Receiver: receiver, Method: "Where", CheckArgs: []int{1}, ArgTypeGuards: map[int]string{1: "string"}},
+			taint.Sink{Package: squirrelPackage, Receiver: receiver, Method: "Prefix", CheckArgs: []int{1}},
+			taint.Sink{Package: squirrelPackage, Receiver: receiver, Method: "Suffix", CheckArgs: []int{1}},
+			taint.Sink{Package: squirrelPackage, Receiver: receiver, Method: "OrderBy", CheckArgs: []int{1}},
+		)
+	}
+	return sinks
+}
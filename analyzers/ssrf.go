@@ -23,7 +23,8 @@ import (
 // SSRF returns a configuration for detecting Server-Side Request Forgery vulnerabilities.
 func SSRF() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
+		Class: "ssrf",
+		Sources: append([]taint.Source{
 			// Type sources: tainted when received as function parameters from external callers
 			{Package: "net/http", Name: "Request", Pointer: true},
 
@@ -40,7 +41,7 @@ func SSRF() taint.Config {
 			// If the file was opened from user-controlled input, the taint would
 			// flow through the path argument, and that's a path traversal issue (G703),
 			// not SSRF.
-		},
+		}, frameworkSources()...),
 		Sinks: []taint.Sink{
 			// URL argument is what we check - these are the first data arg
 			{Package: "net/http", Method: "Get", CheckArgs: []int{0}},
@@ -67,6 +68,7 @@ func SSRF() taint.Config {
 			// However, url.Parse itself is not a sanitizer — it doesn't restrict
 			// which hosts can be accessed.
 		},
+		Guards: regexpGuards(),
 	}
 }
 
@@ -32,7 +32,8 @@ import (
 // text/template into an HTTP response produces unescaped HTML, enabling XSS.
 func SSTI() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
+		Class: "template",
+		Sources: append([]taint.Source{
 			// Type sources: tainted when received as parameters
 			{Package: "net/http", Name: "Request", Pointer: true},
 			{Package: "net/url", Name: "Values"},
@@ -44,7 +45,7 @@ func SSTI() taint.Config {
 			// I/O sources
 			{Package: "bufio", Name: "Reader", Pointer: true},
 			{Package: "bufio", Name: "Scanner", Pointer: true},
-		},
+		}, frameworkSources()...),
 		Sinks: []taint.Sink{
 			// CRITICAL: user input flows into the template string itself.
 			// Template.Parse takes a single string argument (the template text).
@@ -90,6 +91,7 @@ func SSTI() taint.Config {
 			{Package: "strconv", Method: "FormatUint"},
 			{Package: "strconv", Method: "FormatFloat"},
 		},
+		Guards: regexpGuards(),
 	}
 }
 
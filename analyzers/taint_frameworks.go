@@ -0,0 +1,82 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import "github.com/securego/gosec/v2/taint"
+
+// Import paths of the web frameworks known to the taint analyzers.
+const (
+	ginPackage     = "github.com/gin-gonic/gin"
+	echoPackage    = "github.com/labstack/echo/v4"
+	chiPackage     = "github.com/go-chi/chi/v5"
+	muxPackage     = "github.com/gorilla/mux"
+	fiberPackage   = "github.com/gofiber/fiber/v2"
+	grpcMDPackage  = "google.golang.org/grpc/metadata"
+	gatewayPackage = "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// frameworkSources returns the sources of request data of the common web
+// frameworks. The request contexts are type sources: a handler receiving one
+// is an entry point, and anything read from it, such as c.Query("id"), is
+// tainted.
+func frameworkSources() []taint.Source {
+	return []taint.Source{
+		// gin handlers receive a *gin.Context
+		{Package: ginPackage, Name: "Context", Pointer: true},
+		// echo handlers receive the echo.Context interface
+		{Package: echoPackage, Name: "Context"},
+		// fiber handlers receive a *fiber.Ctx
+		{Package: fiberPackage, Name: "Ctx", Pointer: true},
+
+		// chi and gorilla/mux expose the path parameters through functions
+		{Package: chiPackage, Name: "URLParam", IsFunc: true},
+		{Package: chiPackage, Name: "URLParamFromCtx", IsFunc: true},
+		{Package: muxPackage, Name: "Vars", IsFunc: true},
+
+		// grpc-gateway fills the request messages of the services it proxies
+		// from the path and query parameters, and forwards the HTTP headers
+		// as incoming gRPC metadata. Its HandlePath handlers receive the
+		// *http.Request, which is a source already.
+		{Package: gatewayPackage, Name: "PopulateFieldFromPath", IsFunc: true, Args: []int{0}},
+		{Package: gatewayPackage, Name: "PopulateQueryParameters", IsFunc: true, Args: []int{0}},
+		{Package: grpcMDPackage, Name: "MD"},
+		{Package: grpcMDPackage, Name: "FromIncomingContext", IsFunc: true},
+		{Package: grpcMDPackage, Name: "ValueFromIncomingContext", IsFunc: true},
+	}
+}
+
+// frameworkXSSSinks returns the framework methods writing a response body.
+// Receivers of static method calls are at position 0, while the arguments of
+// interface method calls start at 0, hence the different CheckArgs.
+func frameworkXSSSinks() []taint.Sink {
+	return []taint.Sink{
+		{Package: ginPackage, Receiver: "Context", Method: "String", Pointer: true, CheckArgs: []int{2, 3}},
+		{Package: ginPackage, Receiver: "Context", Method: "HTML", Pointer: true, CheckArgs: []int{3}},
+		{Package: ginPackage, Receiver: "Context", Method: "Data", Pointer: true, CheckArgs: []int{3}},
+		{Package: echoPackage, Receiver: "Context", Method: "String", CheckArgs: []int{1}},
+		{Package: echoPackage, Receiver: "Context", Method: "HTML", CheckArgs: []int{1}},
+		{Package: echoPackage, Receiver: "Context", Method: "HTMLBlob", CheckArgs: []int{1}},
+		{Package: fiberPackage, Receiver: "Ctx", Method: "SendString", Pointer: true, CheckArgs: []int{1}},
+	}
+}
+
+// frameworkRedirectSinks returns the framework methods redirecting to a URL.
+func frameworkRedirectSinks() []taint.Sink {
+	return []taint.Sink{
+		{Package: ginPackage, Receiver: "Context", Method: "Redirect", Pointer: true, CheckArgs: []int{2}},
+		{Package: echoPackage, Receiver: "Context", Method: "Redirect", CheckArgs: []int{1}},
+		{Package: fiberPackage, Receiver: "Ctx", Method: "Redirect", Pointer: true, CheckArgs: []int{1}},
+	}
+}
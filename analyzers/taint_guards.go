@@ -0,0 +1,29 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import "github.com/securego/gosec/v2/taint"
+
+// regexpGuards returns the guards validating data against a regular expression.
+// Code which only runs when the data matches an allow-list pattern, such as
+// `if !validID.MatchString(id) { return }`, does not receive tainted data.
+func regexpGuards() []taint.Guard {
+	return []taint.Guard{
+		{Package: "regexp", Receiver: "Regexp", Method: "MatchString", Pointer: true, Arg: 1},
+		{Package: "regexp", Receiver: "Regexp", Method: "Match", Pointer: true, Arg: 1},
+		{Package: "regexp", Method: "MatchString", Arg: 1},
+		{Package: "regexp", Method: "Match", Arg: 1},
+	}
+}
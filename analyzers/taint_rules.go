@@ -0,0 +1,339 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"fmt"
+	"go/types"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/securego/gosec/v2/taint"
+)
+
+// taintRuleIDPattern restricts user-defined rule IDs to the GNNN format so
+// that #nosec directives, which only recognise that shape, can reference them.
+var taintRuleIDPattern = regexp.MustCompile(`^G\d{3}$`)
+
+// TaintRule describes a user-defined taint analysis rule. Rules are usually
+// loaded from the "taint-rules" section of the gosec configuration file.
+type TaintRule struct {
+	ID          string            `json:"id"`
+	Description string            `json:"description"`
+	Severity    string            `json:"severity"`
+	CWE         string            `json:"cwe"`
+	Class       string            `json:"class"`
+	Sources     []taint.Source    `json:"sources"`
+	Sinks       []taint.Sink      `json:"sinks"`
+	Sanitizers  []taint.Sanitizer `json:"sanitizers"`
+	Guards      []taint.Guard     `json:"guards"`
+}
+
+// Validate checks that the rule is complete and normalizes its severity.
+func (r *TaintRule) Validate() error {
+	if !taintRuleIDPattern.MatchString(r.ID) {
+		return fmt.Errorf("invalid rule id %q: expected the form GNNN (e.g. G790)", r.ID)
+	}
+	if isDefaultAnalyzer(r.ID) {
+		return fmt.Errorf("rule %s: id is already used by a built-in analyzer", r.ID)
+	}
+	if r.Description == "" {
+		return fmt.Errorf("rule %s: description cannot be empty", r.ID)
+	}
+
+	r.Severity = strings.ToUpper(strings.TrimSpace(r.Severity))
+	switch r.Severity {
+	case "LOW", "MEDIUM", "HIGH", "CRITICAL":
+	case "":
+		r.Severity = "MEDIUM"
+	default:
+		return fmt.Errorf("rule %s: invalid severity %q: valid options are LOW, MEDIUM, HIGH, CRITICAL", r.ID, r.Severity)
+	}
+
+	if len(r.Sources) == 0 {
+		return fmt.Errorf("rule %s: at least one source is required", r.ID)
+	}
+	if len(r.Sinks) == 0 {
+		return fmt.Errorf("rule %s: at least one sink is required", r.ID)
+	}
+	if err := validateTaintConfig(r.config()); err != nil {
+		return fmt.Errorf("rule %s: %w", r.ID, err)
+	}
+	return nil
+}
+
+// config returns the taint configuration declared by the rule.
+func (r TaintRule) config() taint.Config {
+	return taint.Config{
+		Class:      r.Class,
+		Sources:    r.Sources,
+		Sinks:      r.Sinks,
+		Sanitizers: r.Sanitizers,
+		Guards:     r.Guards,
+	}
+}
+
+// validateTaintConfig checks that every source, sink, sanitizer and guard
+// entry identifies a package and a function, type or method.
+func validateTaintConfig(config taint.Config) error {
+	for i, src := range config.Sources {
+		if src.Package == "" || src.Name == "" {
+			return fmt.Errorf("sources[%d]: package and name are required", i)
+		}
+		if (src.Receiver != "" || len(src.Args) > 0) && !src.IsFunc {
+			return fmt.Errorf("sources[%d]: receiver and args require is_func", i)
+		}
+		for _, idx := range src.Args {
+			if idx < 0 {
+				return fmt.Errorf("sources[%d]: args index %d cannot be negative", i, idx)
+			}
+		}
+	}
+	for i, sink := range config.Sinks {
+		if sink.Package == "" || sink.Method == "" {
+			return fmt.Errorf("sinks[%d]: package and method are required", i)
+		}
+		for _, idx := range sink.CheckArgs {
+			if idx < 0 {
+				return fmt.Errorf("sinks[%d]: check_args index %d cannot be negative", i, idx)
+			}
+		}
+		for idx, typePath := range sink.ArgTypeGuards {
+			if idx < 0 {
+				return fmt.Errorf("sinks[%d]: arg_type_guards index %d cannot be negative", i, idx)
+			}
+			if _, predeclared := types.Universe.Lookup(typePath).(*types.TypeName); !predeclared && !strings.Contains(typePath, ".") {
+				return fmt.Errorf("sinks[%d]: arg_type_guards type %q must have the form import/path.Type or be a predeclared type", i, typePath)
+			}
+		}
+	}
+	for i, san := range config.Sanitizers {
+		if san.Package == "" || san.Method == "" {
+			return fmt.Errorf("sanitizers[%d]: package and method are required", i)
+		}
+		for _, idx := range san.Args {
+			if idx < 0 {
+				return fmt.Errorf("sanitizers[%d]: args index %d cannot be negative", i, idx)
+			}
+		}
+		for _, idx := range san.Results {
+			if idx < 0 {
+				return fmt.Errorf("sanitizers[%d]: results index %d cannot be negative", i, idx)
+			}
+		}
+		for _, class := range san.Classes {
+			if strings.TrimSpace(class) == "" {
+				return fmt.Errorf("sanitizers[%d]: classes cannot contain an empty class", i)
+			}
+		}
+	}
+	for i, guard := range config.Guards {
+		if guard.Package == "" || guard.Method == "" {
+			return fmt.Errorf("guards[%d]: package and method are required", i)
+		}
+		if guard.Arg < 0 {
+			return fmt.Errorf("guards[%d]: arg index %d cannot be negative", i, guard.Arg)
+		}
+		for _, class := range guard.Classes {
+			if strings.TrimSpace(class) == "" {
+				return fmt.Errorf("guards[%d]: classes cannot contain an empty class", i)
+			}
+		}
+	}
+	return nil
+}
+
+// Definition returns the analyzer definition which builds a taint analyzer
+// for this rule.
+func (r TaintRule) Definition() AnalyzerDefinition {
+	return AnalyzerDefinition{
+		ID:          r.ID,
+		Description: r.Description,
+		Create: func(id string, description string) *analysis.Analyzer {
+			config := r.config()
+			rule := taint.RuleInfo{
+				ID:          id,
+				Description: description,
+				Severity:    r.Severity,
+				CWE:         r.CWE,
+			}
+			return taint.NewGosecAnalyzer(&rule, &config)
+		},
+	}
+}
+
+// NewTaintRuleDefinitions validates the user-defined taint rules and converts
+// them into analyzer definitions which can be passed to GenerateWith.
+func NewTaintRuleDefinitions(rules []TaintRule) ([]AnalyzerDefinition, error) {
+	seen := make(map[string]bool, len(rules))
+	definitions := make([]AnalyzerDefinition, 0, len(rules))
+	for i := range rules {
+		rule := rules[i]
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("taint-rules[%d]: %w", i, err)
+		}
+		if seen[rule.ID] {
+			return nil, fmt.Errorf("taint-rules[%d]: duplicate rule id %s", i, rule.ID)
+		}
+		seen[rule.ID] = true
+		definitions = append(definitions, rule.Definition())
+	}
+	return definitions, nil
+}
+
+// builtinTaintRule couples the metadata of a built-in taint analyzer with the
+// function producing its default configuration.
+type builtinTaintRule struct {
+	info   *taint.RuleInfo
+	config func() taint.Config
+}
+
+// builtinTaintRules lists the built-in analyzers whose taint configuration can
+// be extended from the gosec configuration.
+var builtinTaintRules = map[string]builtinTaintRule{
+	"G120": {&FormParsingLimitRule, FormParsingLimits},
+	"G701": {&SQLInjectionRule, SQLInjection},
+	"G702": {&CommandInjectionRule, CommandInjection},
+	"G703": {&PathTraversalRule, PathTraversal},
+	"G704": {&SSRFRule, SSRF},
+	"G705": {&XSSRule, XSS},
+	"G706": {&LogInjectionRule, LogInjection},
+	"G707": {&SMTPInjectionRule, SMTPInjection},
+	"G708": {&SSTIRule, SSTI},
+	"G709": {&UnsafeDeserializationRule, UnsafeDeserialization},
+	"G710": {&OpenRedirectRule, OpenRedirect},
+}
+
+// TaintExtension holds the sources, sinks, sanitizers and guards added to a
+// taint rule, and the opt-in source classes it enables.
+type TaintExtension struct {
+	taint.Config
+	// SourceClasses lists the source classes, such as "storage", whose
+	// sources are added to the rule.
+	SourceClasses []string `json:"source_classes,omitempty"`
+}
+
+// config returns the taint configuration of the extension, including the
+// sources of the enabled source classes.
+func (e TaintExtension) config() taint.Config {
+	config := e.Config
+	for _, class := range e.SourceClasses {
+		config.Sources = append(slices.Clip(config.Sources), sourceClasses[class]()...)
+	}
+	return config
+}
+
+// TaintExtensions holds the extensions of the taint rules keyed by the ID of
+// the rule they extend. The "*" key applies to every taint rule.
+type TaintExtensions map[string]TaintExtension
+
+// forRule returns the extension which applies to the given rule ID, combining
+// the entries declared for all rules with the rule-specific ones.
+func (e TaintExtensions) forRule(id string) (taint.Config, bool) {
+	all, hasAll := e[allTaintRules]
+	own, hasOwn := e[id]
+	return all.config().Merge(own.config()), hasAll || hasOwn
+}
+
+// allTaintRules is the TaintExtensions key which applies to every taint rule.
+const allTaintRules = "*"
+
+// NewTaintDefinitions validates the user-defined taint rules and the
+// extensions of taint rules, and returns the analyzer definitions to pass to
+// GenerateWith. The definitions of extended built-in rules replace the
+// default ones, while the user-defined rules are added to them.
+func NewTaintDefinitions(rules []TaintRule, extensions TaintExtensions) ([]AnalyzerDefinition, error) {
+	customIDs := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		customIDs[rule.ID] = true
+	}
+
+	ids := make([]string, 0, len(extensions))
+	for id := range extensions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if _, builtin := builtinTaintRules[id]; !builtin && !customIDs[id] && id != allTaintRules {
+			if isDefaultAnalyzer(id) {
+				return nil, fmt.Errorf("taint-extensions: %s is not a taint analysis rule", id)
+			}
+			return nil, fmt.Errorf("taint-extensions: unknown taint rule %s", id)
+		}
+		if extensions[id].Class != "" {
+			return nil, fmt.Errorf("taint-extensions: %s: the class of a rule cannot be extended", id)
+		}
+		if err := validateTaintConfig(extensions[id].Config); err != nil {
+			return nil, fmt.Errorf("taint-extensions: %s: %w", id, err)
+		}
+		for _, class := range extensions[id].SourceClasses {
+			if _, ok := sourceClasses[class]; !ok {
+				return nil, fmt.Errorf("taint-extensions: %s: unknown source class %q: valid options are %s",
+					id, class, strings.Join(slices.Sorted(maps.Keys(sourceClasses)), ", "))
+			}
+		}
+	}
+
+	extended := make([]TaintRule, len(rules))
+	for i, rule := range rules {
+		if extension, ok := extensions.forRule(rule.ID); ok {
+			merged := rule.config().Merge(extension)
+			rule.Sources, rule.Sinks, rule.Sanitizers, rule.Guards = merged.Sources, merged.Sinks, merged.Sanitizers, merged.Guards
+		}
+		extended[i] = rule
+	}
+	definitions, err := NewTaintRuleDefinitions(extended)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, def := range defaultAnalyzers {
+		builtin, ok := builtinTaintRules[def.ID]
+		if !ok {
+			continue
+		}
+		extension, ok := extensions.forRule(def.ID)
+		if !ok {
+			continue
+		}
+		definitions = append(definitions, AnalyzerDefinition{
+			ID:          def.ID,
+			Description: def.Description,
+			Create: func(id string, description string) *analysis.Analyzer {
+				config := builtin.config().Merge(extension)
+				rule := *builtin.info
+				rule.ID = id
+				rule.Description = description
+				return taint.NewGosecAnalyzer(&rule, &config)
+			},
+		})
+	}
+	return definitions, nil
+}
+
+// isDefaultAnalyzer reports whether id belongs to a built-in analyzer.
+func isDefaultAnalyzer(id string) bool {
+	for _, def := range defaultAnalyzers {
+		if def.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,411 @@
+package analyzers_test
+
+import (
+	"log"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/analyzers"
+	"github.com/securego/gosec/v2/taint"
+	"github.com/securego/gosec/v2/testutils"
+)
+
+var _ = Describe("user-defined taint rules", func() {
+	var (
+		logger *log.Logger
+		rule   analyzers.TaintRule
+	)
+
+	BeforeEach(func() {
+		logger, _ = testutils.NewLogger()
+		rule = analyzers.TaintRule{
+			ID:          "G790",
+			Description: "Working directory controlled by user input",
+			Severity:    "high",
+			CWE:         "CWE-22",
+			Sources:     []taint.Source{{Package: "net/http", Name: "Request", Pointer: true}},
+			Sinks:       []taint.Sink{{Package: "os", Method: "Chdir"}},
+			Sanitizers:  []taint.Sanitizer{{Package: "path/filepath", Method: "Base"}},
+		}
+	})
+
+	scan := func(code string, defs []analyzers.AnalyzerDefinition, filters ...analyzers.AnalyzerFilter) int {
+		analyzer := gosec.NewAnalyzer(gosec.NewConfig(), false, false, false, 1, logger)
+		analyzer.LoadAnalyzers(analyzers.GenerateWith(false, defs, filters...).AnalyzersInfo())
+		pkg := testutils.NewTestPackage()
+		defer pkg.Close()
+		pkg.AddFile("main.go", code)
+		Expect(pkg.Build()).ShouldNot(HaveOccurred())
+		Expect(analyzer.Process(nil, pkg.Path)).ShouldNot(HaveOccurred())
+		issues, _, _ := analyzer.Report()
+		return len(issues)
+	}
+
+	Context("validation", func() {
+		It("should accept a complete rule and normalize its severity", func() {
+			Expect(rule.Validate()).Should(Succeed())
+			Expect(rule.Severity).Should(Equal("HIGH"))
+		})
+
+		It("should default the severity to MEDIUM", func() {
+			rule.Severity = ""
+			Expect(rule.Validate()).Should(Succeed())
+			Expect(rule.Severity).Should(Equal("MEDIUM"))
+		})
+
+		It("should reject IDs which cannot be referenced by #nosec", func() {
+			rule.ID = "CUSTOM1"
+			Expect(rule.Validate()).Should(MatchError(ContainSubstring("GNNN")))
+		})
+
+		It("should reject IDs of built-in analyzers", func() {
+			rule.ID = "G701"
+			Expect(rule.Validate()).Should(MatchError(ContainSubstring("built-in")))
+		})
+
+		It("should reject an invalid severity", func() {
+			rule.Severity = "urgent"
+			Expect(rule.Validate()).Should(MatchError(ContainSubstring("invalid severity")))
+		})
+
+		It("should require sources and sinks", func() {
+			rule.Sinks = nil
+			Expect(rule.Validate()).Should(MatchError(ContainSubstring("sink")))
+			rule.Sources = nil
+			Expect(rule.Validate()).Should(MatchError(ContainSubstring("source")))
+		})
+
+		It("should reject incomplete entries", func() {
+			rule.Sinks = []taint.Sink{{Package: "os"}}
+			Expect(rule.Validate()).Should(MatchError(ContainSubstring("sinks[0]")))
+		})
+
+		It("should reject negative sanitizer positions", func() {
+			rule.Sanitizers = []taint.Sanitizer{{Package: "path/filepath", Method: "Base", Args: []int{-1}}}
+			Expect(rule.Validate()).Should(MatchError(ContainSubstring("sanitizers[0]: args index -1")))
+			rule.Sanitizers = []taint.Sanitizer{{Package: "path/filepath", Method: "Base", Results: []int{-1}}}
+			Expect(rule.Validate()).Should(MatchError(ContainSubstring("sanitizers[0]: results index -1")))
+		})
+
+		It("should reject duplicated rule IDs", func() {
+			_, err := analyzers.NewTaintRuleDefinitions([]analyzers.TaintRule{rule, rule})
+			Expect(err).Should(MatchError(ContainSubstring("duplicate")))
+		})
+	})
+
+	Context("registration", func() {
+		It("should be generated alongside the default analyzers", func() {
+			defs, err := analyzers.NewTaintRuleDefinitions([]analyzers.TaintRule{rule})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			list := analyzers.GenerateWith(false, defs)
+			Expect(list.Analyzers).Should(HaveKey("G790"))
+			Expect(list.Analyzers).Should(HaveKey("G701"))
+		})
+
+		It("should respect the include and exclude filters", func() {
+			defs, err := analyzers.NewTaintRuleDefinitions([]analyzers.TaintRule{rule})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			list := analyzers.GenerateWith(false, defs, analyzers.NewAnalyzerFilter(true, "G790"))
+			Expect(list.Analyzers).ShouldNot(HaveKey("G790"))
+
+			list = analyzers.GenerateWith(false, defs, analyzers.NewAnalyzerFilter(false, "G790"))
+			Expect(list.Analyzers).Should(HaveLen(1))
+			Expect(list.Analyzers).Should(HaveKey("G790"))
+		})
+	})
+
+	Context("analysis", func() {
+		const vulnerable = `
+package main
+
+import (
+	"net/http"
+	"os"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	os.Chdir(r.URL.Query().Get("dir"))
+}
+
+func main() {
+	http.HandleFunc("/", handler)
+}
+`
+		It("should report flows into the configured sink", func() {
+			defs, err := analyzers.NewTaintRuleDefinitions([]analyzers.TaintRule{rule})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(scan(vulnerable, defs, analyzers.NewAnalyzerFilter(false, "G790"))).Should(Equal(1))
+		})
+
+		It("should honour the configured sanitizers", func() {
+			defs, err := analyzers.NewTaintRuleDefinitions([]analyzers.TaintRule{rule})
+			Expect(err).ShouldNot(HaveOccurred())
+			code := `
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	os.Chdir(filepath.Base(r.URL.Query().Get("dir")))
+}
+
+func main() {
+	http.HandleFunc("/", handler)
+}
+`
+			Expect(scan(code, defs, analyzers.NewAnalyzerFilter(false, "G790"))).Should(Equal(0))
+		})
+
+		It("should be suppressed by #nosec directives naming the rule", func() {
+			defs, err := analyzers.NewTaintRuleDefinitions([]analyzers.TaintRule{rule})
+			Expect(err).ShouldNot(HaveOccurred())
+			code := `
+package main
+
+import (
+	"net/http"
+	"os"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	os.Chdir(r.URL.Query().Get("dir")) // #nosec G790 -- directory is validated by the proxy
+}
+
+func main() {
+	http.HandleFunc("/", handler)
+}
+`
+			Expect(scan(code, defs, analyzers.NewAnalyzerFilter(false, "G790"))).Should(Equal(0))
+		})
+	})
+
+	Context("extensions", func() {
+		It("should reject unknown rule IDs", func() {
+			_, err := analyzers.NewTaintDefinitions(nil, analyzers.TaintExtensions{
+				"G799": {Config: taint.Config{Sinks: []taint.Sink{{Package: "os", Method: "Chdir"}}}},
+			})
+			Expect(err).Should(MatchError(ContainSubstring("unknown taint rule G799")))
+		})
+
+		It("should reject built-in rules which are not taint rules", func() {
+			_, err := analyzers.NewTaintDefinitions(nil, analyzers.TaintExtensions{
+				"G115": {Config: taint.Config{Sinks: []taint.Sink{{Package: "os", Method: "Chdir"}}}},
+			})
+			Expect(err).Should(MatchError(ContainSubstring("G115 is not a taint analysis rule")))
+		})
+
+		It("should reject incomplete entries", func() {
+			_, err := analyzers.NewTaintDefinitions(nil, analyzers.TaintExtensions{
+				"G703": {Config: taint.Config{Sanitizers: []taint.Sanitizer{{Package: "example.com/sanitize"}}}},
+			})
+			Expect(err).Should(MatchError(ContainSubstring("taint-extensions: G703: sanitizers[0]")))
+		})
+
+		It("should reject changing the class of a rule", func() {
+			_, err := analyzers.NewTaintDefinitions(nil, analyzers.TaintExtensions{
+				"G705": {Config: taint.Config{Class: "sql"}},
+			})
+			Expect(err).Should(MatchError(ContainSubstring("class")))
+		})
+
+		It("should replace only the extended built-in rules", func() {
+			defs, err := analyzers.NewTaintDefinitions([]analyzers.TaintRule{rule}, analyzers.TaintExtensions{
+				"G703": {Config: taint.Config{Sinks: []taint.Sink{{Package: "os", Method: "Chdir"}}}},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			ids := make([]string, 0, len(defs))
+			for _, def := range defs {
+				ids = append(ids, def.ID)
+			}
+			Expect(ids).Should(ConsistOf("G790", "G703"))
+		})
+
+		It("should apply the wildcard entry to every taint rule", func() {
+			defs, err := analyzers.NewTaintDefinitions([]analyzers.TaintRule{rule}, analyzers.TaintExtensions{
+				"*": {Config: taint.Config{Sinks: []taint.Sink{{Package: "os", Method: "Chdir"}}}},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(defs).Should(HaveLen(12))
+		})
+
+		It("should report flows into an added sink of a built-in rule", func() {
+			code := `
+package main
+
+import (
+	"net/http"
+	"os"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	os.Chdir(r.URL.Query().Get("dir"))
+}
+
+func main() {
+	http.HandleFunc("/", handler)
+}
+`
+			Expect(scan(code, nil, analyzers.NewAnalyzerFilter(false, "G703"))).Should(Equal(0))
+
+			defs, err := analyzers.NewTaintDefinitions(nil, analyzers.TaintExtensions{
+				"G703": {Config: taint.Config{Sinks: []taint.Sink{{Package: "os", Method: "Chdir"}}}},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(scan(code, defs, analyzers.NewAnalyzerFilter(false, "G703"))).Should(Equal(1))
+		})
+
+		It("should honour an added sanitizer of a built-in rule", func() {
+			code := `
+package main
+
+import (
+	"net/http"
+	"os"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	f, _ := os.Open(strings.ToLower(r.URL.Query().Get("file")))
+	defer f.Close()
+}
+
+func main() {
+	http.HandleFunc("/", handler)
+}
+`
+			Expect(scan(code, nil, analyzers.NewAnalyzerFilter(false, "G703"))).Should(Equal(1))
+
+			defs, err := analyzers.NewTaintDefinitions(nil, analyzers.TaintExtensions{
+				"G703": {Config: taint.Config{Sanitizers: []taint.Sanitizer{{Package: "strings", Method: "ToLower"}}}},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(scan(code, defs, analyzers.NewAnalyzerFilter(false, "G703"))).Should(Equal(0))
+		})
+	})
+
+	Context("source classes", func() {
+		storage := func(id string) analyzers.TaintExtensions {
+			return analyzers.TaintExtensions{id: {SourceClasses: []string{analyzers.StorageSourceClass}}}
+		}
+
+		It("should reject unknown source classes", func() {
+			_, err := analyzers.NewTaintDefinitions(nil, analyzers.TaintExtensions{
+				"G701": {SourceClasses: []string{"cache"}},
+			})
+			Expect(err).Should(MatchError(ContainSubstring(`G701: unknown source class "cache"`)))
+		})
+
+		It("should reject sources filling arguments which are not functions", func() {
+			_, err := analyzers.NewTaintDefinitions(nil, analyzers.TaintExtensions{
+				"G701": {Config: taint.Config{Sources: []taint.Source{{Package: "example.com/store", Name: "Load", Args: []int{1}}}}},
+			})
+			Expect(err).Should(MatchError(ContainSubstring("sources[0]: receiver and args require is_func")))
+		})
+
+		It("should report second-order SQL injection from scanned rows", func() {
+			code := `
+package main
+
+import (
+	"database/sql"
+)
+
+func rename(db *sql.DB, id int) error {
+	var name string
+	if err := db.QueryRow("SELECT name FROM users WHERE id = ?", id).Scan(&name); err != nil {
+		return err
+	}
+	_, err := db.Exec("UPDATE audit SET last = '" + name + "'")
+	return err
+}
+
+func main() {
+	db, _ := sql.Open("sqlite", "app.db")
+	_ = rename(db, 1)
+}
+`
+			Expect(scan(code, nil, analyzers.NewAnalyzerFilter(false, "G701"))).Should(Equal(0))
+
+			defs, err := analyzers.NewTaintDefinitions(nil, storage("G701"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(scan(code, defs, analyzers.NewAnalyzerFilter(false, "G701"))).Should(Equal(1))
+		})
+
+		It("should taint only the fields filled by a decoder", func() {
+			code := `
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+)
+
+type page struct {
+	Title string
+}
+
+var banner = "<h1>gosec</h1>"
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	f, err := os.Open("page.json")
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	var p page
+	if err := json.NewDecoder(f).Decode(&p); err != nil {
+		return
+	}
+	w.Write([]byte(p.Title))
+	w.Write([]byte(banner))
+}
+
+func main() {
+	http.HandleFunc("/", handler)
+}
+`
+			Expect(scan(code, nil, analyzers.NewAnalyzerFilter(false, "G705"))).Should(Equal(0))
+
+			defs, err := analyzers.NewTaintDefinitions(nil, storage("G705"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(scan(code, defs, analyzers.NewAnalyzerFilter(false, "G705"))).Should(Equal(1))
+		})
+
+		It("should report stored XSS from a redis cache", func() {
+			code := `
+package main
+
+import (
+	"net/http"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var rdb = redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	bio := rdb.Get(r.Context(), "bio").Val()
+	w.Write([]byte(bio))
+}
+
+func main() {
+	http.HandleFunc("/", handler)
+}
+`
+			Expect(scan(code, nil, analyzers.NewAnalyzerFilter(false, "G705"))).Should(Equal(0))
+
+			defs, err := analyzers.NewTaintDefinitions(nil, storage("*"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(scan(code, defs, analyzers.NewAnalyzerFilter(false, "G705"))).Should(Equal(1))
+		})
+	})
+})
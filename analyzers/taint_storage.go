@@ -0,0 +1,64 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import "github.com/securego/gosec/v2/taint"
+
+// StorageSourceClass is the source class treating the data read back from
+// databases, caches and files as untrusted. It catches stored XSS and
+// second-order SQL injection, at the price of more findings, so the rules
+// enable it only through the "source_classes" of their taint-extensions.
+const StorageSourceClass = "storage"
+
+// redisPackage is the import path of the go-redis client.
+const redisPackage = "github.com/redis/go-redis/v9"
+
+// sourceClasses maps the source classes which can be enabled per rule to the
+// function producing their sources.
+var sourceClasses = map[string]func() []taint.Source{
+	StorageSourceClass: storageSources,
+}
+
+// storageSources returns the functions reading stored data. Scan and Decode
+// style calls fill their pointer arguments rather than returning the data.
+func storageSources() []taint.Source {
+	return []taint.Source{
+		// database/sql scans the columns into the destinations
+		{Package: "database/sql", Receiver: "Rows", Name: "Scan", Pointer: true, IsFunc: true, Args: []int{1}},
+		{Package: "database/sql", Receiver: "Row", Name: "Scan", Pointer: true, IsFunc: true, Args: []int{1}},
+		{Package: sqlxPackage, Receiver: "DB", Name: "Get", Pointer: true, IsFunc: true, Args: []int{1}},
+		{Package: sqlxPackage, Receiver: "DB", Name: "Select", Pointer: true, IsFunc: true, Args: []int{1}},
+
+		// decoding stored documents
+		{Package: "encoding/json", Receiver: "Decoder", Name: "Decode", Pointer: true, IsFunc: true, Args: []int{1}},
+		{Package: "encoding/json", Name: "Unmarshal", IsFunc: true, Args: []int{1}},
+
+		// files
+		{Package: "os", Name: "ReadFile", IsFunc: true},
+		{Package: "io/ioutil", Name: "ReadFile", IsFunc: true},
+
+		// The go-redis commands are promoted from an unexported type shared by
+		// the clients, pipelines and transactions, hence no receiver. The
+		// value of the returned command is tainted.
+		{Package: redisPackage, Name: "Get", IsFunc: true},
+		{Package: redisPackage, Name: "GetDel", IsFunc: true},
+		{Package: redisPackage, Name: "MGet", IsFunc: true},
+		{Package: redisPackage, Name: "HGet", IsFunc: true},
+		{Package: redisPackage, Name: "HGetAll", IsFunc: true},
+		{Package: redisPackage, Name: "HMGet", IsFunc: true},
+		{Package: redisPackage, Name: "LRange", IsFunc: true},
+		{Package: redisPackage, Name: "SMembers", IsFunc: true},
+	}
+}
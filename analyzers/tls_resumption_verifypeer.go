@@ -115,7 +115,7 @@ func (s *tlsResumptionState) trackTLSConfigFieldStore(store *ssa.Store) {
 		return
 	}
 
-	root := tlsConfigRoot(fieldAddr.X, 0)
+	root := tlsConfigRoot(fieldAddr.X, 0, s.maxDepth())
 	if root == nil {
 		return
 	}
@@ -246,7 +246,7 @@ func (s *tlsResumptionState) getConfigForClientReturnsRiskyTLSConfig(fns []*ssa.
 }
 
 func (s *tlsResumptionState) extractTLSConfigsFromValue(v ssa.Value, visited map[ssa.Value]struct{}, depth int) []*tlsConfigState {
-	if v == nil || depth > MaxDepth {
+	if v == nil || depth > s.maxDepth() {
 		return nil
 	}
 	if _, ok := visited[v]; ok {
@@ -254,7 +254,7 @@ func (s *tlsResumptionState) extractTLSConfigsFromValue(v ssa.Value, visited map
 	}
 	visited[v] = struct{}{}
 
-	root := tlsConfigRoot(v, 0)
+	root := tlsConfigRoot(v, 0, s.maxDepth())
 	if root != nil {
 		if cfg, ok := s.configs[root]; ok {
 			return []*tlsConfigState{cfg}
@@ -292,8 +292,8 @@ func (s *tlsResumptionState) addIssue(pos token.Pos) {
 	s.issuesByPos[pos] = newIssue(s.Pass.Analyzer.Name, msgTLSResumptionVerifyPeerBypass, s.Pass.Fset, pos, issue.High, issue.High)
 }
 
-func tlsConfigRoot(v ssa.Value, depth int) ssa.Value {
-	if v == nil || depth > MaxDepth {
+func tlsConfigRoot(v ssa.Value, depth int, maxDepth int) ssa.Value {
+	if v == nil || depth > maxDepth {
 		return nil
 	}
 
@@ -303,18 +303,18 @@ func tlsConfigRoot(v ssa.Value, depth int) ssa.Value {
 
 	switch value := v.(type) {
 	case *ssa.ChangeType:
-		return tlsConfigRoot(value.X, depth+1)
+		return tlsConfigRoot(value.X, depth+1, maxDepth)
 	case *ssa.MakeInterface:
-		return tlsConfigRoot(value.X, depth+1)
+		return tlsConfigRoot(value.X, depth+1, maxDepth)
 	case *ssa.TypeAssert:
-		return tlsConfigRoot(value.X, depth+1)
+		return tlsConfigRoot(value.X, depth+1, maxDepth)
 	case *ssa.UnOp:
-		return tlsConfigRoot(value.X, depth+1)
+		return tlsConfigRoot(value.X, depth+1, maxDepth)
 	case *ssa.FieldAddr:
-		return tlsConfigRoot(value.X, depth+1)
+		return tlsConfigRoot(value.X, depth+1, maxDepth)
 	case *ssa.Phi:
 		if len(value.Edges) > 0 {
-			return tlsConfigRoot(value.Edges[0], depth+1)
+			return tlsConfigRoot(value.Edges[0], depth+1, maxDepth)
 		}
 	}
 
@@ -34,7 +34,8 @@ import (
 // DoS and external entity expansion.
 func UnsafeDeserialization() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
+		Class: "deserialization",
+		Sources: append([]taint.Source{
 			// Type sources: tainted when received as parameters
 			{Package: "net/http", Name: "Request", Pointer: true},
 			{Package: "net/url", Name: "Values"},
@@ -46,7 +47,7 @@ func UnsafeDeserialization() taint.Config {
 			// I/O sources
 			{Package: "bufio", Name: "Reader", Pointer: true},
 			{Package: "bufio", Name: "Scanner", Pointer: true},
-		},
+		}, frameworkSources()...),
 		Sinks: []taint.Sink{
 			// encoding/gob — highest risk: arbitrary type instantiation from wire format
 			// gob.NewDecoder takes an io.Reader (arg 0), so if the reader is tainted
@@ -31,7 +31,8 @@ import (
 	"github.com/securego/gosec/v2/issue"
 )
 
-// MaxDepth defines the maximum recursion depth for SSA analysis to avoid infinite loops and memory exhaustion.
+// MaxDepth defines the default maximum recursion depth for SSA analysis to avoid infinite loops and memory exhaustion.
+// The max-depth of the analyzer-limits configuration overrides it.
 const MaxDepth = 20
 
 const (
@@ -53,6 +54,7 @@ type BaseAnalyzerState struct {
 	BlockMap     map[*ssa.BasicBlock]bool
 	ClosureCache map[ssa.Value]bool
 	Depth        int
+	DepthLimit   int // Maximum Depth, MaxDepth when zero
 }
 
 // Error aliases for backward compatibility
@@ -86,16 +88,28 @@ var (
 
 // NewBaseState creates a new BaseAnalyzerState with pooled maps.
 func NewBaseState(pass *analysis.Pass) *BaseAnalyzerState {
+	depthLimit := passMaxDepth(pass)
+	rangeAnalyzer := NewRangeAnalyzer()
+	rangeAnalyzer.DepthLimit = depthLimit
 	return &BaseAnalyzerState{
 		Pass:         pass,
-		Analyzer:     NewRangeAnalyzer(),
+		Analyzer:     rangeAnalyzer,
 		Visited:      visitedPool.Get().(map[ssa.Value]bool),
 		FuncMap:      funcMapPool.Get().(map[*ssa.Function]bool),
 		BlockMap:     blockMapPool.Get().(map[*ssa.BasicBlock]bool),
 		ClosureCache: closureCachePool.Get().(map[ssa.Value]bool),
+		DepthLimit:   depthLimit,
 	}
 }
 
+// maxDepth returns the maximum recursion depth of the analysis.
+func (s *BaseAnalyzerState) maxDepth() int {
+	if s.DepthLimit > 0 {
+		return s.DepthLimit
+	}
+	return MaxDepth
+}
+
 // Reset clears the caches and maps for reuse within an analyzer run.
 func (s *BaseAnalyzerState) Reset() {
 	if s.Analyzer != nil {
@@ -139,7 +153,7 @@ func (s *BaseAnalyzerState) Release() {
 // ResolveFuncs resolves a value to a list of possible functions (e.g., closures, phi nodes).
 // It reuses the state's ClosureCache to avoid cycles and redundant work.
 func (s *BaseAnalyzerState) ResolveFuncs(val ssa.Value, funcs *[]*ssa.Function) {
-	if val == nil || s.Depth > MaxDepth {
+	if val == nil || s.Depth > s.maxDepth() {
 		return
 	}
 	if s.ClosureCache[val] {
@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/ssa"
 )
 
@@ -605,3 +606,31 @@ var _ = Describe("Utility helper functions", func() {
 		})
 	})
 })
+
+var _ = Describe("NewBaseState", func() {
+	newPass := func(config map[string]any) *analysis.Pass {
+		return &analysis.Pass{ResultOf: map[*analysis.Analyzer]any{
+			buildssa.Analyzer: &SSAAnalyzerResult{Config: config},
+		}}
+	}
+
+	It("should limit the depth to MaxDepth by default", func() {
+		state := NewBaseState(newPass(nil))
+		defer state.Release()
+		Expect(state.maxDepth()).To(Equal(MaxDepth))
+		Expect(state.Analyzer.maxDepth()).To(Equal(MaxDepth))
+	})
+
+	It("should limit the depth to the configured max-depth", func() {
+		state := NewBaseState(newPass(map[string]any{LimitsConfigKey: map[string]any{"max-depth": 40}}))
+		defer state.Release()
+		Expect(state.maxDepth()).To(Equal(40))
+		Expect(state.Analyzer.maxDepth()).To(Equal(40))
+	})
+
+	It("should keep the default depth for invalid limits", func() {
+		state := NewBaseState(newPass(map[string]any{LimitsConfigKey: map[string]any{"max-depth": -1}}))
+		defer state.Release()
+		Expect(state.maxDepth()).To(Equal(MaxDepth))
+	})
+})
@@ -149,7 +149,7 @@ func (s *walkSymlinkRaceState) scanCallbackForRaceSinks(fn *ssa.Function, pathPa
 				if idx >= len(common.Args) {
 					continue
 				}
-				if pathDependsOn(common.Args[idx], pathParam, 0, map[ssa.Value]struct{}{}) {
+				if pathDependsOn(common.Args[idx], pathParam, 0, s.maxDepth(), map[ssa.Value]struct{}{}) {
 					s.addIssue(instr.Pos())
 					break
 				}
@@ -259,8 +259,8 @@ func isStringType(t types.Type) bool {
 	return basic.Kind() == types.String
 }
 
-func pathDependsOn(value ssa.Value, target ssa.Value, depth int, visited map[ssa.Value]struct{}) bool {
-	if value == nil || target == nil || depth > MaxDepth {
+func pathDependsOn(value ssa.Value, target ssa.Value, depth int, maxDepth int, visited map[ssa.Value]struct{}) bool {
+	if value == nil || target == nil || depth > maxDepth {
 		return false
 	}
 	if value == target {
@@ -271,29 +271,29 @@ func pathDependsOn(value ssa.Value, target ssa.Value, depth int, visited map[ssa
 	}
 	visited[value] = struct{}{}
 
-	if valueDependsOn(value, target, depth) {
+	if valueDependsOn(value, target, depth, maxDepth) {
 		return true
 	}
 
 	switch v := value.(type) {
 	case *ssa.BinOp:
-		return pathDependsOn(v.X, target, depth+1, visited) || pathDependsOn(v.Y, target, depth+1, visited)
+		return pathDependsOn(v.X, target, depth+1, maxDepth, visited) || pathDependsOn(v.Y, target, depth+1, maxDepth, visited)
 	case *ssa.Convert:
-		return pathDependsOn(v.X, target, depth+1, visited)
+		return pathDependsOn(v.X, target, depth+1, maxDepth, visited)
 	case *ssa.UnOp:
-		if pathDependsOn(v.X, target, depth+1, visited) {
+		if pathDependsOn(v.X, target, depth+1, maxDepth, visited) {
 			return true
 		}
 		if v.Op == token.MUL {
 			for _, stored := range storedValues(v.X) {
-				if pathDependsOn(stored, target, depth+1, visited) {
+				if pathDependsOn(stored, target, depth+1, maxDepth, visited) {
 					return true
 				}
 			}
 		}
 	case *ssa.Call:
 		for _, arg := range v.Call.Args {
-			if pathDependsOn(arg, target, depth+1, visited) {
+			if pathDependsOn(arg, target, depth+1, maxDepth, visited) {
 				return true
 			}
 		}
@@ -23,7 +23,8 @@ import (
 // XSS returns a configuration for detecting Cross-Site Scripting vulnerabilities.
 func XSS() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
+		Class: "xss",
+		Sources: append([]taint.Source{
 			// Type sources: tainted when received as parameters
 			{Package: "net/http", Name: "Request", Pointer: true},
 			{Package: "net/url", Name: "Values"},
@@ -34,8 +35,8 @@ func XSS() taint.Config {
 			// I/O sources
 			{Package: "bufio", Name: "Reader", Pointer: true},
 			{Package: "bufio", Name: "Scanner", Pointer: true},
-		},
-		Sinks: []taint.Sink{
+		}, frameworkSources()...),
+		Sinks: append([]taint.Sink{
 			// Direct write on the response writer itself — receiver already scopes it.
 			{Package: "net/http", Receiver: "ResponseWriter", Method: "Write"},
 			// fmt print family: arg[0] is the io.Writer target; args[1..n] are the
@@ -72,7 +73,7 @@ func XSS() taint.Config {
 			{Package: "html/template", Method: "HTMLAttr"},
 			{Package: "html/template", Method: "JS"},
 			{Package: "html/template", Method: "CSS"},
-		},
+		}, frameworkXSSSinks()...),
 		Sanitizers: []taint.Sanitizer{
 			// html.EscapeString escapes HTML special characters
 			{Package: "html", Method: "EscapeString"},
@@ -101,6 +102,7 @@ func XSS() taint.Config {
 			{Package: "strconv", Method: "FormatUint"},
 			{Package: "strconv", Method: "FormatFloat"},
 		},
+		Guards: regexpGuards(),
 	}
 }
 
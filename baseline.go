@@ -0,0 +1,147 @@
+package gosec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/securego/gosec/v2/issue"
+)
+
+// BaselineVersion is the version of the format of the baseline files. It
+// changes with the fingerprint scheme, since the fingerprints of the files of
+// another version would match no finding. Version 2 fingerprints the file
+// path qualified by its module and the occurrence among the identical
+// findings, and has no count of findings per entry.
+const BaselineVersion = 2
+
+// Baseline lists the accepted findings of a code base, such that only the new
+// ones are reported. The findings are identified by their fingerprint rather
+// than by their line, which changes with the unrelated edits of the file.
+type Baseline struct {
+	Version  int             `json:"version"`
+	Findings []BaselineEntry `json:"findings"`
+
+	path string
+}
+
+// BaselineEntry is a finding of a baseline. Only its fingerprint is matched,
+// the other fields help reviewing the file.
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	RuleID      string `json:"rule_id"`
+	File        string `json:"file"`
+	Function    string `json:"function,omitempty"`
+	Code        string `json:"code"`
+}
+
+// NewBaseline builds the baseline of the given issues, whose fingerprints are
+// set. The issues ignored by a #nosec annotation or suppressed are left out.
+func NewBaseline(issues []*issue.Issue, fingerprinter *issue.Fingerprinter) *Baseline {
+	baseline := &Baseline{Version: BaselineVersion, Findings: make([]BaselineEntry, 0, len(issues))}
+	for _, iss := range issues {
+		if iss.NoSec || len(iss.Suppressions) > 0 || iss.Fingerprint == "" {
+			continue
+		}
+		parts := fingerprinter.Parts(iss)
+		baseline.Findings = append(baseline.Findings, BaselineEntry{
+			Fingerprint: iss.Fingerprint,
+			RuleID:      parts.RuleID,
+			File:        parts.File,
+			Function:    parts.Function,
+			Code:        parts.Snippet,
+		})
+	}
+	sort.Slice(baseline.Findings, func(i, j int) bool {
+		a, b := baseline.Findings[i], baseline.Findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		if a.Function != b.Function {
+			return a.Function < b.Function
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+	return baseline
+}
+
+// ReadBaseline loads a baseline file.
+func ReadBaseline(path string) (*Baseline, error) {
+	// #nosec G304 -- the baseline file is chosen by the user
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if baseline.Version != BaselineVersion {
+		return nil, fmt.Errorf("%s: unsupported baseline version %d, expected %d", path, baseline.Version, BaselineVersion)
+	}
+	for i, entry := range baseline.Findings {
+		if entry.Fingerprint == "" {
+			return nil, fmt.Errorf("%s: findings[%d]: missing fingerprint", path, i)
+		}
+	}
+	baseline.path = path
+	return &baseline, nil
+}
+
+// WriteFile saves the baseline.
+func (b *Baseline) WriteFile(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// Apply removes the issues accepted by the baseline, or marks them as
+// suppressed when markSuppressed is set. It also returns the number of
+// accepted issues and the stale entries, which match no issue anymore.
+func (b *Baseline) Apply(issues []*issue.Issue, markSuppressed bool) ([]*issue.Issue, int, []BaselineEntry) {
+	remaining := make(map[string]bool, len(b.Findings))
+	for _, entry := range b.Findings {
+		remaining[entry.Fingerprint] = true
+	}
+
+	justification := "Accepted in the baseline."
+	if b.path != "" {
+		justification = fmt.Sprintf("Accepted in the baseline %s.", b.path)
+	}
+
+	result := make([]*issue.Issue, 0, len(issues))
+	accepted := 0
+	for _, iss := range issues {
+		if iss.NoSec || len(iss.Suppressions) > 0 {
+			result = append(result, iss)
+			continue
+		}
+		if !remaining[iss.Fingerprint] {
+			result = append(result, iss)
+			continue
+		}
+		delete(remaining, iss.Fingerprint)
+		accepted++
+		if markSuppressed {
+			iss.WithSuppressions([]issue.SuppressionInfo{{
+				Kind:          "external",
+				Justification: justification,
+			}})
+			result = append(result, iss)
+		}
+	}
+
+	var stale []BaselineEntry
+	for _, entry := range b.Findings {
+		if remaining[entry.Fingerprint] {
+			stale = append(stale, entry)
+		}
+	}
+	return result, accepted, stale
+}
@@ -0,0 +1,108 @@
+package gosec_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+)
+
+var _ = Describe("Baseline", func() {
+	var (
+		dir           string
+		file          string
+		fingerprinter *issue.Fingerprinter
+	)
+
+	newIssue := func(ruleID, line, code string) *issue.Issue {
+		return &issue.Issue{RuleID: ruleID, File: file, Line: line, Code: code}
+	}
+
+	fingerprinted := func(issues ...*issue.Issue) []*issue.Issue {
+		issue.NewFingerprinter().SetFingerprints(issues)
+		return issues
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		file = filepath.Join(dir, "main.go")
+		Expect(os.WriteFile(file, []byte("package main\n\nfunc main() {\n\tcall()\n\tcall()\n\trun()\n}\n"), 0o600)).To(Succeed())
+		fingerprinter = issue.NewFingerprinter()
+	})
+
+	It("should record the findings which are not suppressed", func() {
+		issues := fingerprinted(
+			newIssue("G104", "4", "4: \tcall()\n"),
+			newIssue("G104", "5", "5: \tcall()\n"),
+			newIssue("G204", "6", "6: \trun()\n"),
+			newIssue("G204", "6", "6: \trun()\n").WithSuppressions([]issue.SuppressionInfo{{Kind: "inSource"}}),
+		)
+
+		baseline := gosec.NewBaseline(issues, fingerprinter)
+		Expect(baseline.Version).To(Equal(gosec.BaselineVersion))
+		Expect(baseline.Findings).To(HaveLen(3))
+		entry := baseline.Findings[0]
+		Expect(entry.RuleID).To(Equal("G104"))
+		Expect(entry.File).To(Equal(filepath.ToSlash(file)))
+		Expect(entry.Function).To(Equal("main"))
+		Expect(entry.Code).To(Equal("call()"))
+		Expect(baseline.Findings[1].Fingerprint).NotTo(Equal(entry.Fingerprint))
+	})
+
+	It("should round trip through a file", func() {
+		path := filepath.Join(dir, "baseline.json")
+		baseline := gosec.NewBaseline(fingerprinted(newIssue("G104", "4", "4: \tcall()\n")), fingerprinter)
+		Expect(baseline.WriteFile(path)).To(Succeed())
+
+		loaded, err := gosec.ReadBaseline(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(loaded.Findings).To(Equal(baseline.Findings))
+	})
+
+	It("should reject the files of another version", func() {
+		path := filepath.Join(dir, "baseline.json")
+		Expect(os.WriteFile(path, []byte(`{"version": 1, "findings": []}`), 0o600)).To(Succeed())
+
+		_, err := gosec.ReadBaseline(path)
+		Expect(err).Should(MatchError(ContainSubstring("unsupported baseline version 1, expected 2")))
+	})
+
+	It("should drop the accepted findings and report the stale entries", func() {
+		baseline := gosec.NewBaseline(fingerprinted(
+			newIssue("G104", "4", "4: \tcall()\n"),
+			newIssue("G204", "6", "6: \trun()\n"),
+		), fingerprinter)
+
+		// The findings moved, one call was added and run() was fixed.
+		Expect(os.WriteFile(file, []byte("package main\n\n// main runs.\nfunc main() {\n\tcall()\n\tcall()\n}\n"), 0o600)).To(Succeed())
+		issues, accepted, stale := baseline.Apply(fingerprinted(
+			newIssue("G104", "5", "5: \tcall()\n"),
+			newIssue("G104", "6", "6: \tcall()\n"),
+		), false)
+		Expect(accepted).To(Equal(1))
+		Expect(issues).To(HaveLen(1))
+		Expect(issues[0].Line).To(Equal("6"))
+		Expect(stale).To(HaveLen(1))
+		Expect(stale[0].RuleID).To(Equal("G204"))
+	})
+
+	It("should mark the accepted findings as suppressed", func() {
+		path := filepath.Join(dir, "baseline.json")
+		Expect(gosec.NewBaseline(fingerprinted(newIssue("G104", "4", "4: \tcall()\n")), fingerprinter).WriteFile(path)).To(Succeed())
+		baseline, err := gosec.ReadBaseline(path)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		issues, accepted, stale := baseline.Apply(fingerprinted(newIssue("G104", "4", "4: \tcall()\n")), true)
+		Expect(accepted).To(Equal(1))
+		Expect(stale).To(BeEmpty())
+		Expect(issues).To(HaveLen(1))
+		Expect(issues[0].Suppressions).To(Equal([]issue.SuppressionInfo{{
+			Kind:          "external",
+			Justification: "Accepted in the baseline " + path + ".",
+		}}))
+	})
+})
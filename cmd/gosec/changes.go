@@ -0,0 +1,275 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/securego/gosec/v2/issue"
+)
+
+// wholeFile is the line range of the files added from scratch.
+var wholeFile = lineRange{start: 1, end: int(^uint(0) >> 1)}
+
+// lineRange is a range of lines, bounds included.
+type lineRange struct {
+	start, end int
+}
+
+// changedLines maps the absolute paths of the changed files to the ranges of
+// their lines which were added or modified.
+type changedLines map[string][]lineRange
+
+func (c changedLines) add(file string, lines lineRange) {
+	ranges := c[file]
+	if n := len(ranges); n > 0 && ranges[n-1].end+1 >= lines.start {
+		ranges[n-1].end = max(ranges[n-1].end, lines.end)
+		return
+	}
+	c[file] = append(ranges, lines)
+}
+
+// intersects reports whether one of the lines between start and end of the
+// file changed.
+func (c changedLines) intersects(file string, start, end int) bool {
+	if abs, err := filepath.Abs(file); err == nil {
+		file = abs
+	}
+	for _, lines := range c[file] {
+		if lines.start <= end && start <= lines.end {
+			return true
+		}
+	}
+	return false
+}
+
+// touches reports whether the issue, or one of the steps of its taint trace,
+// is on a changed line.
+func (c changedLines) touches(iss *issue.Issue) bool {
+	start, end := issueLineRange(iss.Line)
+	if c.intersects(iss.File, start, end) {
+		return true
+	}
+	for _, step := range iss.Trace {
+		if c.intersects(step.File, step.Line, step.Line) {
+			return true
+		}
+	}
+	return false
+}
+
+// issueLineRange parses the line of an issue, such as "12" or "12-14".
+func issueLineRange(line string) (int, int) {
+	first, last, found := strings.Cut(line, "-")
+	start, _ := strconv.Atoi(first)
+	end := start
+	if found {
+		end, _ = strconv.Atoi(last)
+	}
+	return start, end
+}
+
+// filterChangedIssues keeps the issues touching the changed lines. It returns
+// them and the number of issues left out.
+func filterChangedIssues(issues []*issue.Issue, changes changedLines) ([]*issue.Issue, int) {
+	result := make([]*issue.Issue, 0, len(issues))
+	for _, iss := range issues {
+		if changes.touches(iss) {
+			result = append(result, iss)
+		}
+	}
+	return result, len(issues) - len(result)
+}
+
+// hunkHeader matches the header of a hunk of a unified diff, and captures the
+// starts and lengths of its ranges in the old and the new file.
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// parseUnifiedDiff returns the lines added or modified by a unified diff. The
+// file paths of the diff, without their a/ and b/ prefixes, are relative to
+// root. A deletion marks the line which follows it as changed.
+func parseUnifiedDiff(r io.Reader, root string) (changedLines, error) {
+	changes := make(changedLines)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	file := ""
+	line, oldLeft, newLeft := 0, 0, 0
+	for scanner.Scan() {
+		text := scanner.Text()
+		if oldLeft > 0 || newLeft > 0 {
+			// The lines of a hunk, counted to tell them from the headers.
+			switch {
+			case strings.HasPrefix(text, "+"):
+				if file != "" {
+					changes.add(file, lineRange{start: line, end: line})
+				}
+				line++
+				newLeft--
+			case strings.HasPrefix(text, "-"):
+				if file != "" {
+					changes.add(file, lineRange{start: line, end: line})
+				}
+				oldLeft--
+			case strings.HasPrefix(text, "\\"):
+				// "\ No newline at end of file"
+			default:
+				line++
+				oldLeft--
+				newLeft--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "+++ "):
+			file = diffPath(strings.TrimPrefix(text, "+++ "), root)
+		case strings.HasPrefix(text, "@@"):
+			match := hunkHeader.FindStringSubmatch(text)
+			if match == nil {
+				return nil, fmt.Errorf("invalid hunk header %q", text)
+			}
+			oldLeft = hunkLength(match[2])
+			line, _ = strconv.Atoi(match[3])
+			newLeft = hunkLength(match[4])
+			if newLeft == 0 {
+				// A pure deletion gives the line which precedes it.
+				line++
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// hunkLength parses the length of a hunk range, which is 1 when omitted.
+func hunkLength(length string) int {
+	if length == "" {
+		return 1
+	}
+	n, _ := strconv.Atoi(length)
+	return n
+}
+
+// diffPath converts a file name of a diff into an absolute path, or "" for
+// the deleted files.
+func diffPath(name, root string) string {
+	if tab := strings.IndexByte(name, '\t'); tab >= 0 {
+		name = name[:tab]
+	}
+	if unquoted, err := strconv.Unquote(name); err == nil {
+		name = unquoted
+	}
+	if name == "/dev/null" {
+		return ""
+	}
+	if strings.HasPrefix(name, "a/") || strings.HasPrefix(name, "b/") {
+		name = name[2:]
+	}
+	return filepath.Join(root, filepath.FromSlash(name))
+}
+
+// loadChanges returns the lines changed since the git revision or by the
+// patch, or nil when all the issues are reported.
+func loadChanges(rev, patch string) (changedLines, error) {
+	switch {
+	case rev != "" && patch != "":
+		return nil, errors.New("-new-from-rev and -new-from-patch cannot be used together")
+	case rev != "":
+		return loadRevisionChanges("", rev)
+	case patch != "":
+		return loadPatchChanges(patch)
+	}
+	return nil, nil
+}
+
+// loadPatchChanges reads the changed lines from a unified diff file, whose
+// paths are relative to the current directory.
+func loadPatchChanges(path string) (changedLines, error) {
+	file, err := os.Open(path) // #nosec G304 -- the patch is chosen by the user
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close() // #nosec G307
+	root, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	return parseUnifiedDiff(file, root)
+}
+
+// loadRevisionChanges reads the lines changed since a git revision from the
+// local repository of dir, or of the current directory when dir is empty:
+// those of the committed and uncommitted changes, and the whole untracked
+// files.
+func loadRevisionChanges(dir, rev string) (changedLines, error) {
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return nil, fmt.Errorf("invalid git revision %q", rev)
+	}
+	root, err := runGit(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	top := strings.TrimSpace(string(root))
+
+	diff, err := runGit(top, "diff", "--no-color", "--no-ext-diff", "--unified=0", rev, "--")
+	if err != nil {
+		return nil, err
+	}
+	changes, err := parseUnifiedDiff(bytes.NewReader(diff), top)
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := runGit(top, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range strings.Split(string(untracked), "\x00") {
+		if name != "" {
+			changes.add(filepath.Join(top, filepath.FromSlash(name)), wholeFile)
+		}
+	}
+	return changes, nil
+}
+
+// runGit runs a git command in dir and returns its output.
+func runGit(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...) // #nosec G204 -- the revision cannot be an option
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return output, nil
+}
@@ -0,0 +1,171 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/securego/gosec/v2"
+)
+
+// commandLineSource is the source of the settings given as flags.
+const commandLineSource = "command line"
+
+// runConfigKeys are the settings which apply to the whole run. They are only
+// read from the configuration of the current directory.
+var runConfigKeys = []string{gosec.TaintRulesKey, gosec.TaintExtensionsKey, gosec.ExcludeRulesKey, gosec.FailPolicyKey}
+
+// withConfigFlags merges the global options given on the command line into
+// the effective configuration.
+func withConfigFlags(effective *gosec.EffectiveConfig) *gosec.EffectiveConfig {
+	if globals := configFlags(); len(globals) > 0 {
+		flags := gosec.NewConfig()
+		for option, value := range globals {
+			flags.SetGlobal(option, value)
+		}
+		effective.Merge(flags, commandLineSource)
+	}
+	applyConfigFlags(effective.Config)
+	return effective
+}
+
+// loadPackageConfigs returns the effective configuration of each package
+// directory, or nil when no configuration file applies to any of them.
+// runSources are the configuration files of the current directory, the only
+// ones which may hold the settings of the whole run.
+func loadPackageConfigs(discovery *gosec.ConfigDiscovery, runSources []string, packages []string) (map[string]gosec.Config, error) {
+	configs := make(map[string]gosec.Config, len(packages))
+	found := false
+	for _, pkg := range packages {
+		effective, err := discovery.ConfigFor(pkg)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPackageConfig(effective, runSources); err != nil {
+			return nil, fmt.Errorf("%s: %w", pkg, err)
+		}
+		found = found || len(effective.Sources) > 0
+		configs[pkg] = withConfigFlags(effective).Config
+	}
+	if !found {
+		return nil, nil
+	}
+	return configs, nil
+}
+
+// checkPackageConfig rejects the settings of a package configuration which
+// would otherwise be ignored or dropped during the scan: the settings of the
+// whole run coming from a file which does not apply to the current directory,
+// and the invalid limits and score overrides.
+func checkPackageConfig(effective *gosec.EffectiveConfig, runSources []string) error {
+	settings := make([]string, 0, len(effective.Origins))
+	for setting := range effective.Origins {
+		settings = append(settings, setting)
+	}
+	sort.Strings(settings)
+	for _, setting := range settings {
+		key, _, _ := strings.Cut(setting, ".")
+		source := effective.Origins[setting]
+		if slices.Contains(runConfigKeys, key) && !slices.Contains(runSources, source) {
+			return fmt.Errorf("%s: %s applies to the whole run and is only read from the configuration of the current directory", source, key)
+		}
+	}
+
+	if _, err := effective.Config.GetTaintLimits(); err != nil {
+		return fmt.Errorf("invalid taint limits: %w", err)
+	}
+	if _, err := effective.Config.GetAnalyzerLimits(); err != nil {
+		return fmt.Errorf("invalid analyzer limits: %w", err)
+	}
+	scoreOverrides, err := effective.Config.GetScoreOverrides()
+	if err == nil {
+		_, err = gosec.NewScoreOverrides(scoreOverrides)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid score overrides: %w", err)
+	}
+	return nil
+}
+
+// runPrintEffectiveConfig prints the configuration which applies to the
+// package in dir, followed by the source of each of its settings.
+func runPrintEffectiveConfig(dir string, stdout, stderr io.Writer) int {
+	effective := gosec.NewEffectiveConfig()
+	if *flagConfig != "" {
+		config := gosec.NewConfig()
+		if err := config.ReadFile(*flagConfig); err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitConfigError
+		}
+		effective.Merge(config, *flagConfig)
+	} else {
+		discovery := gosec.NewConfigDiscovery()
+		run, err := discovery.ConfigFor(".")
+		if err == nil {
+			effective, err = discovery.ConfigFor(dir)
+		}
+		if err == nil {
+			err = checkPackageConfig(effective, run.Sources)
+		}
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitConfigError
+		}
+	}
+	withConfigFlags(effective)
+
+	if err := printEffectiveConfig(stdout, effective); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitScanErrors
+	}
+	return exitSuccess
+}
+
+func printEffectiveConfig(w io.Writer, effective *gosec.EffectiveConfig) error {
+	fmt.Fprintln(w, "Sources, in merge order:")
+	if len(effective.Sources) == 0 {
+		fmt.Fprintln(w, "  none, the defaults apply")
+	}
+	for _, source := range effective.Sources {
+		fmt.Fprintf(w, "  %s\n", source)
+	}
+
+	data, err := json.MarshalIndent(effective.Config, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\nEffective configuration:\n%s\n", data)
+
+	if len(effective.Origins) == 0 {
+		return nil
+	}
+	settings := make([]string, 0, len(effective.Origins))
+	for setting := range effective.Origins {
+		settings = append(settings, setting)
+	}
+	sort.Strings(settings)
+	fmt.Fprintln(w, "\nOrigin of the settings:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, setting := range settings {
+		fmt.Fprintf(tw, "  %s\t%s\n", setting, effective.Origins[setting])
+	}
+	return tw.Flush()
+}
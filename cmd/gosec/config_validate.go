@@ -0,0 +1,75 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/securego/gosec/v2"
+)
+
+// isConfigValidateCommand reports whether the arguments request the
+// "config validate" mode instead of a scan.
+func isConfigValidateCommand(args []string) bool {
+	return len(args) >= 2 && args[0] == "config" && args[1] == "validate"
+}
+
+// runConfigValidate checks the configuration files given as arguments, or
+// the one given with -conf, without scanning any code. Every problem is
+// printed to stderr.
+func runConfigValidate(files []string, stdout, stderr io.Writer) int {
+	if len(files) == 0 && *flagConfig != "" {
+		files = []string{*flagConfig}
+	}
+	if len(files) == 0 {
+		fmt.Fprintln(stderr, "Error: gosec config validate expects a configuration file or -conf")
+		return exitConfigError
+	}
+
+	exitCode := exitSuccess
+	for _, file := range files {
+		if err := validateConfigFile(file); err != nil {
+			fmt.Fprintln(stderr, err)
+			exitCode = exitConfigError
+			continue
+		}
+		fmt.Fprintf(stdout, "%s: configuration is valid\n", file)
+	}
+	return exitCode
+}
+
+// validateConfigFile loads the configuration file, which checks it against
+// the schema, and then checks the settings which are only verified when they
+// are used: the taint rules, the analysis limits and the path exclusions.
+func validateConfigFile(file string) error {
+	config := gosec.NewConfig()
+	if err := config.ReadFile(file); err != nil {
+		return err
+	}
+	if _, err := loadTaintRules(config); err != nil {
+		return fmt.Errorf("%s: invalid taint rules: %w", file, err)
+	}
+	if _, err := config.GetTaintLimits(); err != nil {
+		return fmt.Errorf("%s: invalid taint limits: %w", file, err)
+	}
+	if _, err := config.GetAnalyzerLimits(); err != nil {
+		return fmt.Errorf("%s: invalid analyzer limits: %w", file, err)
+	}
+	if _, err := buildPathExclusionFilter(config, ""); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+)
+
+// noSecFixes holds the removals of the unused nosec directives, by file.
+type noSecFixes struct {
+	replacements map[string][]issue.Replacement
+	count        int
+}
+
+// takeUnusedNoSecFixes takes the diagnostics of the unused nosec directives
+// out of the issues, and returns the removals of those directives. The
+// diagnostics which are suppressed, e.g. accepted by the baseline, are left
+// untouched.
+func takeUnusedNoSecFixes(issues []*issue.Issue) ([]*issue.Issue, *noSecFixes) {
+	fixes := &noSecFixes{replacements: make(map[string][]issue.Replacement)}
+	result := make([]*issue.Issue, 0, len(issues))
+	for _, iss := range issues {
+		if iss.RuleID != gosec.UnusedNoSecRuleID || len(iss.Fixes) == 0 || iss.NoSec || len(iss.Suppressions) > 0 {
+			result = append(result, iss)
+			continue
+		}
+		fixes.replacements[iss.File] = append(fixes.replacements[iss.File], iss.Fixes[0].Replacements...)
+		fixes.count++
+	}
+	return result, fixes
+}
+
+// apply removes the unused nosec directives from the files. It is a no-op on
+// nil fixes.
+func (f *noSecFixes) apply() error {
+	if f == nil {
+		return nil
+	}
+	files := make([]string, 0, len(f.replacements))
+	for file := range f.replacements {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		if err := applyReplacements(file, f.replacements[file]); err != nil {
+			return err
+		}
+	}
+	if f.count > 0 {
+		logger.Printf("Removed %d unused nosec directives", f.count)
+	}
+	return nil
+}
+
+// applyReplacements edits a file with replacements which do not overlap.
+func applyReplacements(file string, replacements []issue.Replacement) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(file) // #nosec G304 -- the scanned files are fixed
+	if err != nil {
+		return err
+	}
+
+	lineStarts := []int{0}
+	for offset, b := range content {
+		if b == '\n' {
+			lineStarts = append(lineStarts, offset+1)
+		}
+	}
+	offset := func(line, column int) (int, error) {
+		if line < 1 || line > len(lineStarts) || column < 1 {
+			return 0, fmt.Errorf("%s: invalid position %d:%d", file, line, column)
+		}
+		o := lineStarts[line-1] + column - 1
+		if o > len(content) {
+			return 0, fmt.Errorf("%s: invalid position %d:%d", file, line, column)
+		}
+		return o, nil
+	}
+
+	type edit struct {
+		start, end int
+		text       string
+	}
+	edits := make([]edit, 0, len(replacements))
+	for _, r := range replacements {
+		start, err := offset(r.StartLine, r.StartColumn)
+		if err != nil {
+			return err
+		}
+		end, err := offset(r.EndLine, r.EndColumn)
+		if err != nil {
+			return err
+		}
+		if end < start {
+			return fmt.Errorf("%s: invalid range %d:%d-%d:%d", file, r.StartLine, r.StartColumn, r.EndLine, r.EndColumn)
+		}
+		edits = append(edits, edit{start: start, end: end, text: r.Text})
+	}
+	// The edits are applied from the end of the file, so that the offsets of
+	// the others remain valid.
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].start > edits[j].start
+	})
+	for i := 1; i < len(edits); i++ {
+		if edits[i].end > edits[i-1].start {
+			return fmt.Errorf("%s: overlapping fixes", file)
+		}
+	}
+	for _, e := range edits {
+		content = append(content[:e.start], append([]byte(e.text), content[e.end:]...)...)
+	}
+	return os.WriteFile(file, content, info.Mode().Perm())
+}
@@ -23,6 +23,7 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/securego/gosec/v2"
 	"github.com/securego/gosec/v2/analyzers"
@@ -64,6 +65,31 @@ USAGE:
 
 	# Exclude all rules from scripts directory
 	$ gosec --exclude-rules="scripts/.*:*" ./...
+
+	# Check a configuration file (JSON, YAML or TOML) without scanning
+	$ gosec config validate .gosec.yaml
+
+	# Show the configuration files merged for a package directory
+	$ gosec -print-effective-config ./internal/legacy
+
+	# Accept the current findings, then report only the new ones
+	$ gosec -write-baseline gosec-baseline.json ./...
+	$ gosec -baseline gosec-baseline.json ./...
+
+	# Report only the issues on the lines changed since the main branch
+	$ gosec -new-from-rev origin/main ./...
+
+	# Remove the #nosec annotations which suppress no issue
+	$ gosec -fix-unused-nosec ./...
+
+	# Write the SARIF and SonarQube reports, and print the text one, in a single run
+	$ gosec -fmt=sarif:results.sarif -fmt=sonarqube:sonar.json -fmt=text ./...
+
+	# Render the report through a text/template
+	$ gosec -fmt=template:slack.tmpl:report.md ./...
+
+	# Report all the findings, but fail only on the high and critical ones
+	$ gosec -fail-on-severity high ./...
 `
 	// Environment variable for AI API key.
 	aiAPIKeyEnv   = "GOSEC_AI_API_KEY" // #nosec G101
@@ -72,7 +98,14 @@ USAGE:
 
 	// Exit codes
 	exitSuccess = 0
-	exitFailure = 1
+	// exitFindings is returned when the findings fail the run
+	exitFindings = 1
+	// exitScanErrors is returned when the packages cannot be analyzed or the
+	// report cannot be written
+	exitScanErrors = 2
+	// exitConfigError is returned for the invalid arguments, flags and
+	// configurations
+	exitConfigError = 3
 )
 
 type arrayFlags []string
@@ -99,9 +132,6 @@ Use "*" to exclude all rules for a path: "scripts/.*:*"`)
 	// show ignored
 	flagShowIgnored = flag.Bool("show-ignored", false, "If enabled, ignored issues are printed")
 
-	// format output
-	flagFormat = flag.String("fmt", "text", "Set output format. Valid options are: json, yaml, csv, junit-xml, html, sonarqube, golint, sarif or text")
-
 	// #nosec alternative tag
 	flagAlternativeNoSec = flag.String("nosec-tag", "", "Set an alternative string for #nosec. Some examples: #dontanalyze, #falsepositive")
 
@@ -111,6 +141,15 @@ Use "*" to exclude all rules for a path: "scripts/.*:*"`)
 	// require justification in #nosec annotations
 	flagNoSecRequireJustification = flag.Bool("nosec-require-justification", false, "Require a `-- justification` in every #nosec / //gosec:disable annotation")
 
+	// report the unused #nosec annotations
+	flagNoSecReportUnused = flag.Bool("nosec-report-unused", false, "Report the #nosec / //gosec:disable annotations which suppress no issue")
+
+	// remove the unused #nosec annotations
+	flagFixUnusedNoSec = flag.Bool("fix-unused-nosec", false, "Remove the #nosec / //gosec:disable annotations which suppress no issue from the scanned files. Implies -nosec-report-unused")
+
+	// require a ticket in #nosec annotations
+	flagNoSecRequireTicket = flag.Bool("nosec-require-ticket", false, "Require a `ticket=<id>` in the justification of every #nosec / //gosec:disable annotation")
+
 	// flagEnableAudit enables audit mode
 	flagEnableAudit = flag.Bool("enable-audit", false, "Enable audit mode")
 
@@ -118,7 +157,10 @@ Use "*" to exclude all rules for a path: "scripts/.*:*"`)
 	flagOutput = flag.String("out", "", "Set output file for results")
 
 	// config file
-	flagConfig = flag.String("conf", "", "Path to optional config file")
+	flagConfig = flag.String("conf", "", "Path to optional config file (JSON, YAML or TOML). Without it, the .gosec.{json,yaml,yml,toml} files of the module root and of the package directories and their parents are merged")
+
+	// print the configuration merged for a package
+	flagPrintEffectiveConfig = flag.String("print-effective-config", "", "Print the configuration merged for the package in the given directory and the source of each setting, then exit")
 
 	// quiet
 	flagQuiet = flag.Bool("quiet", false, "Only show output when errors are found")
@@ -141,7 +183,7 @@ Use "*" to exclude all rules for a path: "scripts/.*:*"`)
 	flagBuildTags = flag.String("tags", "", "Comma separated list of build tags")
 
 	// fail by severity
-	flagSeverity = flag.String("severity", "low", "Filter out the issues with a lower severity than the given value. Valid options are: low, medium, high")
+	flagSeverity = flag.String("severity", "low", "Filter out the issues with a lower severity than the given value. Valid options are: low, medium, high, critical")
 
 	// fail by confidence
 	flagConfidence = flag.String("confidence", "low", "Filter out the issues with a lower confidence than the given value. Valid options are: low, medium, high")
@@ -150,7 +192,13 @@ Use "*" to exclude all rules for a path: "scripts/.*:*"`)
 	flagConcurrency = flag.Int("concurrency", runtime.NumCPU(), "Concurrency value")
 
 	// do not fail
-	flagNoFail = flag.Bool("no-fail", false, "Do not fail the scanning, even if issues were found")
+	flagNoFail = flag.Bool("no-fail", false, "Do not fail the scanning, even if issues or scan errors were found")
+
+	// fail only on the issues of a minimum severity
+	flagFailOnSeverity = flag.String("fail-on-severity", "", "Fail only on the reported issues with at least the given severity. Valid options are: low, medium, high, critical")
+
+	// fail only on the issues of a minimum confidence
+	flagFailOnConfidence = flag.String("fail-on-confidence", "", "Fail only on the reported issues with at least the given confidence. Valid options are: low, medium, high")
 
 	// scan tests files
 	flagScanTests = flag.Bool("tests", false, "Scan tests files")
@@ -168,7 +216,7 @@ Use "*" to exclude all rules for a path: "scripts/.*:*"`)
 	flagRecursive = flag.Bool("r", false, "Appends \"./...\" to the target dir.")
 
 	// overrides the output format when stdout the results while saving them in the output file
-	flagVerbose = flag.String("verbose", "", "Overrides the output format when stdout the results while saving them in the output file.\nValid options are: json, yaml, csv, junit-xml, html, sonarqube, golint, sarif or text")
+	flagVerbose = flag.String("verbose", "", "Overrides the output format when stdout the results while saving them in the output file.\nValid options are: json, yaml, csv, junit-xml, html, sonarqube, golint, sarif, gitlab-sast, codeclimate, checkstyle, rdjson, rdjsonl or text")
 
 	// output suppression information for auditing purposes
 	flagTrackSuppressions = flag.Bool("track-suppressions", false, "Output suppression information, including its kind and justification")
@@ -188,9 +236,24 @@ Use "*" to exclude all rules for a path: "scripts/.*:*"`)
 	// skip SSL verification for AI API
 	flagAiSkipSSL = flag.Bool("ai-skip-ssl", false, "Skip SSL certificate verification for AI API")
 
+	// baseline of the accepted findings
+	flagBaseline = flag.String("baseline", "", "Path to a baseline file. The findings it lists are not reported, or are reported as suppressed with -track-suppressions")
+
+	// write the findings to a baseline file
+	flagWriteBaseline = flag.String("write-baseline", "", "Write the findings to the given baseline file and exit")
+
+	// report only the issues on the lines changed since a git revision
+	flagNewFromRev = flag.String("new-from-rev", "", "Report only the issues on the lines changed since the given git revision, including the uncommitted changes")
+
+	// report only the issues on the lines changed by a patch
+	flagNewFromPatch = flag.String("new-from-patch", "", "Report only the issues on the lines changed by the given unified diff file, whose paths are relative to the current directory")
+
 	// exclude the folders from scan
 	flagDirsExclude arrayFlags
 
+	// output formats, with their optional output files
+	flagFormats arrayFlags
+
 	logger *log.Logger
 )
 
@@ -228,43 +291,62 @@ func usage() {
 func loadConfig(configFile string) (gosec.Config, error) {
 	config := gosec.NewConfig()
 	if configFile != "" {
-		// #nosec
-		file, err := os.Open(configFile)
-		if err != nil {
-			return nil, err
-		}
-		defer file.Close() // #nosec G307
-		if _, err := config.ReadFrom(file); err != nil {
+		if err := config.ReadFile(configFile); err != nil {
 			return nil, err
 		}
 	}
+	applyConfigFlags(config)
+	return config, nil
+}
+
+// configFlags returns the global options set on the command line, which
+// take precedence over the configuration files.
+func configFlags() map[gosec.GlobalOption]string {
+	globals := make(map[gosec.GlobalOption]string)
 	if *flagIgnoreNoSec {
-		config.SetGlobal(gosec.Nosec, "true")
+		globals[gosec.Nosec] = "true"
 	}
 	if *flagShowIgnored {
-		config.SetGlobal(gosec.ShowIgnored, "true")
+		globals[gosec.ShowIgnored] = "true"
 	}
 	if *flagAlternativeNoSec != "" {
-		config.SetGlobal(gosec.NoSecAlternative, *flagAlternativeNoSec)
+		globals[gosec.NoSecAlternative] = *flagAlternativeNoSec
 	}
 	if *flagNoSecRequireRules {
-		config.SetGlobal(gosec.NoSecRequireRules, "true")
+		globals[gosec.NoSecRequireRules] = "true"
 	}
 	if *flagNoSecRequireJustification {
-		config.SetGlobal(gosec.NoSecRequireJustification, "true")
+		globals[gosec.NoSecRequireJustification] = "true"
+	}
+	if *flagNoSecRequireTicket {
+		globals[gosec.NoSecRequireTicket] = "true"
+	}
+	if *flagNoSecReportUnused || *flagFixUnusedNoSec {
+		globals[gosec.NoSecReportUnused] = "true"
 	}
 	if *flagEnableAudit {
-		config.SetGlobal(gosec.Audit, "true")
+		globals[gosec.Audit] = "true"
 	}
-	// set global option IncludeRules, when flag set or global option IncludeRules  is nil
-	if v, _ := config.GetGlobal(gosec.IncludeRules); *flagRulesInclude != "" || v == "" {
-		config.SetGlobal(gosec.IncludeRules, *flagRulesInclude)
+	if *flagRulesInclude != "" {
+		globals[gosec.IncludeRules] = *flagRulesInclude
 	}
-	// set global option ExcludeRules, when flag set or global option ExcludeRules  is nil
-	if v, _ := config.GetGlobal(gosec.ExcludeRules); flagRulesExclude.String() != "" || v == "" {
-		config.SetGlobal(gosec.ExcludeRules, flagRulesExclude.String())
+	if flagRulesExclude.String() != "" {
+		globals[gosec.ExcludeRules] = flagRulesExclude.String()
+	}
+	return globals
+}
+
+// applyConfigFlags sets the global options given on the command line in the
+// config, and defaults the rules to include and exclude to none.
+func applyConfigFlags(config gosec.Config) {
+	for option, value := range configFlags() {
+		config.SetGlobal(option, value)
+	}
+	for _, option := range []gosec.GlobalOption{gosec.IncludeRules, gosec.ExcludeRules} {
+		if _, err := config.GetGlobal(option); err != nil {
+			config.SetGlobal(option, "")
+		}
 	}
-	return config, nil
 }
 
 func loadRules(include, exclude string) rules.RuleList {
@@ -287,7 +369,7 @@ func loadRules(include, exclude string) rules.RuleList {
 	return rules.Generate(*flagTrackSuppressions, filters...)
 }
 
-func loadAnalyzers(include, exclude string) *analyzers.AnalyzerList {
+func loadAnalyzers(include, exclude string, additional ...analyzers.AnalyzerDefinition) *analyzers.AnalyzerList {
 	var filters []analyzers.AnalyzerFilter
 	if include != "" {
 		logger.Printf("Including analyzers: %s", include)
@@ -304,7 +386,36 @@ func loadAnalyzers(include, exclude string) *analyzers.AnalyzerList {
 	} else {
 		logger.Println("Excluding analyzers: default")
 	}
-	return analyzers.Generate(*flagTrackSuppressions, filters...)
+	return analyzers.GenerateWith(*flagTrackSuppressions, additional, filters...)
+}
+
+// loadTaintRules builds the analyzer definitions for the user-defined and the
+// extended built-in taint rules from the config, rejecting user-defined IDs
+// that clash with the built-in AST rules.
+func loadTaintRules(config gosec.Config) ([]analyzers.AnalyzerDefinition, error) {
+	taintRules, err := config.GetTaintRules()
+	if err != nil {
+		return nil, err
+	}
+	extensions, err := config.GetTaintExtensions()
+	if err != nil {
+		return nil, err
+	}
+	if len(taintRules) == 0 && len(extensions) == 0 {
+		return nil, nil
+	}
+	builtinRules := rules.Generate(false)
+	for _, rule := range taintRules {
+		if _, ok := builtinRules.Rules[rule.ID]; ok {
+			return nil, fmt.Errorf("taint rule %s: id is already used by a built-in rule", rule.ID)
+		}
+	}
+	definitions, err := analyzers.NewTaintDefinitions(taintRules, extensions)
+	if err != nil {
+		return nil, err
+	}
+	logger.Printf("Loaded %d custom taint rules and %d taint rule extensions", len(taintRules), len(extensions))
+	return definitions, nil
 }
 
 func getRootPaths(paths []string) ([]string, error) {
@@ -342,17 +453,19 @@ func saveReport(filename, format string, rootPaths []string, reportInfo *gosec.R
 }
 
 func convertToScore(value string) (issue.Score, error) {
-	value = strings.ToLower(value)
-	switch value {
-	case "low":
-		return issue.Low, nil
-	case "medium":
-		return issue.Medium, nil
-	case "high":
-		return issue.High, nil
-	default:
-		return issue.Low, fmt.Errorf("provided value '%s' not valid. Valid options: low, medium, high", value)
+	score, err := issue.ParseScore(value)
+	if err != nil {
+		return issue.Low, fmt.Errorf("provided value '%s' not valid. Valid options: low, medium, high, critical", strings.ToLower(value))
+	}
+	return score, nil
+}
+
+func convertToConfidence(value string) (issue.Score, error) {
+	score, err := issue.ParseConfidence(value)
+	if err != nil {
+		return issue.Low, fmt.Errorf("provided value '%s' not valid. Valid options: low, medium, high", strings.ToLower(value))
 	}
+	return score, nil
 }
 
 func filterIssues(issues []*issue.Issue, severity issue.Score, confidence issue.Score) ([]*issue.Issue, int) {
@@ -369,20 +482,86 @@ func filterIssues(issues []*issue.Issue, severity issue.Score, confidence issue.
 	return result, trueIssues
 }
 
-// computeExitCode determines the exit code based on issues found and noFail flag.
-func computeExitCode(issues []*issue.Issue, errors map[string][]gosec.Error, noFail bool) int {
-	nsi := 0
-	for _, issue := range issues {
-		if len(issue.Suppressions) == 0 {
-			nsi++
+// computeExitCode determines the exit code from the scan errors, the issues
+// failing the policy and the noFail flag. The scan errors take precedence.
+func computeExitCode(issues []*issue.Issue, errors map[string][]gosec.Error, policy gosec.FailPolicy, noFail bool) int {
+	if noFail {
+		return exitSuccess
+	}
+	if len(errors) > 0 {
+		return exitScanErrors
+	}
+	failing, err := policy.Evaluate(issues)
+	if err != nil {
+		logger.Printf("Invalid fail policy: %v", err)
+		return exitConfigError
+	}
+	for _, findings := range failing {
+		class := "issues"
+		if findings.RuleID != "" {
+			class = findings.RuleID + " issues"
+		}
+		if findings.MaxFindings > 0 {
+			logger.Printf("Found %d %s, more than the %d allowed", findings.Count, class, findings.MaxFindings)
 		}
 	}
-	if (nsi > 0 || len(errors) > 0) && !noFail {
-		return exitFailure
+	if len(failing) > 0 {
+		return exitFindings
 	}
 	return exitSuccess
 }
 
+// loadFailPolicy returns the fail policy of the configuration, whose
+// thresholds are overridden by the flags when they are set.
+func loadFailPolicy(config gosec.Config, severity, confidence string) (gosec.FailPolicy, error) {
+	policy, err := config.GetFailPolicy()
+	if err != nil {
+		return policy, err
+	}
+	if severity != "" {
+		policy.Severity = severity
+	}
+	if confidence != "" {
+		policy.Confidence = confidence
+	}
+	return policy, policy.Validate()
+}
+
+// writeBaseline saves the issues found as the accepted ones.
+func writeBaseline(path string, issues []*issue.Issue) int {
+	baseline := gosec.NewBaseline(issues, issue.NewFingerprinter())
+	if err := baseline.WriteFile(path); err != nil {
+		logger.Printf("Failed to write the baseline: %v", err)
+		return exitScanErrors
+	}
+	logger.Printf("Wrote %d findings to the baseline %s", len(baseline.Findings), path)
+	return exitSuccess
+}
+
+// applyBaseline drops the issues accepted by the baseline, or marks them as
+// suppressed when the suppressions are tracked, and reports its stale entries.
+func applyBaseline(path string, issues []*issue.Issue, trackSuppressions bool) ([]*issue.Issue, error) {
+	baseline, err := gosec.ReadBaseline(path)
+	if err != nil {
+		return nil, err
+	}
+	issues, accepted, stale := baseline.Apply(issues, trackSuppressions)
+	if accepted > 0 {
+		logger.Printf("Accepted %d issues listed in the baseline %s", accepted, path)
+	}
+	if len(stale) > 0 {
+		logger.Printf("The baseline %s has %d stale entries, which match no finding anymore and can be removed:", path, len(stale))
+		for _, entry := range stale {
+			location := entry.File
+			if entry.Function != "" {
+				location += " in " + entry.Function
+			}
+			logger.Printf("  %s %s (fingerprint %s)", entry.RuleID, location, entry.Fingerprint)
+		}
+	}
+	return issues, nil
+}
+
 // buildPathExclusionFilter creates a PathExclusionFilter from config and CLI flags
 func buildPathExclusionFilter(config gosec.Config, cliFlag string) (*gosec.PathExclusionFilter, error) {
 	// Parse CLI exclude-rules
@@ -424,6 +603,9 @@ func run() int {
 		fmt.Fprintf(os.Stderr, "\nError: failed to exclude the %q directory from scan", "\\.git/")
 	}
 
+	// Setup the output formats
+	flag.Var(&flagFormats, "fmt", "Set output format, optionally followed by :file to write it to a file (can be specified multiple times).\nValid options are: json, yaml, csv, junit-xml, html, sonarqube, golint, sarif, gitlab-sast, codeclimate, checkstyle, rdjson, rdjsonl, text, or template:<template file> to render a text/template (default \"text\")")
+
 	// set for exclude
 	flag.Var(&flagRulesExclude, "exclude", "Comma separated list of rules IDs to exclude. (see rule list)")
 
@@ -435,11 +617,15 @@ func run() int {
 		return exitSuccess
 	}
 
+	if *flagPrintEffectiveConfig != "" {
+		return runPrintEffectiveConfig(*flagPrintEffectiveConfig, os.Stdout, os.Stderr)
+	}
+
 	// Ensure at least one file was specified or that the recursive -r flag was set.
 	if flag.NArg() == 0 && !*flagRecursive {
 		fmt.Fprintf(os.Stderr, "\nError: FILE [FILE...] or './...' or -r expected\n") // #nosec
 		flag.Usage()
-		return exitFailure
+		return exitConfigError
 	}
 
 	// Setup logging
@@ -450,7 +636,7 @@ func run() int {
 		if err != nil {
 			flag.Usage()
 			log.Printf("failed to create log file: %v", err)
-			return exitFailure
+			return exitConfigError
 		}
 		defer logWriter.Close() // #nosec
 	}
@@ -461,86 +647,173 @@ func run() int {
 		logger = log.New(logWriter, "[gosec] ", log.LstdFlags)
 	}
 
+	if isConfigValidateCommand(flag.Args()) {
+		return runConfigValidate(flag.Args()[2:], os.Stdout, os.Stderr)
+	}
+
 	// Initialize profiling after logger setup so it uses the same logger
 	// (defers execute in LIFO order, so finishProfiling runs before logWriter.Close)
 	profiler, err := initProfiling(logger)
 	if err != nil {
 		logger.Printf("failed to initialize profiling: %v", err)
-		return exitFailure
+		return exitConfigError
 	}
 	defer finishProfiling(profiler)
 
 	failSeverity, err := convertToScore(*flagSeverity)
 	if err != nil {
 		logger.Printf("Invalid severity value: %v", err)
-		return exitFailure
+		return exitConfigError
 	}
 
-	failConfidence, err := convertToScore(*flagConfidence)
+	failConfidence, err := convertToConfidence(*flagConfidence)
 	if err != nil {
 		logger.Printf("Invalid confidence value: %v", err)
-		return exitFailure
+		return exitConfigError
+	}
+
+	if *flagFailOnSeverity != "" {
+		if _, err := convertToScore(*flagFailOnSeverity); err != nil {
+			logger.Printf("Invalid fail-on-severity value: %v", err)
+			return exitConfigError
+		}
+	}
+	if *flagFailOnConfidence != "" {
+		if _, err := convertToConfidence(*flagFailOnConfidence); err != nil {
+			logger.Printf("Invalid fail-on-confidence value: %v", err)
+			return exitConfigError
+		}
+	}
+
+	outputs, err := parseOutputs(flagFormats, *flagOutput, *flagStdOut, *flagVerbose)
+	if err != nil {
+		logger.Printf("Invalid output: %v", err)
+		return exitConfigError
+	}
+
+	// Load the changed lines the issues are limited to
+	changes, err := loadChanges(*flagNewFromRev, *flagNewFromPatch)
+	if err != nil {
+		logger.Printf("Failed to load the changes: %v", err)
+		return exitConfigError
 	}
 
 	// Load the analyzer configuration
 	config, err := loadConfig(*flagConfig)
 	if err != nil {
 		logger.Printf("Failed to load config: %v", err)
-		return exitFailure
+		return exitConfigError
+	}
+
+	excludedDirs := gosec.ExcludedDirsRegExp(flagDirsExclude)
+	var packages []string
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = append(paths, "./...")
+	}
+	for _, path := range paths {
+		pcks, err := gosec.PackagePaths(path, excludedDirs)
+		if err != nil {
+			logger.Printf("Failed to get package paths: %v", err)
+			return exitConfigError
+		}
+		packages = append(packages, pcks...)
+	}
+
+	if len(packages) == 0 {
+		logger.Print("No packages found")
+		return exitConfigError
+	}
+
+	// Without -conf, the configuration files found from the module root down
+	// to each package apply. The run-wide settings, such as the taint rules
+	// and the path exclusions, come from the current directory, and are
+	// rejected in the other files.
+	var packageConfigs map[string]gosec.Config
+	if *flagConfig == "" {
+		discovery := gosec.NewConfigDiscovery()
+		effective, err := discovery.ConfigFor(".")
+		if err != nil {
+			logger.Printf("Failed to load config: %v", err)
+			return exitConfigError
+		}
+		if len(effective.Sources) > 0 {
+			logger.Printf("Using the configuration files: %s", strings.Join(effective.Sources, ", "))
+			config = withConfigFlags(effective).Config
+		}
+		if packageConfigs, err = loadPackageConfigs(discovery, effective.Sources, packages); err != nil {
+			logger.Printf("Failed to load config: %v", err)
+			return exitConfigError
+		}
 	}
 
 	// Load enabled rule definitions
 	excludeRules, err := config.GetGlobal(gosec.ExcludeRules)
 	if err != nil {
 		logger.Printf("Failed to get exclude rules: %v", err)
-		return exitFailure
+		return exitConfigError
 	}
 	includeRules, err := config.GetGlobal(gosec.IncludeRules)
 	if err != nil {
 		logger.Printf("Failed to get include rules: %v", err)
-		return exitFailure
+		return exitConfigError
+	}
+
+	if packageConfigs != nil {
+		// The rules are selected for each package from its configuration.
+		includeRules, excludeRules = "", ""
 	}
 
 	ruleList := loadRules(includeRules, excludeRules)
 
-	analyzerList := loadAnalyzers(includeRules, excludeRules)
+	taintRules, err := loadTaintRules(config)
+	if err != nil {
+		logger.Printf("Invalid taint rules in config: %v", err)
+		return exitConfigError
+	}
+	if _, err := config.GetTaintLimits(); err != nil {
+		logger.Printf("Invalid taint limits in config: %v", err)
+		return exitConfigError
+	}
+	if _, err := config.GetAnalyzerLimits(); err != nil {
+		logger.Printf("Invalid analyzer limits in config: %v", err)
+		return exitConfigError
+	}
+	scoreOverrides, err := config.GetScoreOverrides()
+	if err == nil {
+		_, err = gosec.NewScoreOverrides(scoreOverrides)
+	}
+	if err != nil {
+		logger.Printf("Invalid score overrides in config: %v", err)
+		return exitConfigError
+	}
+	failPolicy, err := loadFailPolicy(config, *flagFailOnSeverity, *flagFailOnConfidence)
+	if err != nil {
+		logger.Printf("Invalid fail policy: %v", err)
+		return exitConfigError
+	}
+
+	analyzerList := loadAnalyzers(includeRules, excludeRules, taintRules...)
 
 	if len(ruleList.Rules) == 0 && len(analyzerList.Analyzers) == 0 {
 		logger.Print("No rules/analyzers are configured")
-		return exitFailure
+		return exitConfigError
 	}
 
 	// Build path exclusion filter
 	pathFilter, err := buildPathExclusionFilter(config, *flagExcludeRules)
 	if err != nil {
 		logger.Printf("Path exclusion filter error: %v", err)
-		return exitFailure
+		return exitConfigError
 	}
 
 	// Create the analyzer
 	analyzer := gosec.NewAnalyzer(config, *flagScanTests, *flagExcludeGenerated, *flagTrackSuppressions, *flagConcurrency, logger)
 	analyzer.LoadRules(ruleList.RulesInfo())
 	analyzer.LoadAnalyzers(analyzerList.AnalyzersInfo())
-
-	excludedDirs := gosec.ExcludedDirsRegExp(flagDirsExclude)
-	var packages []string
-
-	paths := flag.Args()
-	if len(paths) == 0 {
-		paths = append(paths, "./...")
-	}
-	for _, path := range paths {
-		pcks, err := gosec.PackagePaths(path, excludedDirs)
-		if err != nil {
-			logger.Printf("Failed to get package paths: %v", err)
-			return exitFailure
-		}
-		packages = append(packages, pcks...)
-	}
-
-	if len(packages) == 0 {
-		logger.Print("No packages found")
-		return exitFailure
+	for dir, packageConfig := range packageConfigs {
+		analyzer.SetPackageConfig(dir, packageConfig)
 	}
 
 	var buildTags []string
@@ -548,11 +821,14 @@ func run() int {
 		buildTags = strings.Split(*flagBuildTags, ",")
 	}
 
+	startTime := time.Now()
 	if err := analyzer.Process(buildTags, packages...); err != nil {
 		logger.Printf("Analyzer error: %v", err)
-		return exitFailure
+		return exitScanErrors
 	}
 
+	endTime := time.Now()
+
 	// Collect the results
 	issues, metrics, errors := analyzer.Report()
 
@@ -563,11 +839,40 @@ func run() int {
 		logger.Printf("Excluded %d issues by path-based rules", pathExcludedCount)
 	}
 
+	// Accept the findings of the baseline, or record them in a new one
+	if *flagWriteBaseline != "" {
+		return writeBaseline(*flagWriteBaseline, issues)
+	}
+	if *flagBaseline != "" {
+		if issues, err = applyBaseline(*flagBaseline, issues, *flagTrackSuppressions); err != nil {
+			logger.Printf("Failed to apply the baseline: %v", err)
+			return exitConfigError
+		}
+	}
+
 	// Sort the issue by severity
 	if *flagSortIssues {
 		sortIssues(issues)
 	}
 
+	// Keep the issues on the changed lines
+	if changes != nil {
+		var unchangedCount int
+		issues, unchangedCount = filterChangedIssues(issues, changes)
+		if unchangedCount > 0 {
+			logger.Printf("Excluded %d issues outside of the changed lines", unchangedCount)
+		}
+	}
+
+	// Take out the unused nosec directives still reported, whatever the
+	// severity and confidence filters. They are removed from the files once
+	// the reports, whose positions refer to the code before the fixes, are
+	// written.
+	var unusedNoSec *noSecFixes
+	if *flagFixUnusedNoSec {
+		issues, unusedNoSec = takeUnusedNoSecFixes(issues)
+	}
+
 	// Filter the issues by severity and confidence
 	var trueIssues int
 	issues, trueIssues = filterIssues(issues, failSeverity, failConfidence)
@@ -577,6 +882,10 @@ func run() int {
 
 	// Exit quietly if nothing was found
 	if len(issues) == 0 && *flagQuiet {
+		if err := unusedNoSec.apply(); err != nil {
+			logger.Printf("Failed to remove the unused nosec directives: %v", err)
+			return exitScanErrors
+		}
 		return exitSuccess
 	}
 
@@ -584,10 +893,10 @@ func run() int {
 	rootPaths, err := getRootPaths(flag.Args())
 	if err != nil {
 		logger.Printf("Failed to get root paths: %v", err)
-		return exitFailure
+		return exitScanErrors
 	}
 
-	reportInfo := gosec.NewReportInfo(issues, metrics, errors).WithVersion(Version)
+	reportInfo := gosec.NewReportInfo(issues, metrics, errors).WithVersion(Version).WithScanTimes(startTime, endTime)
 
 	// Call AI request to solve the issues
 	aiProvider := *flagAiAPIProvider
@@ -614,19 +923,15 @@ func run() int {
 		}
 	}
 
-	if *flagOutput == "" || *flagStdOut {
-		fileFormat := getPrintedFormat(*flagFormat, *flagVerbose)
-		if err := printReport(fileFormat, *flagColor, rootPaths, reportInfo); err != nil {
-			logger.Printf("Failed to print report: %v", err)
-			return exitFailure
-		}
+	if err := writeReports(outputs, *flagColor, rootPaths, reportInfo); err != nil {
+		logger.Printf("Failed to write report: %v", err)
+		return exitScanErrors
 	}
-	if *flagOutput != "" {
-		if err := saveReport(*flagOutput, *flagFormat, rootPaths, reportInfo); err != nil {
-			logger.Printf("Failed to save report: %v", err)
-			return exitFailure
-		}
+
+	if err := unusedNoSec.apply(); err != nil {
+		logger.Printf("Failed to remove the unused nosec directives: %v", err)
+		return exitScanErrors
 	}
 
-	return computeExitCode(issues, errors, *flagNoFail)
+	return computeExitCode(issues, errors, failPolicy, *flagNoFail)
 }
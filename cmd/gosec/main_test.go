@@ -5,6 +5,10 @@ import (
 	"io"
 	"log"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -175,6 +179,147 @@ var _ = Describe("loadConfig", func() {
 	})
 })
 
+var _ = Describe("runConfigValidate", func() {
+	var stdout, stderr *bytes.Buffer
+
+	write := func(name, content string) string {
+		path := filepath.Join(GinkgoT().TempDir(), name)
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}
+	})
+
+	It("should recognise the config validate command", func() {
+		Expect(isConfigValidateCommand([]string{"config", "validate", "gosec.yaml"})).To(BeTrue())
+		Expect(isConfigValidateCommand([]string{"config"})).To(BeFalse())
+		Expect(isConfigValidateCommand([]string{"./..."})).To(BeFalse())
+	})
+
+	It("should accept a valid configuration", func() {
+		file := write("gosec.yaml", "global:\n  audit: enabled\nG104:\n  os: [Remove]\n")
+		Expect(runConfigValidate([]string{file}, stdout, stderr)).To(Equal(exitSuccess))
+		Expect(stdout.String()).To(ContainSubstring(file + ": configuration is valid"))
+		Expect(stderr.String()).To(BeEmpty())
+	})
+
+	It("should report every problem of an invalid configuration", func() {
+		file := write("gosec.yaml", "global:\n  audti: enabled\nG104: [Remove]\n")
+		Expect(runConfigValidate([]string{file}, stdout, stderr)).To(Equal(exitConfigError))
+		Expect(stderr.String()).To(ContainSubstring(file + `:2:3: global: unknown key "audti"`))
+		Expect(stderr.String()).To(ContainSubstring(file + ":3:7: G104: expected a map, found a list"))
+	})
+
+	It("should report the settings checked when they are used", func() {
+		file := write("gosec.json", `{"taint-extensions": {"G799": {"source_classes": ["storage"]}}}`)
+		Expect(runConfigValidate([]string{file}, stdout, stderr)).To(Equal(exitConfigError))
+		Expect(stderr.String()).To(ContainSubstring("unknown taint rule G799"))
+
+		file = write("gosec.toml", "[[exclude-rules]]\npath = \"[\"\nrules = [\"G101\"]\n")
+		Expect(runConfigValidate([]string{file}, stdout, stderr)).To(Equal(exitConfigError))
+		Expect(stderr.String()).To(ContainSubstring("invalid path regex"))
+	})
+
+	It("should require a configuration file", func() {
+		origConfig := *flagConfig
+		defer func() { *flagConfig = origConfig }()
+		*flagConfig = ""
+
+		Expect(runConfigValidate(nil, stdout, stderr)).To(Equal(exitConfigError))
+		Expect(stderr.String()).To(ContainSubstring("expects a configuration file"))
+	})
+})
+
+var _ = Describe("configuration discovery", func() {
+	var module string
+	var origRulesExclude vflag.ValidatedFlag
+
+	write := func(name, content string) string {
+		path := filepath.Join(module, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o750)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		module = GinkgoT().TempDir()
+		write("go.mod", "module example.com/discovery\n\ngo 1.22\n")
+		origRulesExclude = flagRulesExclude
+	})
+
+	AfterEach(func() {
+		flagRulesExclude = origRulesExclude
+	})
+
+	It("should load no package configuration when no file is found", func() {
+		configs, err := loadPackageConfigs(gosec.NewConfigDiscovery(), nil, []string{module})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configs).To(BeNil())
+	})
+
+	It("should load the configuration of every package when a file is found", func() {
+		write("tools/.gosec.yaml", "global:\n  exclude: G204\n")
+		tools, app := filepath.Join(module, "tools"), filepath.Join(module, "app")
+
+		configs, err := loadPackageConfigs(gosec.NewConfigDiscovery(), nil, []string{tools, app})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configs).To(HaveLen(2))
+		exclude, _ := configs[tools].GetGlobal(gosec.ExcludeRules)
+		Expect(exclude).To(Equal("G204"))
+		exclude, _ = configs[app].GetGlobal(gosec.ExcludeRules)
+		Expect(exclude).To(BeEmpty())
+	})
+
+	It("should reject the settings of the whole run outside of the current directory", func() {
+		root := write(".gosec.yaml", "fail-policy:\n  severity: high\n")
+		tools := write("tools/.gosec.yaml", "taint-rules:\n  - id: X001\n")
+		toolsDir := filepath.Join(module, "tools")
+
+		_, err := loadPackageConfigs(gosec.NewConfigDiscovery(), []string{root}, []string{toolsDir})
+		Expect(err).To(MatchError(toolsDir + ": " + tools + ": taint-rules applies to the whole run and is only read from the configuration of the current directory"))
+
+		write("tools/.gosec.yaml", "global:\n  exclude: G204\n")
+		_, err = loadPackageConfigs(gosec.NewConfigDiscovery(), []string{root}, []string{toolsDir})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = loadPackageConfigs(gosec.NewConfigDiscovery(), nil, []string{toolsDir})
+		Expect(err).To(MatchError(ContainSubstring(root + ": fail-policy applies to the whole run")))
+	})
+
+	It("should reject the invalid score overrides of the packages", func() {
+		write("tools/.gosec.yaml", "score-overrides:\n  - rule: G104\n")
+		tools := filepath.Join(module, "tools")
+
+		_, err := loadPackageConfigs(gosec.NewConfigDiscovery(), nil, []string{tools})
+		Expect(err).To(MatchError(ContainSubstring("invalid score overrides: score-overrides[0]: severity or confidence is required")))
+	})
+
+	It("should print the effective configuration and the origin of each setting", func() {
+		root := write(".gosec.yaml", "global:\n  audit: enabled\nG306: \"0o600\"\n")
+		legacy := write("internal/legacy/.gosec.json", `{"G306": "0o644"}`)
+		flagRulesExclude = vflag.ValidatedFlag{Value: "G101"}
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		Expect(runPrintEffectiveConfig(filepath.Join(module, "internal", "legacy"), stdout, stderr)).To(Equal(exitSuccess))
+		Expect(stderr.String()).To(BeEmpty())
+		output := stdout.String()
+		Expect(output).To(ContainSubstring("  " + root + "\n  " + legacy + "\n  command line\n"))
+		Expect(output).To(ContainSubstring(`"G306": "0o644"`))
+		Expect(output).To(MatchRegexp(`G306 +` + regexp.QuoteMeta(legacy)))
+		Expect(output).To(MatchRegexp(`global\.audit +` + regexp.QuoteMeta(root)))
+		Expect(output).To(MatchRegexp(`global\.exclude +command line`))
+	})
+
+	It("should report the invalid configuration files", func() {
+		write(".gosec.yaml", "G306: 600\n")
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		Expect(runPrintEffectiveConfig(module, stdout, stderr)).To(Equal(exitConfigError))
+		Expect(stderr.String()).To(ContainSubstring("expected a file mode"))
+	})
+})
+
 var _ = Describe("loadRules", func() {
 	It("should load default rules when no filters specified", func() {
 		rules := loadRules("", "")
@@ -300,6 +445,20 @@ var _ = Describe("convertToScore", func() {
 		Expect(score).To(Equal(issue.High))
 	})
 
+	It("should convert 'critical' to Critical score", func() {
+		score, err := convertToScore("critical")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(score).To(Equal(issue.Critical))
+	})
+
+	It("should not accept 'critical' as a confidence", func() {
+		score, err := convertToConfidence("high")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(score).To(Equal(issue.High))
+		_, err = convertToConfidence("critical")
+		Expect(err).To(HaveOccurred())
+	})
+
 	It("should be case insensitive", func() {
 		score, err := convertToScore("LOW")
 		Expect(err).NotTo(HaveOccurred())
@@ -439,7 +598,7 @@ var _ = Describe("filterIssues", func() {
 
 var _ = Describe("computeExitCode", func() {
 	It("should return success when no issues and no errors", func() {
-		exitCode := computeExitCode([]*issue.Issue{}, map[string][]gosec.Error{}, false)
+		exitCode := computeExitCode([]*issue.Issue{}, map[string][]gosec.Error{}, gosec.FailPolicy{}, false)
 		Expect(exitCode).To(Equal(exitSuccess))
 	})
 
@@ -447,23 +606,29 @@ var _ = Describe("computeExitCode", func() {
 		issues := []*issue.Issue{
 			{Severity: issue.High, Confidence: issue.High},
 		}
-		exitCode := computeExitCode(issues, map[string][]gosec.Error{}, false)
-		Expect(exitCode).To(Equal(exitFailure))
+		exitCode := computeExitCode(issues, map[string][]gosec.Error{}, gosec.FailPolicy{}, false)
+		Expect(exitCode).To(Equal(exitFindings))
 	})
 
-	It("should return failure when errors exist", func() {
+	It("should return the scan errors code when errors exist", func() {
 		errors := map[string][]gosec.Error{
 			"file.go": {{Line: 1, Column: 1, Err: "test error"}},
 		}
-		exitCode := computeExitCode([]*issue.Issue{}, errors, false)
-		Expect(exitCode).To(Equal(exitFailure))
+		exitCode := computeExitCode([]*issue.Issue{}, errors, gosec.FailPolicy{}, false)
+		Expect(exitCode).To(Equal(exitScanErrors))
+
+		issues := []*issue.Issue{
+			{Severity: issue.High, Confidence: issue.High},
+		}
+		exitCode = computeExitCode(issues, errors, gosec.FailPolicy{}, false)
+		Expect(exitCode).To(Equal(exitScanErrors))
 	})
 
 	It("should return success with noFail flag even when issues exist", func() {
 		issues := []*issue.Issue{
 			{Severity: issue.High, Confidence: issue.High},
 		}
-		exitCode := computeExitCode(issues, map[string][]gosec.Error{}, true)
+		exitCode := computeExitCode(issues, map[string][]gosec.Error{}, gosec.FailPolicy{}, true)
 		Expect(exitCode).To(Equal(exitSuccess))
 	})
 
@@ -471,7 +636,7 @@ var _ = Describe("computeExitCode", func() {
 		errors := map[string][]gosec.Error{
 			"file.go": {{Line: 1, Column: 1, Err: "test error"}},
 		}
-		exitCode := computeExitCode([]*issue.Issue{}, errors, true)
+		exitCode := computeExitCode([]*issue.Issue{}, errors, gosec.FailPolicy{}, true)
 		Expect(exitCode).To(Equal(exitSuccess))
 	})
 
@@ -483,7 +648,7 @@ var _ = Describe("computeExitCode", func() {
 				Suppressions: []issue.SuppressionInfo{{Kind: "inSource"}},
 			},
 		}
-		exitCode := computeExitCode(issues, map[string][]gosec.Error{}, false)
+		exitCode := computeExitCode(issues, map[string][]gosec.Error{}, gosec.FailPolicy{}, false)
 		Expect(exitCode).To(Equal(exitSuccess))
 	})
 
@@ -499,8 +664,51 @@ var _ = Describe("computeExitCode", func() {
 				Confidence: issue.High,
 			},
 		}
-		exitCode := computeExitCode(issues, map[string][]gosec.Error{}, false)
-		Expect(exitCode).To(Equal(exitFailure))
+		exitCode := computeExitCode(issues, map[string][]gosec.Error{}, gosec.FailPolicy{}, false)
+		Expect(exitCode).To(Equal(exitFindings))
+	})
+
+	It("should only fail on the issues above the thresholds of the policy", func() {
+		issues := []*issue.Issue{
+			{RuleID: "G104", Severity: issue.Low, Confidence: issue.High},
+			{RuleID: "G401", Severity: issue.Medium, Confidence: issue.Low},
+		}
+		policy := gosec.FailPolicy{Severity: "high"}
+		Expect(computeExitCode(issues, map[string][]gosec.Error{}, policy, false)).To(Equal(exitSuccess))
+
+		issues = append(issues, &issue.Issue{RuleID: "G402", Severity: issue.Critical, Confidence: issue.Medium})
+		Expect(computeExitCode(issues, map[string][]gosec.Error{}, policy, false)).To(Equal(exitFindings))
+
+		policy.Confidence = "high"
+		Expect(computeExitCode(issues, map[string][]gosec.Error{}, policy, false)).To(Equal(exitSuccess))
+	})
+
+	It("should return the config error code for an invalid policy", func() {
+		policy := gosec.FailPolicy{Severity: "urgent"}
+		Expect(computeExitCode(nil, map[string][]gosec.Error{}, policy, false)).To(Equal(exitConfigError))
+	})
+})
+
+var _ = Describe("loadFailPolicy", func() {
+	It("should override the thresholds of the config with the flags", func() {
+		config := gosec.NewConfig()
+		config.SetFailPolicy(gosec.FailPolicy{Severity: "medium", Confidence: "medium", MaxFindings: 3})
+
+		policy, err := loadFailPolicy(config, "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy).To(Equal(gosec.FailPolicy{Severity: "medium", Confidence: "medium", MaxFindings: 3}))
+
+		policy, err = loadFailPolicy(config, "critical", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy).To(Equal(gosec.FailPolicy{Severity: "critical", Confidence: "medium", MaxFindings: 3}))
+	})
+
+	It("should reject an invalid policy", func() {
+		config := gosec.NewConfig()
+		config.SetFailPolicy(gosec.FailPolicy{Rules: map[string]gosec.RuleFailPolicy{"G104": {Action: "skip"}}})
+
+		_, err := loadFailPolicy(config, "", "")
+		Expect(err).To(MatchError(ContainSubstring(`invalid action "skip"`)))
 	})
 })
 
@@ -682,3 +890,271 @@ var _ = Describe("extractLineNumber", func() {
 		Expect(line).To(Equal(0))
 	})
 })
+
+var _ = Describe("baseline", func() {
+	It("should write the baseline and accept its findings", func() {
+		dir := GinkgoT().TempDir()
+		file := filepath.Join(dir, "main.go")
+		Expect(os.WriteFile(file, []byte("package main\n\nfunc main() {\n\tcall()\n}\n"), 0o600)).To(Succeed())
+		found := func() []*issue.Issue {
+			issues := []*issue.Issue{{RuleID: "G104", File: file, Line: "4", Code: "4: \tcall()\n"}}
+			issue.NewFingerprinter().SetFingerprints(issues)
+			return issues
+		}
+		path := filepath.Join(dir, "baseline.json")
+
+		Expect(writeBaseline(path, found())).To(Equal(exitSuccess))
+		issues, err := applyBaseline(path, found(), false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issues).To(BeEmpty())
+
+		issues, err = applyBaseline(path, found(), true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issues).To(HaveLen(1))
+		Expect(issues[0].Suppressions[0].Kind).To(Equal("external"))
+	})
+
+	It("should fail on a missing baseline", func() {
+		_, err := applyBaseline(filepath.Join(GinkgoT().TempDir(), "missing.json"), nil, false)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("changed lines", func() {
+	const patch = `diff --git a/pkg/main.go b/pkg/main.go
+index 1111111..2222222 100644
+--- a/pkg/main.go
++++ b/pkg/main.go
+@@ -3,2 +3,3 @@ package main
+ import "os"
+-var a = 1
++var b = 2
++var c = 3
+@@ -20,2 +21,0 @@ func f() {
+-	g()
+-	h()
+diff --git a/old.go b/old.go
+deleted file mode 100644
+--- a/old.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package old
+`
+
+	It("should parse the added, modified and deleted lines of a unified diff", func() {
+		changes, err := parseUnifiedDiff(strings.NewReader(patch), "/repo")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(Equal(changedLines{
+			filepath.Join("/repo", "pkg", "main.go"): {{start: 4, end: 5}, {start: 22, end: 22}},
+		}))
+	})
+
+	It("should reject the invalid hunk headers", func() {
+		_, err := parseUnifiedDiff(strings.NewReader("+++ b/main.go\n@@ bad @@\n"), "/repo")
+		Expect(err).To(MatchError(ContainSubstring("invalid hunk header")))
+	})
+
+	It("should keep the issues whose position or trace touches the changed lines", func() {
+		file := filepath.Join("/repo", "pkg", "main.go")
+		changes := changedLines{file: {{start: 4, end: 5}}}
+		issues := []*issue.Issue{
+			{RuleID: "G101", File: file, Line: "4"},
+			{RuleID: "G204", File: file, Line: "1-3"},
+			{RuleID: "G304", File: file, Line: "2-6"},
+			{RuleID: "G701", File: filepath.Join("/repo", "db.go"), Line: "9", Trace: []issue.TraceStep{{File: file, Line: 5}}},
+			{RuleID: "G702", File: filepath.Join("/repo", "db.go"), Line: "9", Trace: []issue.TraceStep{{File: file, Line: 6}}},
+		}
+
+		kept, excluded := filterChangedIssues(issues, changes)
+		Expect(excluded).To(Equal(2))
+		ids := make([]string, 0, len(kept))
+		for _, iss := range kept {
+			ids = append(ids, iss.RuleID)
+		}
+		Expect(ids).To(Equal([]string{"G101", "G304", "G701"}))
+	})
+
+	It("should read the changes since a git revision", func() {
+		if _, err := exec.LookPath("git"); err != nil {
+			Skip("git is not available")
+		}
+		repo := GinkgoT().TempDir()
+		git := func(args ...string) {
+			cmd := exec.Command("git", append([]string{"-c", "user.name=gosec", "-c", "user.email=gosec@example.com"}, args...)...)
+			cmd.Dir = repo
+			output, err := cmd.CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), string(output))
+		}
+		Expect(os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n\nfunc main() {\n}\n"), 0o600)).To(Succeed())
+		git("init", "-q")
+		git("add", "main.go")
+		git("commit", "-q", "-m", "init")
+		Expect(os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n\nfunc main() {\n\tprintln()\n}\n"), 0o600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(repo, "new.go"), []byte("package main\n"), 0o600)).To(Succeed())
+
+		changes, err := loadRevisionChanges(repo, "HEAD")
+		Expect(err).NotTo(HaveOccurred())
+		top, err := filepath.EvalSymlinks(repo)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes.intersects(filepath.Join(top, "main.go"), 4, 4)).To(BeTrue())
+		Expect(changes.intersects(filepath.Join(top, "main.go"), 3, 3)).To(BeFalse())
+		Expect(changes.intersects(filepath.Join(top, "new.go"), 1, 1)).To(BeTrue())
+
+		_, err = loadRevisionChanges(repo, "--output=/tmp/x")
+		Expect(err).To(MatchError(ContainSubstring("invalid git revision")))
+	})
+
+	It("should not combine a revision and a patch", func() {
+		_, err := loadChanges("HEAD", "changes.diff")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("takeUnusedNoSecFixes", func() {
+	It("should remove the unused directives and their diagnostics", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "main.go")
+		source := "package main\n\nfunc main() {\n\t// #nosec G304\n\tx := 1 // #nosec G401 G304 -- legacy\n\t_ = x //#nosec\n}\n"
+		Expect(os.WriteFile(file, []byte(source), 0o600)).To(Succeed())
+
+		finding := &issue.Issue{RuleID: "G401", File: file, Line: "5"}
+		issues := []*issue.Issue{
+			finding,
+			{RuleID: gosec.UnusedNoSecRuleID, File: file, Line: "4", Fixes: []issue.Fix{{
+				Replacements: []issue.Replacement{{StartLine: 4, StartColumn: 1, EndLine: 5, EndColumn: 1}},
+			}}},
+			{RuleID: gosec.UnusedNoSecRuleID, File: file, Line: "5", Fixes: []issue.Fix{{
+				Replacements: []issue.Replacement{{StartLine: 5, StartColumn: 9, EndLine: 5, EndColumn: 38, Text: "// #nosec G401 -- legacy"}},
+			}}},
+			{RuleID: gosec.UnusedNoSecRuleID, File: file, Line: "6", Fixes: []issue.Fix{{
+				Replacements: []issue.Replacement{{StartLine: 6, StartColumn: 7, EndLine: 6, EndColumn: 16}},
+			}}},
+		}
+
+		issues, fixes := takeUnusedNoSecFixes(issues)
+		Expect(fixes.count).To(Equal(3))
+		Expect(issues).To(Equal([]*issue.Issue{finding}))
+
+		content, err := os.ReadFile(file)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal(source))
+
+		Expect(fixes.apply()).To(Succeed())
+		content, err = os.ReadFile(file)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("package main\n\nfunc main() {\n\tx := 1 // #nosec G401 -- legacy\n\t_ = x\n}\n"))
+	})
+
+	It("should keep the suppressed diagnostics", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "main.go")
+		source := "package main // #nosec\n"
+		Expect(os.WriteFile(file, []byte(source), 0o600)).To(Succeed())
+
+		accepted := &issue.Issue{
+			RuleID: gosec.UnusedNoSecRuleID, File: file, Line: "1",
+			Suppressions: []issue.SuppressionInfo{{Kind: "external", Justification: "baseline"}},
+			Fixes:        []issue.Fix{{Replacements: []issue.Replacement{{StartLine: 1, StartColumn: 13, EndLine: 1, EndColumn: 23}}}},
+		}
+		issues, fixes := takeUnusedNoSecFixes([]*issue.Issue{accepted})
+		Expect(fixes.count).To(BeZero())
+		Expect(issues).To(Equal([]*issue.Issue{accepted}))
+		Expect(fixes.apply()).To(Succeed())
+
+		content, err := os.ReadFile(file)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal(source))
+	})
+
+	It("should reject the overlapping fixes", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "main.go")
+		Expect(os.WriteFile(file, []byte("package main // #nosec\n"), 0o600)).To(Succeed())
+
+		fix := []issue.Fix{{Replacements: []issue.Replacement{{StartLine: 1, StartColumn: 13, EndLine: 1, EndColumn: 23}}}}
+		_, fixes := takeUnusedNoSecFixes([]*issue.Issue{
+			{RuleID: gosec.UnusedNoSecRuleID, File: file, Line: "1", Fixes: fix},
+			{RuleID: gosec.UnusedNoSecRuleID, File: file, Line: "1", Fixes: fix},
+		})
+		Expect(fixes.apply()).To(MatchError(ContainSubstring("overlapping fixes")))
+	})
+})
+
+var _ = Describe("parseOutputs", func() {
+	It("should print the text report by default", func() {
+		outputs, err := parseOutputs(nil, "", false, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(outputs).To(Equal([]reportOutput{{format: "text"}}))
+	})
+
+	It("should write the formats to their files and print the others", func() {
+		outputs, err := parseOutputs([]string{"sarif:out.sarif", "sonarqube:sonar.json", "text"}, "", false, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(outputs).To(Equal([]reportOutput{
+			{format: "sarif", file: "out.sarif"},
+			{format: "sonarqube", file: "sonar.json"},
+			{format: "text"},
+		}))
+	})
+
+	It("should write the format without file to the -out file", func() {
+		outputs, err := parseOutputs([]string{"junit-xml:junit.xml", "json"}, "results.json", true, "text")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(outputs).To(Equal([]reportOutput{
+			{format: "json", file: "results.json"},
+			{format: "junit-xml", file: "junit.xml"},
+			{format: "text"},
+		}))
+	})
+
+	It("should split the template file and the output file", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "report.tmpl")
+		Expect(os.WriteFile(file, []byte("{{ len .Issues }}"), 0o600)).To(Succeed())
+
+		outputs, err := parseOutputs([]string{"template:" + file + ":report.md", "template:" + file}, "", false, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(outputs).To(Equal([]reportOutput{
+			{format: "template:" + file, file: "report.md"},
+			{format: "template:" + file},
+		}))
+	})
+
+	It("should reject the invalid outputs", func() {
+		for _, args := range [][]string{
+			{"xml"},
+			{"xml:out.xml"},
+			{"sarif:"},
+			{"sarif:out", "json:out"},
+		} {
+			_, err := parseOutputs(args, "", false, "")
+			Expect(err).To(HaveOccurred(), "%v", args)
+		}
+		_, err := parseOutputs([]string{"json", "text"}, "results", false, "")
+		Expect(err).To(MatchError(ContainSubstring("can only be written in one format")))
+		_, err = parseOutputs([]string{"sarif:out.sarif"}, "results", false, "")
+		Expect(err).To(HaveOccurred())
+		_, err = parseOutputs(nil, "", false, "xml")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("writeReports", func() {
+	It("should write every report from the same findings", func() {
+		dir := GinkgoT().TempDir()
+		suppressed := &issue.Issue{RuleID: "G101", File: "main.go", Line: "1", Suppressions: []issue.SuppressionInfo{{Kind: "inSource"}}}
+		reportInfo := gosec.NewReportInfo([]*issue.Issue{suppressed}, &gosec.Metrics{}, map[string][]gosec.Error{})
+
+		outputs := []reportOutput{
+			{format: "csv", file: filepath.Join(dir, "out.csv")},
+			{format: "json", file: filepath.Join(dir, "out.json")},
+		}
+		Expect(writeReports(outputs, false, []string{"."}, reportInfo)).To(Succeed())
+
+		content, err := os.ReadFile(filepath.Join(dir, "out.json"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("G101"))
+	})
+
+	It("should return the error of the failing output", func() {
+		reportInfo := gosec.NewReportInfo([]*issue.Issue{}, &gosec.Metrics{}, map[string][]gosec.Error{})
+		err := writeReports([]reportOutput{{format: "text", file: "/nonexistent/dir/report.txt"}}, false, []string{"."}, reportInfo)
+		Expect(err).To(MatchError(ContainSubstring("/nonexistent/dir/report.txt")))
+	})
+})
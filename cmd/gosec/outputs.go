@@ -0,0 +1,116 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/report"
+)
+
+// reportOutput is a format of the report and the file it is written to, or
+// the stdout when the file is empty.
+type reportOutput struct {
+	format string
+	file   string
+}
+
+// parseOutputs returns the outputs of the report given by the -fmt flags, as
+// format or format:file, where the format can be template:<template file>,
+// and by the -out, -stdout and -verbose flags. A format without a file is
+// written to the -out file when it is set, which only one format can be, and
+// to the stdout otherwise. With -stdout, the report is printed as well, in the
+// -verbose format or the format of the -out file, or else of the first file.
+func parseOutputs(formats []string, out string, stdout bool, verbose string) ([]reportOutput, error) {
+	if len(formats) == 0 {
+		formats = []string{"text"}
+	}
+	if verbose != "" {
+		if err := report.ValidateFormat(verbose); err != nil {
+			return nil, fmt.Errorf("invalid verbose format: %w", err)
+		}
+	}
+
+	var outputs []reportOutput
+	var unnamed []string
+	files := make(map[string]bool)
+	for _, value := range formats {
+		format, file, named := splitFormat(value)
+		if err := report.ValidateFormat(format); err != nil {
+			return nil, err
+		}
+		if !named {
+			unnamed = append(unnamed, format)
+			continue
+		}
+		if file == "" {
+			return nil, fmt.Errorf("missing output file in format %q", value)
+		}
+		if files[file] {
+			return nil, fmt.Errorf("output file %s is used by several formats", file)
+		}
+		files[file] = true
+		outputs = append(outputs, reportOutput{format: format, file: file})
+	}
+
+	if out != "" {
+		switch {
+		case len(unnamed) == 0:
+			return nil, fmt.Errorf("no format without output file to write to %s", out)
+		case len(unnamed) > 1:
+			return nil, fmt.Errorf("%s can only be written in one format, got %s", out, strings.Join(unnamed, ", "))
+		case files[out]:
+			return nil, fmt.Errorf("output file %s is used by several formats", out)
+		}
+		outputs = append([]reportOutput{{format: unnamed[0], file: out}}, outputs...)
+		unnamed = nil
+	}
+	for _, format := range unnamed {
+		outputs = append(outputs, reportOutput{format: getPrintedFormat(format, verbose)})
+	}
+	if stdout && len(unnamed) == 0 {
+		outputs = append(outputs, reportOutput{format: getPrintedFormat(outputs[0].format, verbose)})
+	}
+	return outputs, nil
+}
+
+// splitFormat splits a -fmt flag in its format and its output file, if any.
+func splitFormat(value string) (string, string, bool) {
+	format, file, named := strings.Cut(value, ":")
+	if format != report.TemplateFormat || !named {
+		return format, file, named
+	}
+	tmpl, file, named := strings.Cut(file, ":")
+	return report.TemplateFormat + ":" + tmpl, file, named
+}
+
+// writeReports writes the report to each of its outputs. The text reports
+// printed in the stdout are colorized on demand.
+func writeReports(outputs []reportOutput, color bool, rootPaths []string, reportInfo *gosec.ReportInfo) error {
+	for _, output := range outputs {
+		if output.file == "" {
+			if err := printReport(output.format, color, rootPaths, reportInfo); err != nil {
+				return fmt.Errorf("failed to print the %s report: %w", output.format, err)
+			}
+			continue
+		}
+		if err := saveReport(output.file, output.format, rootPaths, reportInfo); err != nil {
+			return fmt.Errorf("failed to save the %s report to %s: %w", output.format, output.file, err)
+		}
+	}
+	return nil
+}
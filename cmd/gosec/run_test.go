@@ -1,10 +1,12 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"flag"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"github.com/securego/gosec/v2/cmd/vflag"
@@ -14,8 +16,8 @@ func TestRun_NoInputReturnsFailure(t *testing.T) {
 	t.Parallel()
 
 	code := runInSubprocess(t, "no-input")
-	if code != exitFailure {
-		t.Fatalf("unexpected exit code: got %d want %d", code, exitFailure)
+	if code != exitConfigError {
+		t.Fatalf("unexpected exit code: got %d want %d", code, exitConfigError)
 	}
 }
 
@@ -28,7 +30,53 @@ func TestRun_VersionReturnsSuccess(t *testing.T) {
 	}
 }
 
-func runInSubprocess(t *testing.T, scenario string) int {
+func TestRun_FixUnusedNoSecBelowTheSeverity(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	source := "package main\n\nimport \"crypto/tls\"\n\nfunc main() {\n\t// #nosec G401\n\t_ = &tls.Config{InsecureSkipVerify: true}\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/fix\n\ngo 1.22\n"), 0o600); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(source), 0o600); err != nil {
+		t.Fatalf("failed to write main.go: %v", err)
+	}
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+
+	code := runInSubprocess(t, "fix-unused-nosec", "GOSEC_RUN_DIR="+dir, "GOSEC_RUN_REPORT="+reportFile)
+	if code != exitFindings {
+		t.Fatalf("unexpected exit code: got %d want %d", code, exitFindings)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "main.go"))
+	if err != nil {
+		t.Fatalf("failed to read main.go: %v", err)
+	}
+	want := "package main\n\nimport \"crypto/tls\"\n\nfunc main() {\n\t_ = &tls.Config{InsecureSkipVerify: true}\n}\n"
+	if string(content) != want {
+		t.Errorf("expected the unused directive to be removed, got:\n%s", content)
+	}
+
+	// The report refers to the code before the fixes
+	data, err := os.ReadFile(reportFile)
+	if err != nil {
+		t.Fatalf("failed to read the report: %v", err)
+	}
+	var report struct {
+		Issues []struct {
+			RuleID string `json:"rule_id"`
+			Line   string `json:"line"`
+		}
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("failed to parse the report: %v", err)
+	}
+	if len(report.Issues) != 1 || report.Issues[0].RuleID != "G402" || report.Issues[0].Line != "7" {
+		t.Errorf("expected the G402 issue on line 7 only, got %s", data)
+	}
+}
+
+func runInSubprocess(t *testing.T, scenario string, env ...string) int {
 	t.Helper()
 
 	executable, err := os.Executable()
@@ -38,6 +86,7 @@ func runInSubprocess(t *testing.T, scenario string) int {
 
 	cmd := exec.Command(executable, "-test.run=^TestRunHelperProcess$")
 	cmd.Env = append(os.Environ(), "GOSEC_RUN_HELPER=1", "GOSEC_RUN_SCENARIO="+scenario)
+	cmd.Env = append(cmd.Env, env...)
 
 	err = cmd.Run()
 	if err == nil {
@@ -80,6 +129,10 @@ func TestRunHelperProcess(t *testing.T) {
 	*flagSeverity = "low"
 	*flagConfidence = "low"
 	*flagNoFail = false
+	*flagFailOnSeverity = ""
+	*flagFailOnConfidence = ""
+	*flagNoSecReportUnused = false
+	*flagFixUnusedNoSec = false
 	*flagScanTests = false
 	*flagVersion = false
 	*flagStdOut = false
@@ -93,10 +146,19 @@ func TestRunHelperProcess(t *testing.T) {
 	*flagAiBaseURL = ""
 	*flagAiSkipSSL = false
 	flagDirsExclude = nil
+	flagFormats = nil
 
 	if scenario == "version" {
 		*flagVersion = true
 	}
+	if scenario == "fix-unused-nosec" {
+		os.Args = []string{"gosec", os.Getenv("GOSEC_RUN_DIR")}
+		*flagQuiet = false
+		*flagSeverity = "high"
+		*flagFixUnusedNoSec = true
+		*flagOutput = os.Getenv("GOSEC_RUN_REPORT")
+		flagFormats = arrayFlags{"json"}
+	}
 
 	os.Exit(run())
 }
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+
+	"github.com/securego/gosec/v2/analyzers"
+	"github.com/securego/gosec/v2/taint"
 )
 
 const (
@@ -13,6 +16,22 @@ const (
 	Globals = "global"
 	// ExcludeRulesKey is the config key for path-based rule exclusions
 	ExcludeRulesKey = "exclude-rules"
+	// TaintRulesKey is the config key for user-defined taint analysis rules
+	TaintRulesKey = "taint-rules"
+	// TaintExtensionsKey is the config key for additional sources, sinks and
+	// sanitizers of existing taint analysis rules
+	TaintExtensionsKey = "taint-extensions"
+	// TaintLimitsKey is the config key for the limits of the taint analysis
+	TaintLimitsKey = taint.LimitsConfigKey
+	// AnalyzerLimitsKey is the config key for the limits of the other SSA
+	// analyzers
+	AnalyzerLimitsKey = analyzers.LimitsConfigKey
+	// ScoreOverridesKey is the config key for the per-rule severity and
+	// confidence overrides
+	ScoreOverridesKey = "score-overrides"
+	// FailPolicyKey is the config key for the policy deciding which findings
+	// fail the run
+	FailPolicyKey = "fail-policy"
 )
 
 // GlobalOption defines the name of the global options
@@ -42,6 +61,15 @@ const (
 	// without a justification no longer suppress any findings and an error is
 	// reported instead.
 	NoSecRequireJustification GlobalOption = "nosec-require-justification"
+	// NoSecRequireTicket global option requires a `ticket=<id>` in the
+	// justification of every #nosec / //gosec:disable annotation. When
+	// enabled, directives without a ticket no longer suppress any findings
+	// and an error is reported instead.
+	NoSecRequireTicket GlobalOption = "nosec-require-ticket"
+	// NoSecReportUnused global option reports the #nosec / //gosec:disable
+	// annotations which suppress no issue, under the UnusedNoSecRuleID rule
+	// ID.
+	NoSecReportUnused GlobalOption = "nosec-report-unused"
 )
 
 // NoSecTag returns the tag used to disable gosec for a line of code.
@@ -181,3 +209,150 @@ func (c Config) SetExcludeRules(rules []PathExcludeRule) {
 	}
 	c[ExcludeRulesKey] = rules
 }
+
+// GetScoreOverrides retrieves the severity and confidence overrides from the
+// configuration. Returns nil if no overrides are configured.
+func (c Config) GetScoreOverrides() ([]ScoreOverride, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	rawOverrides, exists := c[ScoreOverridesKey]
+	if !exists {
+		return nil, nil
+	}
+
+	overridesJSON, err := json.Marshal(rawOverrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", ScoreOverridesKey, err)
+	}
+
+	var overrides []ScoreOverride
+	decoder := json.NewDecoder(bytes.NewReader(overridesJSON))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ScoreOverridesKey, err)
+	}
+
+	return overrides, nil
+}
+
+// SetScoreOverrides sets the severity and confidence overrides in the
+// configuration.
+func (c Config) SetScoreOverrides(overrides []ScoreOverride) {
+	if c == nil {
+		return
+	}
+	c[ScoreOverridesKey] = overrides
+}
+
+// GetFailPolicy retrieves the policy deciding which findings fail the run.
+// Returns the default policy, failing on every finding, if none is configured.
+func (c Config) GetFailPolicy() (FailPolicy, error) {
+	var policy FailPolicy
+	if c == nil {
+		return policy, nil
+	}
+
+	rawPolicy, exists := c[FailPolicyKey]
+	if !exists {
+		return policy, nil
+	}
+
+	policyJSON, err := json.Marshal(rawPolicy)
+	if err != nil {
+		return policy, fmt.Errorf("failed to marshal %s: %w", FailPolicyKey, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(policyJSON))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policy); err != nil {
+		return FailPolicy{}, fmt.Errorf("failed to parse %s: %w", FailPolicyKey, err)
+	}
+
+	return policy, nil
+}
+
+// SetFailPolicy sets the policy deciding which findings fail the run in the
+// configuration.
+func (c Config) SetFailPolicy(policy FailPolicy) {
+	if c == nil {
+		return
+	}
+	c[FailPolicyKey] = policy
+}
+
+// GetTaintRules retrieves the user-defined taint analysis rules from the configuration.
+// Returns nil if no taint rules are configured.
+func (c Config) GetTaintRules() ([]analyzers.TaintRule, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	rawRules, exists := c[TaintRulesKey]
+	if !exists {
+		return nil, nil
+	}
+
+	rulesJSON, err := json.Marshal(rawRules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal taint-rules: %w", err)
+	}
+
+	var rules []analyzers.TaintRule
+	decoder := json.NewDecoder(bytes.NewReader(rulesJSON))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("failed to parse taint-rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// GetTaintExtensions retrieves the additional sources, sinks, sanitizers and
+// source classes of the taint analysis rules, keyed by rule ID ("*" applies to
+// all taint rules).
+// Returns nil if no taint extensions are configured.
+func (c Config) GetTaintExtensions() (analyzers.TaintExtensions, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	rawExtensions, exists := c[TaintExtensionsKey]
+	if !exists {
+		return nil, nil
+	}
+
+	extensionsJSON, err := json.Marshal(rawExtensions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal taint-extensions: %w", err)
+	}
+
+	var extensions analyzers.TaintExtensions
+	decoder := json.NewDecoder(bytes.NewReader(extensionsJSON))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&extensions); err != nil {
+		return nil, fmt.Errorf("failed to parse taint-extensions: %w", err)
+	}
+
+	return extensions, nil
+}
+
+// GetTaintLimits returns the limits of the taint analysis from the
+// configuration. The taint analyzers read them again when they run.
+func (c Config) GetTaintLimits() (taint.Limits, error) {
+	if c == nil {
+		return taint.Limits{}, nil
+	}
+	return taint.ParseLimits(c[TaintLimitsKey])
+}
+
+// GetAnalyzerLimits returns the limits of the SSA analyzers other than the
+// taint rules from the configuration. The analyzers read them again when they
+// run.
+func (c Config) GetAnalyzerLimits() (analyzers.Limits, error) {
+	if c == nil {
+		return analyzers.Limits{}, nil
+	}
+	return analyzers.ParseLimits(c[AnalyzerLimitsKey])
+}
@@ -0,0 +1,163 @@
+package gosec
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConfigFileNames are the names of the configuration files discovered in the
+// directories of the scanned packages.
+var ConfigFileNames = []string{".gosec.json", ".gosec.yaml", ".gosec.yml", ".gosec.toml"}
+
+// EffectiveConfig is the configuration resulting from the merge of several
+// sources, e.g. the configuration files found from the module root down to a
+// package directory. It records the source of each setting.
+type EffectiveConfig struct {
+	Config Config
+	// Sources lists the merged sources, in order.
+	Sources []string
+	// Origins maps the path of each setting, such as "global.exclude" or
+	// "G101.pattern", to the source it comes from.
+	Origins map[string]string
+}
+
+// NewEffectiveConfig returns an empty effective configuration.
+func NewEffectiveConfig() *EffectiveConfig {
+	return &EffectiveConfig{
+		Config:  NewConfig(),
+		Origins: make(map[string]string),
+	}
+}
+
+// Merge applies conf on top of the effective configuration. The global options
+// and the maps of settings are merged key by key, while the other values,
+// including the lists, replace the previous ones.
+func (e *EffectiveConfig) Merge(conf Config, source string) {
+	for section, value := range conf {
+		if section == Globals {
+			if globals, ok := value.(map[GlobalOption]string); ok {
+				for option, setting := range globals {
+					e.Config.SetGlobal(option, setting)
+					e.Origins[joinConfigPath(Globals, string(option))] = source
+				}
+				continue
+			}
+		}
+		e.Config[section] = e.mergeValue(e.Config[section], value, section, source)
+	}
+	e.Sources = append(e.Sources, source)
+}
+
+func (e *EffectiveConfig) mergeValue(dst, src interface{}, path, source string) interface{} {
+	dstMap, dstIsMap := dst.(map[string]interface{})
+	srcMap, srcIsMap := src.(map[string]interface{})
+	if dstIsMap && srcIsMap {
+		merged := maps.Clone(dstMap)
+		for key, value := range srcMap {
+			merged[key] = e.mergeValue(dstMap[key], value, joinConfigPath(path, key), source)
+		}
+		return merged
+	}
+
+	maps.DeleteFunc(e.Origins, func(setting, _ string) bool {
+		return setting == path || strings.HasPrefix(setting, path+".")
+	})
+	e.recordOrigins(src, path, source)
+	return src
+}
+
+func (e *EffectiveConfig) recordOrigins(value interface{}, path, source string) {
+	if settings, ok := value.(map[string]interface{}); ok && len(settings) > 0 {
+		for key, setting := range settings {
+			e.recordOrigins(setting, joinConfigPath(path, key), source)
+		}
+		return
+	}
+	e.Origins[path] = source
+}
+
+// ConfigDiscovery finds the configuration files which apply to the package
+// directories. The files are looked up in the directory of a package and in
+// its parents up to the module root, and merged from the module root down, so
+// that the configuration of a subdirectory adjusts the one of its parents.
+// Outside of a module, only the package directory is searched.
+type ConfigDiscovery struct {
+	files map[string]*discoveredConfig
+}
+
+// discoveredConfig is a configuration file loaded from a directory.
+type discoveredConfig struct {
+	path   string
+	config Config
+}
+
+// NewConfigDiscovery returns a ConfigDiscovery which loads each configuration
+// file once.
+func NewConfigDiscovery() *ConfigDiscovery {
+	return &ConfigDiscovery{files: make(map[string]*discoveredConfig)}
+}
+
+// ConfigFor returns the effective configuration of a package directory. It
+// has no sources when no configuration file was found.
+func (d *ConfigDiscovery) ConfigFor(dir string) (*EffectiveConfig, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := []string{dir}
+	if root := FindModuleRoot(dir); root != "" {
+		for current := dir; current != root; {
+			current = filepath.Dir(current)
+			dirs = append(dirs, current)
+		}
+	}
+
+	effective := NewEffectiveConfig()
+	for i := len(dirs) - 1; i >= 0; i-- {
+		file, err := d.lookup(dirs[i])
+		if err != nil {
+			return nil, err
+		}
+		if file != nil {
+			effective.Merge(file.config, file.path)
+		}
+	}
+	return effective, nil
+}
+
+// lookup loads the configuration file of a directory, if any.
+func (d *ConfigDiscovery) lookup(dir string) (*discoveredConfig, error) {
+	if file, ok := d.files[dir]; ok {
+		return file, nil
+	}
+
+	var found []string
+	for _, name := range ConfigFileNames {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			found = append(found, path)
+		} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	var file *discoveredConfig
+	switch len(found) {
+	case 0:
+	case 1:
+		config := NewConfig()
+		if err := config.ReadFile(found[0]); err != nil {
+			return nil, err
+		}
+		file = &discoveredConfig{path: found[0], config: config}
+	default:
+		return nil, fmt.Errorf("%s: found several configuration files: %s", dir, strings.Join(found, ", "))
+	}
+	d.files[dir] = file
+	return file, nil
+}
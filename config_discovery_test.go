@@ -0,0 +1,107 @@
+package gosec_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+)
+
+var _ = Describe("Configuration discovery", func() {
+	var module string
+
+	write := func(name, content string) string {
+		path := filepath.Join(module, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o750)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		module = GinkgoT().TempDir()
+		write("go.mod", "module example.com/discovery\n\ngo 1.22\n")
+	})
+
+	It("should merge the files from the module root down to the package", func() {
+		root := write(".gosec.yaml", "global:\n  exclude: G104\n  audit: enabled\nG101:\n  pattern: secret\n  ignore_entropy: true\n")
+		legacy := write("internal/legacy/.gosec.json", `{"global": {"exclude": "G101,G104"}, "G101": {"pattern": "token"}}`)
+		write("internal/legacy/pkg/legacy.go", "package pkg\n")
+
+		effective, err := gosec.NewConfigDiscovery().ConfigFor(filepath.Join(module, "internal", "legacy", "pkg"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(effective.Sources).To(Equal([]string{root, legacy}))
+
+		exclude, err := effective.Config.GetGlobal(gosec.ExcludeRules)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(exclude).To(Equal("G101,G104"))
+		audit, err := effective.Config.IsGlobalEnabled(gosec.Audit)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(audit).To(BeTrue())
+		Expect(effective.Config["G101"]).To(Equal(map[string]interface{}{"pattern": "token", "ignore_entropy": true}))
+
+		Expect(effective.Origins).To(Equal(map[string]string{
+			"global.exclude":      legacy,
+			"global.audit":        root,
+			"G101.pattern":        legacy,
+			"G101.ignore_entropy": root,
+		}))
+	})
+
+	It("should not apply the files of the sibling directories", func() {
+		write(".gosec.toml", "[global]\naudit = \"enabled\"\n")
+		write("tools/.gosec.yaml", "global:\n  exclude: G204\n")
+
+		effective, err := gosec.NewConfigDiscovery().ConfigFor(filepath.Join(module, "cmd"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(effective.Sources).To(HaveLen(1))
+		_, err = effective.Config.GetGlobal(gosec.ExcludeRules)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should not look above the module root", func() {
+		write(".gosec.yaml", "global:\n  audit: enabled\n")
+		nested := filepath.Join(module, "nested")
+		Expect(os.MkdirAll(nested, 0o750)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(nested, "go.mod"), []byte("module example.com/nested\n"), 0o600)).To(Succeed())
+
+		effective, err := gosec.NewConfigDiscovery().ConfigFor(nested)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(effective.Sources).To(BeEmpty())
+	})
+
+	It("should reject several configuration files in a directory", func() {
+		write(".gosec.json", "{}")
+		write(".gosec.yaml", "{}")
+
+		_, err := gosec.NewConfigDiscovery().ConfigFor(module)
+		Expect(err).Should(MatchError(ContainSubstring("found several configuration files")))
+	})
+
+	It("should report the invalid files", func() {
+		write(".gosec.yaml", "global:\n  audti: enabled\n")
+
+		_, err := gosec.NewConfigDiscovery().ConfigFor(module)
+		Expect(err).Should(MatchError(ContainSubstring(`global: unknown key "audti"`)))
+	})
+
+	It("should replace the lists and record the origin of the merged sources", func() {
+		effective := gosec.NewEffectiveConfig()
+		base := gosec.NewConfig()
+		base[gosec.ExcludeRulesKey] = []interface{}{map[string]interface{}{"path": "cmd/.*", "rules": []interface{}{"G204"}}}
+		effective.Merge(base, "base")
+		override := gosec.NewConfig()
+		override[gosec.ExcludeRulesKey] = []interface{}{}
+		override.SetGlobal(gosec.Nosec, "true")
+		effective.Merge(override, "command line")
+
+		Expect(effective.Config[gosec.ExcludeRulesKey]).To(BeEmpty())
+		Expect(effective.Sources).To(Equal([]string{"base", "command line"}))
+		Expect(effective.Origins).To(Equal(map[string]string{
+			gosec.ExcludeRulesKey: "command line",
+			"global.nosec":        "command line",
+		}))
+	})
+})
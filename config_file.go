@@ -0,0 +1,262 @@
+package gosec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"go.yaml.in/yaml/v3"
+)
+
+// ConfigError is a problem found in a configuration file, at a given line and
+// column when they are known.
+type ConfigError struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e ConfigError) Error() string {
+	var location strings.Builder
+	location.WriteString(e.File)
+	if e.Line > 0 {
+		fmt.Fprintf(&location, ":%d", e.Line)
+		if e.Column > 0 {
+			fmt.Fprintf(&location, ":%d", e.Column)
+		}
+	}
+	if location.Len() == 0 {
+		return e.Message
+	}
+	return location.String() + ": " + e.Message
+}
+
+// ReadFile loads the configuration from a JSON, YAML or TOML file, selected
+// by its extension (.json, .yaml, .yml or .toml; JSON otherwise). Unlike
+// ReadFrom, it validates the configuration against the schema of the known
+// settings: unknown keys and values of the wrong type fail with errors
+// locating them in the file.
+func (c Config) ReadFile(path string) error {
+	// #nosec G304 -- the configuration file is chosen by the user
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	node, err := parseConfigNode(data, filepath.Ext(path))
+	if err != nil {
+		var configErr ConfigError
+		if errors.As(err, &configErr) {
+			configErr.File = path
+			return configErr
+		}
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if problems := validateConfigNode(node); len(problems) > 0 {
+		errs := make([]error, len(problems))
+		for i, problem := range problems {
+			problem.File = path
+			errs[i] = problem
+		}
+		return errors.Join(errs...)
+	}
+
+	// Round trip the settings through JSON so that the rules see the same
+	// value types whatever the format of the file.
+	var settings interface{}
+	if err := node.Decode(&settings); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if settings == nil {
+		return nil
+	}
+	normalized, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if _, err := c.ReadFrom(bytes.NewReader(normalized)); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// parseConfigNode parses the configuration file in the format selected by its
+// extension into a YAML node tree, which records the position of each value.
+func parseConfigNode(data []byte, ext string) (*yaml.Node, error) {
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return nil, yamlSyntaxError(err)
+		}
+		return &node, nil
+	case ".toml":
+		return parseTOMLNode(data)
+	default:
+		// JSON is a subset of YAML, which records the positions. The syntax
+		// is checked as JSON first to reject what only YAML accepts.
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				line, column := offsetPosition(data, syntaxErr.Offset)
+				return nil, ConfigError{Line: line, Column: column, Message: syntaxErr.Error()}
+			}
+			return nil, err
+		}
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return nil, err
+		}
+		return &node, nil
+	}
+}
+
+// yamlErrorPattern matches the syntax errors of the YAML parser.
+var yamlErrorPattern = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// yamlSyntaxError converts a syntax error of the YAML parser into a
+// ConfigError.
+func yamlSyntaxError(err error) error {
+	match := yamlErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+	line, _ := strconv.Atoi(match[1])
+	return ConfigError{Line: line, Message: match[2]}
+}
+
+// offsetPosition converts a byte offset into a line and a column.
+func offsetPosition(data []byte, offset int64) (int, int) {
+	offset = min(offset, int64(len(data)))
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+// tomlLocator fails the decoding of any value, which makes the TOML decoder
+// report the position of the key being decoded.
+type tomlLocator struct{}
+
+var errTOMLLocate = errors.New("locate")
+
+func (tomlLocator) UnmarshalTOML(interface{}) error {
+	return errTOMLLocate
+}
+
+// parseTOMLNode parses a TOML document into a YAML node tree.
+func parseTOMLNode(data []byte) (*yaml.Node, error) {
+	var document map[string]toml.Primitive
+	md, err := toml.NewDecoder(bytes.NewReader(data)).Decode(&document)
+	if err != nil {
+		var parseErr toml.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, ConfigError{Line: parseErr.Position.Line, Column: parseErr.Position.Col, Message: parseErr.Message}
+		}
+		return nil, err
+	}
+	root, err := tomlTableNode(&md, document)
+	if err != nil {
+		return nil, err
+	}
+	root.Line = 1
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}, nil
+}
+
+// tomlTableNode converts a TOML table into a mapping node, with its keys in
+// document order.
+func tomlTableNode(md *toml.MetaData, table map[string]toml.Primitive) (*yaml.Node, error) {
+	type entry struct {
+		key   string
+		value *yaml.Node
+	}
+	entries := make([]entry, 0, len(table))
+	for key, prim := range table {
+		value, err := tomlValueNode(md, prim)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry{key, value})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].value.Line != entries[j].value.Line {
+			return entries[i].value.Line < entries[j].value.Line
+		}
+		return entries[i].key < entries[j].key
+	})
+
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, e := range entries {
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: e.key, Line: e.value.Line}
+		node.Content = append(node.Content, key, e.value)
+	}
+	return node, nil
+}
+
+// tomlValueNode converts a TOML value into a node. The TOML decoder only
+// knows the position of the keys, so the values of an array share the
+// position of its key, and the keys of an array of tables the position of
+// their last occurrence.
+func tomlValueNode(md *toml.MetaData, prim toml.Primitive) (*yaml.Node, error) {
+	var line int
+	var parseErr toml.ParseError
+	if errors.As(md.PrimitiveDecode(prim, tomlLocator{}), &parseErr) {
+		line = parseErr.Position.Line
+	}
+
+	var value interface{}
+	if err := md.PrimitiveDecode(prim, &value); err != nil {
+		return nil, err
+	}
+
+	var node *yaml.Node
+	switch v := value.(type) {
+	case map[string]interface{}:
+		var table map[string]toml.Primitive
+		if err := md.PrimitiveDecode(prim, &table); err != nil {
+			return nil, err
+		}
+		var err error
+		if node, err = tomlTableNode(md, table); err != nil {
+			return nil, err
+		}
+	case []map[string]interface{}, []interface{}:
+		var items []toml.Primitive
+		if err := md.PrimitiveDecode(prim, &items); err != nil {
+			return nil, err
+		}
+		node = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range items {
+			child, err := tomlValueNode(md, item)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+	case string:
+		node = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+	case bool:
+		node = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}
+	case int64:
+		node = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.FormatInt(v, 10)}
+	case float64:
+		node = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: strconv.FormatFloat(v, 'g', -1, 64)}
+	case time.Time:
+		node = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!timestamp", Value: v.Format(time.RFC3339)}
+	default:
+		node = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: fmt.Sprint(v)}
+	}
+	node.Line = line
+	return node, nil
+}
@@ -0,0 +1,398 @@
+package gosec
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/securego/gosec/v2/analyzers"
+	"github.com/securego/gosec/v2/issue"
+)
+
+// configSchema is the typed schema of the configuration file. Only the keys
+// it declares are accepted, and their values must have the declared types.
+type configSchema struct {
+	Global          globalSchema              `json:"global"`
+	ExcludeRules    []PathExcludeRule         `json:"exclude-rules"`
+	TaintRules      []analyzers.TaintRule     `json:"taint-rules"`
+	TaintExtensions analyzers.TaintExtensions `json:"taint-extensions"`
+	TaintLimits     taintLimitsSchema         `json:"taint-limits"`
+	AnalyzerLimits  analyzerLimitsSchema      `json:"analyzer-limits"`
+	ScoreOverrides  []scoreOverrideSchema     `json:"score-overrides"`
+	FailPolicy      failPolicySchema          `json:"fail-policy"`
+
+	G101 credentialsSchema   `json:"G101"`
+	G104 map[string][]string `json:"G104"`
+	G111 patternSchema       `json:"G111"`
+	G117 patternSchema       `json:"G117"`
+	G301 fileModeValue       `json:"G301"`
+	G302 fileModeValue       `json:"G302"`
+	G306 fileModeValue       `json:"G306"`
+	G307 fileModeValue       `json:"G307"`
+	G402 tlsSchema           `json:"G402"`
+}
+
+// globalSchema declares the global options. The keys are the GlobalOption
+// values.
+type globalSchema struct {
+	Nosec                     switchValue `json:"nosec"`
+	ShowIgnored               switchValue `json:"show-ignored"`
+	Audit                     switchValue `json:"audit"`
+	NoSecAlternative          string      `json:"#nosec"`
+	ExcludeRules              string      `json:"exclude"`
+	IncludeRules              string      `json:"include"`
+	SSA                       switchValue `json:"ssa"`
+	NoSecRequireRules         switchValue `json:"nosec-require-rules"`
+	NoSecRequireJustification switchValue `json:"nosec-require-justification"`
+	NoSecRequireTicket        switchValue `json:"nosec-require-ticket"`
+	NoSecReportUnused         switchValue `json:"nosec-report-unused"`
+}
+
+// credentialsSchema declares the settings of G101. The numbers are strings,
+// as the rule expects them.
+type credentialsSchema struct {
+	Pattern          regexpValue `json:"pattern"`
+	IgnoreEntropy    bool        `json:"ignore_entropy"`
+	EntropyThreshold floatValue  `json:"entropy_threshold"`
+	PerCharThreshold floatValue  `json:"per_char_threshold"`
+	Truncate         intValue    `json:"truncate"`
+	MinEntropyLength intValue    `json:"min_entropy_length"`
+}
+
+// patternSchema declares the settings of the rules matching a pattern.
+type patternSchema struct {
+	Pattern regexpValue `json:"pattern"`
+}
+
+// tlsSchema declares the settings of G402.
+type tlsSchema struct {
+	Level tlsLevelValue `json:"level"`
+}
+
+// scoreOverrideSchema declares a severity and confidence override.
+type scoreOverrideSchema struct {
+	Rule       string          `json:"rule"`
+	Paths      []string        `json:"paths"`
+	Severity   severityValue   `json:"severity"`
+	Confidence confidenceValue `json:"confidence"`
+}
+
+// failPolicySchema declares the policy deciding which findings fail the run.
+type failPolicySchema struct {
+	Severity    severityValue                   `json:"severity"`
+	Confidence  confidenceValue                 `json:"confidence"`
+	MaxFindings int                             `json:"max-findings"`
+	Rules       map[string]ruleFailPolicySchema `json:"rules"`
+}
+
+// ruleFailPolicySchema declares the fail policy of a rule.
+type ruleFailPolicySchema struct {
+	Action      failActionValue `json:"action"`
+	MaxFindings int             `json:"max-findings"`
+}
+
+// taintLimitsSchema declares the limits of the taint analysis.
+type taintLimitsSchema struct {
+	MaxDepth       int           `json:"max-depth"`
+	MaxVisited     int           `json:"max-visited"`
+	FunctionBudget durationValue `json:"function-budget"`
+}
+
+// analyzerLimitsSchema declares the limits of the SSA analyzers.
+type analyzerLimitsSchema struct {
+	MaxDepth int `json:"max-depth"`
+}
+
+// scalarValue is implemented by the schema types of the scalars having their
+// own syntax. check returns a description of the problem, or "" if the value
+// is valid.
+type scalarValue interface {
+	check(node *yaml.Node) string
+}
+
+// switchValue is an option enabled with true or "enabled".
+type switchValue string
+
+func (switchValue) check(node *yaml.Node) string {
+	switch {
+	case node.Tag == "!!bool":
+		return ""
+	case node.Tag == "!!str" && (node.Value == "true" || node.Value == "false" || node.Value == "enabled" || node.Value == "disabled"):
+		return ""
+	}
+	return fmt.Sprintf(`expected true, false, "enabled" or "disabled", found %s`, describeNode(node))
+}
+
+// regexpValue is a regular expression.
+type regexpValue string
+
+func (regexpValue) check(node *yaml.Node) string {
+	if node.Tag != "!!str" {
+		return fmt.Sprintf("expected a regular expression, found %s", describeNode(node))
+	}
+	if _, err := regexp.Compile(node.Value); err != nil {
+		return fmt.Sprintf("invalid regular expression: %v", err)
+	}
+	return ""
+}
+
+// floatValue is a number written as a string, e.g. "80.0".
+type floatValue string
+
+func (floatValue) check(node *yaml.Node) string {
+	if node.Tag == "!!str" {
+		if _, err := strconv.ParseFloat(node.Value, 64); err == nil {
+			return ""
+		}
+	}
+	return fmt.Sprintf(`expected a number in a string such as "80.0", found %s`, describeNode(node))
+}
+
+// intValue is an integer written as a string, e.g. "16".
+type intValue string
+
+func (intValue) check(node *yaml.Node) string {
+	if node.Tag == "!!str" {
+		if _, err := strconv.Atoi(node.Value); err == nil {
+			return ""
+		}
+	}
+	return fmt.Sprintf(`expected an integer in a string such as "16", found %s`, describeNode(node))
+}
+
+// fileModeValue is a file mode written as a string, e.g. "0o600".
+type fileModeValue string
+
+func (fileModeValue) check(node *yaml.Node) string {
+	if node.Tag == "!!str" {
+		if mode, err := strconv.ParseInt(node.Value, 0, 64); err == nil && mode >= 0 && mode <= 0o7777 {
+			return ""
+		}
+	}
+	return fmt.Sprintf(`expected a file mode in a string such as "0o600", found %s`, describeNode(node))
+}
+
+// tlsLevelValue is one of the TLS configuration levels checked by G402.
+type tlsLevelValue string
+
+func (tlsLevelValue) check(node *yaml.Node) string {
+	if node.Tag == "!!str" {
+		switch node.Value {
+		case "modern", "intermediate", "old":
+			return ""
+		}
+	}
+	return fmt.Sprintf(`expected "modern", "intermediate" or "old", found %s`, describeNode(node))
+}
+
+// severityValue is a severity such as "medium".
+type severityValue string
+
+func (severityValue) check(node *yaml.Node) string {
+	if node.Tag == "!!str" {
+		if _, err := issue.ParseScore(node.Value); err == nil {
+			return ""
+		}
+	}
+	return fmt.Sprintf(`expected "low", "medium", "high" or "critical", found %s`, describeNode(node))
+}
+
+// confidenceValue is a confidence level such as "medium".
+type confidenceValue string
+
+func (confidenceValue) check(node *yaml.Node) string {
+	if node.Tag == "!!str" {
+		if _, err := issue.ParseConfidence(node.Value); err == nil {
+			return ""
+		}
+	}
+	return fmt.Sprintf(`expected "low", "medium" or "high", found %s`, describeNode(node))
+}
+
+// failActionValue is the fail policy of a rule, "fail" or "ignore".
+type failActionValue string
+
+func (failActionValue) check(node *yaml.Node) string {
+	if node.Tag == "!!str" && (node.Value == FailActionFail || node.Value == FailActionIgnore) {
+		return ""
+	}
+	return fmt.Sprintf(`expected "fail" or "ignore", found %s`, describeNode(node))
+}
+
+// durationValue is a duration such as "1.5s".
+type durationValue string
+
+func (durationValue) check(node *yaml.Node) string {
+	if node.Tag == "!!str" {
+		if _, err := time.ParseDuration(node.Value); err == nil {
+			return ""
+		}
+	}
+	return fmt.Sprintf(`expected a duration such as "2s", found %s`, describeNode(node))
+}
+
+var scalarValueType = reflect.TypeOf((*scalarValue)(nil)).Elem()
+
+// validateConfigNode checks the configuration document against the schema and
+// returns the problems found, in document order.
+func validateConfigNode(node *yaml.Node) []ConfigError {
+	var errs []ConfigError
+	checkNode(node, reflect.TypeOf(configSchema{}), "", &errs)
+	return errs
+}
+
+// checkNode checks that node holds a value of type t. path locates the node in
+// the configuration for the error messages.
+func checkNode(node *yaml.Node, t reflect.Type, path string, errs *[]ConfigError) {
+	report := func(n *yaml.Node, format string, args ...interface{}) {
+		message := fmt.Sprintf(format, args...)
+		if path != "" {
+			message = path + ": " + message
+		}
+		*errs = append(*errs, ConfigError{Line: n.Line, Column: n.Column, Message: message})
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) > 0 {
+			checkNode(node.Content[0], t, path, errs)
+		}
+		return
+	case yaml.AliasNode:
+		checkNode(node.Alias, t, path, errs)
+		return
+	}
+	if node.Tag == "!!null" {
+		return
+	}
+
+	if t.Implements(scalarValueType) {
+		if node.Kind != yaml.ScalarNode {
+			report(node, "expected a scalar, found %s", describeNode(node))
+			return
+		}
+		if problem := reflect.Zero(t).Interface().(scalarValue).check(node); problem != "" {
+			report(node, "%s", problem)
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		checkNode(node, t.Elem(), path, errs)
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			report(node, "expected a map, found %s", describeNode(node))
+			return
+		}
+		fields := schemaFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			field, ok := fields[key.Value]
+			if !ok {
+				report(key, "unknown key %q", key.Value)
+				continue
+			}
+			checkNode(value, field, joinConfigPath(path, key.Value), errs)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			report(node, "expected a map, found %s", describeNode(node))
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if t.Key().Kind() == reflect.Int {
+				if _, err := strconv.Atoi(key.Value); err != nil {
+					report(key, "expected an integer key, found %q", key.Value)
+					continue
+				}
+			} else if key.Tag != "!!str" {
+				report(key, "expected a string key, found %s", describeNode(key))
+				continue
+			}
+			checkNode(value, t.Elem(), joinConfigPath(path, key.Value), errs)
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			report(node, "expected a list, found %s", describeNode(node))
+			return
+		}
+		for i, item := range node.Content {
+			checkNode(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	case reflect.String:
+		if node.Tag != "!!str" {
+			report(node, "expected a string, found %s", describeNode(node))
+		}
+	case reflect.Bool:
+		if node.Tag != "!!bool" {
+			report(node, "expected a boolean, found %s", describeNode(node))
+		}
+	case reflect.Int, reflect.Int64:
+		if node.Tag != "!!int" {
+			report(node, "expected an integer, found %s", describeNode(node))
+		}
+	case reflect.Float64:
+		if node.Tag != "!!int" && node.Tag != "!!float" {
+			report(node, "expected a number, found %s", describeNode(node))
+		}
+	}
+}
+
+// schemaFields maps the keys of a schema struct to the types of their values.
+// The fields of embedded structs are promoted, as in encoding/json.
+func schemaFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for name, typ := range schemaFields(field.Type) {
+				fields[name] = typ
+			}
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func joinConfigPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// describeNode describes a value for the error messages, e.g. `the integer 600`.
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a map"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	switch node.Tag {
+	case "!!str":
+		return fmt.Sprintf("the string %q", node.Value)
+	case "!!int":
+		return "the integer " + node.Value
+	case "!!float":
+		return "the number " + node.Value
+	case "!!bool":
+		return "the boolean " + node.Value
+	}
+	return node.Value
+}
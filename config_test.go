@@ -2,7 +2,10 @@ package gosec_test
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -206,4 +209,346 @@ var _ = Describe("Configuration", func() {
 			Expect(rules).Should(BeNil())
 		})
 	})
+
+	Context("when managing score overrides", func() {
+		It("should read the score overrides from the config", func() {
+			overrides := []gosec.ScoreOverride{{Rule: "G104", Paths: []string{"payments/**"}, Severity: "medium"}}
+			configuration.SetScoreOverrides(overrides)
+
+			var buf bytes.Buffer
+			_, err := configuration.WriteTo(&buf)
+			Expect(err).ShouldNot(HaveOccurred())
+			loaded := gosec.NewConfig()
+			_, err = loaded.ReadFrom(&buf)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(loaded.GetScoreOverrides()).Should(Equal(overrides))
+		})
+	})
+
+	Context("when managing the fail policy", func() {
+		It("should read the fail policy from the config", func() {
+			_, err := configuration.ReadFrom(strings.NewReader(`{
+				"fail-policy": {
+					"severity": "high",
+					"max-findings": 2,
+					"rules": {"G104": {"action": "ignore"}, "G402": {"action": "fail", "max-findings": 1}}
+				}
+			}`))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			policy, err := configuration.GetFailPolicy()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(policy).Should(Equal(gosec.FailPolicy{
+				Severity:    "high",
+				MaxFindings: 2,
+				Rules: map[string]gosec.RuleFailPolicy{
+					"G104": {Action: gosec.FailActionIgnore},
+					"G402": {Action: gosec.FailActionFail, MaxFindings: 1},
+				},
+			}))
+		})
+
+		It("should return the default policy when none is configured", func() {
+			policy, err := configuration.GetFailPolicy()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(policy).Should(Equal(gosec.FailPolicy{}))
+		})
+	})
+
+	Context("when managing taint rules", func() {
+		It("should read user-defined taint rules from the config", func() {
+			config := `{
+				"taint-rules": [{
+					"id": "G790",
+					"description": "Raw query via internal store",
+					"severity": "high",
+					"cwe": "CWE-89",
+					"sources": [{"package": "net/http", "name": "Request", "pointer": true}],
+					"sinks": [{"package": "example.com/store", "receiver": "Store", "method": "RawQuery", "pointer": true, "check_args": [1]}],
+					"sanitizers": [{"package": "example.com/store", "method": "Quote"}]
+				}]
+			}`
+			_, err := configuration.ReadFrom(strings.NewReader(config))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			taintRules, err := configuration.GetTaintRules()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(taintRules).Should(HaveLen(1))
+			Expect(taintRules[0].ID).Should(Equal("G790"))
+			Expect(taintRules[0].Sources[0].Pointer).Should(BeTrue())
+			Expect(taintRules[0].Sinks[0].Receiver).Should(Equal("Store"))
+			Expect(taintRules[0].Sinks[0].CheckArgs).Should(Equal([]int{1}))
+			Expect(taintRules[0].Sanitizers[0].Method).Should(Equal("Quote"))
+		})
+
+		It("should return nil when no taint rules are configured", func() {
+			taintRules, err := configuration.GetTaintRules()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(taintRules).Should(BeNil())
+		})
+
+		It("should reject unknown fields in taint rules", func() {
+			config := `{"taint-rules": [{"id": "G790", "sinkz": []}]}`
+			_, err := configuration.ReadFrom(strings.NewReader(config))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, err = configuration.GetTaintRules()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("sinkz"))
+		})
+
+		It("should read taint rule extensions from the config", func() {
+			config := `{
+				"taint-extensions": {
+					"*": {"sources": [{"package": "example.com/rpc", "name": "Request", "pointer": true}]},
+					"G701": {"sinks": [{"package": "example.com/store", "method": "Exec", "check_args": [1]}]}
+				}
+			}`
+			_, err := configuration.ReadFrom(strings.NewReader(config))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			extensions, err := configuration.GetTaintExtensions()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(extensions).Should(HaveLen(2))
+			Expect(extensions["*"].Sources[0].Package).Should(Equal("example.com/rpc"))
+			Expect(extensions["G701"].Sinks[0].CheckArgs).Should(Equal([]int{1}))
+		})
+
+		It("should read the source classes enabled by taint rule extensions", func() {
+			config := `{"taint-extensions": {"G705": {"source_classes": ["storage"]}}}`
+			_, err := configuration.ReadFrom(strings.NewReader(config))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			extensions, err := configuration.GetTaintExtensions()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(extensions["G705"].SourceClasses).Should(Equal([]string{"storage"}))
+		})
+
+		It("should reject unknown fields in taint rule extensions", func() {
+			config := `{"taint-extensions": {"G701": {"sanitisers": []}}}`
+			_, err := configuration.ReadFrom(strings.NewReader(config))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, err = configuration.GetTaintExtensions()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("sanitisers"))
+		})
+
+		It("should read the taint analysis limits", func() {
+			config := `{"taint-limits": {"max-depth": 80, "max-visited": 5000, "function-budget": "1.5s"}}`
+			_, err := configuration.ReadFrom(strings.NewReader(config))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			limits, err := configuration.GetTaintLimits()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(limits.MaxDepth).Should(Equal(80))
+			Expect(limits.MaxVisited).Should(Equal(5000))
+			Expect(limits.FunctionBudget).Should(Equal(1500 * time.Millisecond))
+		})
+
+		It("should reject invalid taint analysis limits", func() {
+			for _, config := range []string{
+				`{"taint-limits": {"max-depth": -1}}`,
+				`{"taint-limits": {"function-budget": "soon"}}`,
+				`{"taint-limits": {"max_values": 10}}`,
+			} {
+				configuration := gosec.NewConfig()
+				_, err := configuration.ReadFrom(strings.NewReader(config))
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = configuration.GetTaintLimits()
+				Expect(err).Should(HaveOccurred(), config)
+			}
+		})
+
+		It("should read the analyzer limits", func() {
+			_, err := configuration.ReadFrom(strings.NewReader(`{"analyzer-limits": {"max-depth": 40}}`))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			limits, err := configuration.GetAnalyzerLimits()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(limits.MaxDepth).Should(Equal(40))
+		})
+
+		It("should reject invalid analyzer limits", func() {
+			for _, config := range []string{
+				`{"analyzer-limits": {"max-depth": -1}}`,
+				`{"analyzer-limits": {"max-visited": 10}}`,
+			} {
+				configuration := gosec.NewConfig()
+				_, err := configuration.ReadFrom(strings.NewReader(config))
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = configuration.GetAnalyzerLimits()
+				Expect(err).Should(HaveOccurred(), config)
+			}
+		})
+	})
+
+	Context("when reading a configuration file", func() {
+		write := func(name, content string) string {
+			path := filepath.Join(GinkgoT().TempDir(), name)
+			Expect(os.WriteFile(path, []byte(content), 0o600)).Should(Succeed())
+			return path
+		}
+
+		It("should read the same settings from JSON, YAML and TOML", func() {
+			files := []string{
+				write("gosec.json", `{
+	"global": {"nosec": "enabled", "audit": true},
+	"G101": {"pattern": "(?i)secret", "entropy_threshold": "70.0"},
+	"G104": {"os": ["Remove"]},
+	"G302": "0o600",
+	"G402": {"level": "modern"},
+	"exclude-rules": [{"path": "cmd/.*", "rules": ["G204"]}]
+}`),
+				write("gosec.yaml", `
+global:
+  nosec: enabled
+  audit: true
+G101:
+  pattern: (?i)secret
+  entropy_threshold: "70.0"
+G104:
+  os: [Remove]
+G302: "0o600"
+G402:
+  level: modern
+exclude-rules:
+  - path: cmd/.*
+    rules: [G204]
+`),
+				write("gosec.toml", `
+G302 = "0o600"
+
+[global]
+nosec = "enabled"
+audit = true
+
+[G101]
+pattern = "(?i)secret"
+entropy_threshold = "70.0"
+
+[G104]
+os = ["Remove"]
+
+[G402]
+level = "modern"
+
+[[exclude-rules]]
+path = "cmd/.*"
+rules = ["G204"]
+`),
+			}
+			for _, file := range files {
+				configuration := gosec.NewConfig()
+				Expect(configuration.ReadFile(file)).Should(Succeed(), file)
+
+				nosec, err := configuration.IsGlobalEnabled(gosec.Nosec)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(nosec).Should(BeTrue())
+				audit, err := configuration.IsGlobalEnabled(gosec.Audit)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(audit).Should(BeTrue())
+
+				Expect(configuration["G101"]).Should(HaveKeyWithValue("entropy_threshold", "70.0"))
+				Expect(configuration["G104"]).Should(HaveKeyWithValue("os", []interface{}{"Remove"}))
+				Expect(configuration["G302"]).Should(Equal("0o600"))
+				Expect(configuration["G402"]).Should(HaveKeyWithValue("level", "modern"))
+
+				excludeRules, err := configuration.GetExcludeRules()
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(excludeRules).Should(Equal([]gosec.PathExcludeRule{{Path: "cmd/.*", Rules: []string{"G204"}}}))
+			}
+		})
+
+		It("should report unknown keys with their position", func() {
+			file := write("gosec.json", `{
+	"global": {"nosec": "enabled"},
+	"G10l": {"pattern": "secret"}
+}`)
+			err := configuration.ReadFile(file)
+			Expect(err).Should(MatchError(file + `:3:2: unknown key "G10l"`))
+
+			file = write("gosec.yaml", "globals:\n  audit: enabled\n")
+			err = configuration.ReadFile(file)
+			Expect(err).Should(MatchError(file + `:1:1: unknown key "globals"`))
+		})
+
+		It("should report every value of the wrong type", func() {
+			file := write("gosec.yaml", `
+global:
+  audit: yes please
+G101:
+  entropy_threshold: 80
+G301: 0750
+G402:
+  level: strict
+`)
+			err := configuration.ReadFile(file)
+			Expect(err).Should(HaveOccurred())
+			Expect(strings.Split(err.Error(), "\n")).Should(Equal([]string{
+				file + `:3:10: global.audit: expected true, false, "enabled" or "disabled", found the string "yes please"`,
+				file + `:5:22: G101.entropy_threshold: expected a number in a string such as "80.0", found the integer 80`,
+				file + `:6:7: G301: expected a file mode in a string such as "0o600", found the integer 0750`,
+				file + `:8:10: G402.level: expected "modern", "intermediate" or "old", found the string "strict"`,
+			}))
+		})
+
+		It("should check the nested settings of the taint rules", func() {
+			file := write("gosec.json", `{
+	"taint-rules": [{"id": "G790", "description": "d", "sinkz": []}],
+	"taint-extensions": {"G701": {"sinks": [{"package": "db", "method": "Exec", "check_args": ["1"]}]}}
+}`)
+			err := configuration.ReadFile(file)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring(`:2:53: taint-rules[0]: unknown key "sinkz"`))
+			Expect(err.Error()).Should(ContainSubstring(`:3:93: taint-extensions.G701.sinks[0].check_args[0]: expected an integer, found the string "1"`))
+		})
+
+		It("should report the line of the TOML keys", func() {
+			file := write("gosec.toml", `
+[global]
+nosec = "enabled"
+
+[G101]
+pattern = "(?i)secret"
+entropy = "70.0"
+`)
+			err := configuration.ReadFile(file)
+			Expect(err).Should(MatchError(file + `:7: G101: unknown key "entropy"`))
+		})
+
+		It("should report syntax errors with their position", func() {
+			file := write("gosec.json", "{\n  \"global\": {\"nosec\": \"enabled\",}\n}")
+			err := configuration.ReadFile(file)
+			Expect(err).Should(MatchError(ContainSubstring(file + ":2:")))
+
+			file = write("gosec.yaml", "global:\n  nosec: [enabled\n")
+			err = configuration.ReadFile(file)
+			Expect(err).Should(MatchError(ContainSubstring(file + ":")))
+
+			file = write("gosec.toml", "[global\nnosec = true\n")
+			err = configuration.ReadFile(file)
+			Expect(err).Should(MatchError(ContainSubstring(file + ":2:")))
+		})
+
+		It("should check the scores of the score overrides", func() {
+			file := write("gosec.yaml", "score-overrides:\n  - rule: G104\n    severity: urgent\n")
+			err := configuration.ReadFile(file)
+			Expect(err).Should(MatchError(file + `:3:15: score-overrides[0].severity: expected "low", "medium", "high" or "critical", found the string "urgent"`))
+		})
+
+		It("should check the actions of the fail policy", func() {
+			file := write("gosec.yaml", "fail-policy:\n  rules:\n    G104:\n      action: skip\n")
+			err := configuration.ReadFile(file)
+			Expect(err).Should(MatchError(ContainSubstring(`fail-policy.rules.G104.action: expected "fail" or "ignore", found the string "skip"`)))
+		})
+
+		It("should reject invalid regular expressions", func() {
+			file := write("gosec.json", `{"G111": {"pattern": "http\\.Dir("}}`)
+			err := configuration.ReadFile(file)
+			Expect(err).Should(MatchError(ContainSubstring("G111.pattern: invalid regular expression")))
+		})
+	})
 })
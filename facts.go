@@ -0,0 +1,105 @@
+package gosec
+
+import (
+	"go/types"
+	"reflect"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/objectpath"
+)
+
+// FactStore keeps the analysis facts exported by the analyzers, such as the
+// taint summaries of functions, so that they are available when analyzing
+// the packages importing them.
+type FactStore interface {
+	ImportObjectFact(analyzer *analysis.Analyzer, obj types.Object, fact analysis.Fact) bool
+	ExportObjectFact(analyzer *analysis.Analyzer, obj types.Object, fact analysis.Fact)
+}
+
+// factKey identifies an object fact. Objects are identified by their path
+// rather than their identity because every package is loaded on its own, with
+// its own copy of the imported packages.
+type factKey struct {
+	analyzer string
+	pkg      string
+	obj      objectpath.Path
+	fact     reflect.Type
+}
+
+// memoryFactStore is a FactStore holding the facts in memory, for the time of
+// a single scan.
+type memoryFactStore struct {
+	mu    sync.RWMutex
+	facts map[factKey]analysis.Fact
+	// pkgPaths holds the import path of the packages being analyzed, which
+	// are loaded from their files as "command-line-arguments".
+	pkgPaths map[*types.Package]string
+}
+
+// NewFactStore creates an empty in-memory fact store.
+func NewFactStore() FactStore {
+	return &memoryFactStore{
+		facts:    make(map[factKey]analysis.Fact),
+		pkgPaths: make(map[*types.Package]string),
+	}
+}
+
+// setPackagePath records the import path of a package while it is analyzed.
+// An empty path forgets the package.
+func (s *memoryFactStore) setPackagePath(pkg *types.Package, path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if path == "" {
+		delete(s.pkgPaths, pkg)
+		return
+	}
+	s.pkgPaths[pkg] = path
+}
+
+func (s *memoryFactStore) newFactKey(analyzer *analysis.Analyzer, obj types.Object, fact analysis.Fact) (factKey, bool) {
+	if obj == nil || obj.Pkg() == nil {
+		return factKey{}, false
+	}
+	path, err := objectpath.For(obj)
+	if err != nil {
+		return factKey{}, false
+	}
+	s.mu.RLock()
+	pkgPath, ok := s.pkgPaths[obj.Pkg()]
+	s.mu.RUnlock()
+	if !ok {
+		pkgPath = obj.Pkg().Path()
+	}
+	return factKey{
+		analyzer: analyzer.Name,
+		pkg:      pkgPath,
+		obj:      path,
+		fact:     reflect.TypeOf(fact),
+	}, true
+}
+
+func (s *memoryFactStore) ImportObjectFact(analyzer *analysis.Analyzer, obj types.Object, fact analysis.Fact) bool {
+	key, ok := s.newFactKey(analyzer, obj, fact)
+	if !ok {
+		return false
+	}
+	s.mu.RLock()
+	stored, ok := s.facts[key]
+	s.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	reflect.ValueOf(fact).Elem().Set(reflect.ValueOf(stored).Elem())
+	return true
+}
+
+func (s *memoryFactStore) ExportObjectFact(analyzer *analysis.Analyzer, obj types.Object, fact analysis.Fact) {
+	key, ok := s.newFactKey(analyzer, obj, fact)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	s.facts[key] = fact
+	s.mu.Unlock()
+}
@@ -0,0 +1,130 @@
+package gosec
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/securego/gosec/v2/issue"
+)
+
+const (
+	// FailActionFail makes every reported finding of a rule fail the run,
+	// whatever its severity and confidence
+	FailActionFail = "fail"
+	// FailActionIgnore reports the findings of a rule without failing the run
+	FailActionIgnore = "ignore"
+)
+
+// FailPolicy decides which of the reported findings fail the run. By default
+// every reported finding does. The findings below the severity or the
+// confidence thresholds are still reported but do not fail the run, and the
+// run fails only when more than MaxFindings findings remain.
+type FailPolicy struct {
+	Severity    string                    `json:"severity,omitempty"`     // Minimum severity: low, medium, high or critical
+	Confidence  string                    `json:"confidence,omitempty"`   // Minimum confidence: low, medium or high
+	MaxFindings int                       `json:"max-findings,omitempty"` // Number of findings tolerated
+	Rules       map[string]RuleFailPolicy `json:"rules,omitempty"`        // Policies of the rules, by rule ID
+}
+
+// RuleFailPolicy is the policy of the findings of a rule. A rule with the
+// "fail" action or its own maximum is counted apart from the other rules.
+type RuleFailPolicy struct {
+	Action      string `json:"action,omitempty"`       // "fail" or "ignore"
+	MaxFindings int    `json:"max-findings,omitempty"` // Number of findings of the rule tolerated
+}
+
+// FailingFindings is a class of findings having more findings than tolerated:
+// those of a rule counted apart, or those of all the other rules.
+type FailingFindings struct {
+	RuleID      string // Empty for the findings of the rules not counted apart
+	Count       int
+	MaxFindings int
+}
+
+// Validate checks the thresholds, the actions and the maximums of the policy.
+func (p FailPolicy) Validate() error {
+	_, _, err := p.thresholds()
+	if err != nil {
+		return err
+	}
+	if p.MaxFindings < 0 {
+		return fmt.Errorf("%s: max-findings cannot be negative", FailPolicyKey)
+	}
+	for ruleID, rule := range p.Rules {
+		switch rule.Action {
+		case "", FailActionFail, FailActionIgnore:
+		default:
+			return fmt.Errorf("%s: rules: %s: invalid action %q, expected %q or %q", FailPolicyKey, ruleID, rule.Action, FailActionFail, FailActionIgnore)
+		}
+		if rule.MaxFindings < 0 {
+			return fmt.Errorf("%s: rules: %s: max-findings cannot be negative", FailPolicyKey, ruleID)
+		}
+	}
+	return nil
+}
+
+// Evaluate counts the findings failing the run, and returns the classes of
+// findings exceeding their maximum, or none if the run succeeds. The findings
+// marked as suppressed never count.
+func (p FailPolicy) Evaluate(issues []*issue.Issue) ([]FailingFindings, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	severity, confidence, _ := p.thresholds()
+
+	counts := make(map[string]int)
+	for _, iss := range issues {
+		if len(iss.Suppressions) > 0 {
+			continue
+		}
+		rule := p.Rules[iss.RuleID]
+		switch {
+		case rule.Action == FailActionIgnore:
+			continue
+		case rule.Action != FailActionFail && (iss.Severity < severity || iss.Confidence < confidence):
+			continue
+		}
+		if p.countedApart(rule) {
+			counts[iss.RuleID]++
+		} else {
+			counts[""]++
+		}
+	}
+
+	var failing []FailingFindings
+	for ruleID, count := range counts {
+		maxFindings := p.MaxFindings
+		if ruleID != "" {
+			maxFindings = p.Rules[ruleID].MaxFindings
+		}
+		if count > maxFindings {
+			failing = append(failing, FailingFindings{RuleID: ruleID, Count: count, MaxFindings: maxFindings})
+		}
+	}
+	sort.Slice(failing, func(i, j int) bool {
+		return failing[i].RuleID < failing[j].RuleID
+	})
+	return failing, nil
+}
+
+func (p FailPolicy) countedApart(rule RuleFailPolicy) bool {
+	return rule.Action == FailActionFail || rule.MaxFindings > 0
+}
+
+// thresholds returns the minimum severity and confidence of the findings
+// failing the run, low by default.
+func (p FailPolicy) thresholds() (issue.Score, issue.Score, error) {
+	severity, confidence := issue.Low, issue.Low
+	var err error
+	if p.Severity != "" {
+		if severity, err = issue.ParseScore(p.Severity); err != nil {
+			return severity, confidence, fmt.Errorf("%s: severity: %w", FailPolicyKey, err)
+		}
+	}
+	if p.Confidence != "" {
+		if confidence, err = issue.ParseConfidence(p.Confidence); err != nil {
+			return severity, confidence, fmt.Errorf("%s: confidence: %w", FailPolicyKey, err)
+		}
+	}
+	return severity, confidence, nil
+}
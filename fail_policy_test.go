@@ -0,0 +1,95 @@
+package gosec_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+)
+
+var _ = Describe("FailPolicy", func() {
+	newIssue := func(ruleID string, severity, confidence issue.Score) *issue.Issue {
+		return &issue.Issue{RuleID: ruleID, Severity: severity, Confidence: confidence}
+	}
+
+	It("should fail on every finding by default", func() {
+		failing, err := gosec.FailPolicy{}.Evaluate([]*issue.Issue{newIssue("G104", issue.Low, issue.Low)})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(failing).To(Equal([]gosec.FailingFindings{{Count: 1}}))
+
+		failing, err = gosec.FailPolicy{}.Evaluate(nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(failing).To(BeEmpty())
+	})
+
+	It("should not count the findings below the thresholds or suppressed", func() {
+		suppressed := newIssue("G401", issue.Critical, issue.High)
+		suppressed.Suppressions = []issue.SuppressionInfo{{Kind: "inSource"}}
+		issues := []*issue.Issue{
+			newIssue("G104", issue.Medium, issue.High),
+			newIssue("G401", issue.High, issue.Low),
+			suppressed,
+		}
+
+		failing, err := gosec.FailPolicy{Severity: "high"}.Evaluate(issues)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(failing).To(Equal([]gosec.FailingFindings{{Count: 1}}))
+
+		failing, err = gosec.FailPolicy{Severity: "high", Confidence: "medium"}.Evaluate(issues)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(failing).To(BeEmpty())
+	})
+
+	It("should fail only when there are more findings than tolerated", func() {
+		issues := []*issue.Issue{
+			newIssue("G104", issue.Low, issue.High),
+			newIssue("G104", issue.Low, issue.High),
+			newIssue("G304", issue.Medium, issue.High),
+			newIssue("G304", issue.Medium, issue.High),
+			newIssue("G304", issue.Medium, issue.High),
+		}
+		policy := gosec.FailPolicy{
+			MaxFindings: 2,
+			Rules:       map[string]gosec.RuleFailPolicy{"G304": {MaxFindings: 3}},
+		}
+
+		failing, err := policy.Evaluate(issues)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(failing).To(BeEmpty())
+
+		issues = append(issues, newIssue("G304", issue.Medium, issue.High), newIssue("G401", issue.Medium, issue.High))
+		failing, err = policy.Evaluate(issues)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(failing).To(Equal([]gosec.FailingFindings{
+			{Count: 3, MaxFindings: 2},
+			{RuleID: "G304", Count: 4, MaxFindings: 3},
+		}))
+	})
+
+	It("should apply the actions of the rules", func() {
+		issues := []*issue.Issue{
+			newIssue("G104", issue.Critical, issue.High),
+			newIssue("G404", issue.Low, issue.Low),
+		}
+		policy := gosec.FailPolicy{
+			Severity: "high",
+			Rules: map[string]gosec.RuleFailPolicy{
+				"G104": {Action: gosec.FailActionIgnore},
+				"G404": {Action: gosec.FailActionFail},
+			},
+		}
+
+		failing, err := policy.Evaluate(issues)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(failing).To(Equal([]gosec.FailingFindings{{RuleID: "G404", Count: 1}}))
+	})
+
+	It("should reject the invalid policies", func() {
+		Expect(gosec.FailPolicy{Severity: "urgent"}.Validate()).To(MatchError(ContainSubstring(`fail-policy: severity: invalid score "urgent"`)))
+		Expect(gosec.FailPolicy{Confidence: "critical"}.Validate()).To(MatchError(ContainSubstring(`fail-policy: confidence: invalid confidence "critical"`)))
+		Expect(gosec.FailPolicy{MaxFindings: -1}.Validate()).To(MatchError("fail-policy: max-findings cannot be negative"))
+		Expect(gosec.FailPolicy{Rules: map[string]gosec.RuleFailPolicy{"G104": {Action: "skip"}}}.Validate()).
+			To(MatchError(`fail-policy: rules: G104: invalid action "skip", expected "fail" or "ignore"`))
+	})
+})
@@ -17,9 +17,12 @@ package goanalysis
 
 import (
 	"fmt"
+	"go/build"
 	"go/token"
 	"io"
 	"log"
+	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -35,12 +38,26 @@ import (
 
 const Doc = `gosec is a static analysis tool that scans Go code for security problems.`
 
-// Analyzer is the standard go/analysis Analyzer for gosec.
+// Analyzer is the standard go/analysis Analyzer for gosec. It has no facts,
+// so the drivers only run it on the analyzed packages, while the function
+// summaries of their dependencies are computed by summaryAnalyzer.
 var Analyzer = &analysis.Analyzer{
 	Name:     "gosec",
 	Doc:      Doc,
 	Run:      run,
-	Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	Requires: []*analysis.Analyzer{buildssa.Analyzer, summaryAnalyzer},
+}
+
+// summaryAnalyzer exports the function summaries of every package, including
+// the dependencies, without looking for issues. Its result gives Analyzer the
+// summaries of the imported packages.
+var summaryAnalyzer = &analysis.Analyzer{
+	Name:       "gosecsummary",
+	Doc:        "computes the function summaries by which gosec follows the taint flows across packages",
+	Run:        runSummary,
+	Requires:   []*analysis.Analyzer{buildssa.Analyzer},
+	FactTypes:  []analysis.Fact{new(objectFacts)},
+	ResultType: reflect.TypeOf((*passFactStore)(nil)),
 }
 
 var (
@@ -56,15 +73,17 @@ func init() {
 	Analyzer.Flags.StringVar(&flagIncludeRules, "include", "", "Comma-separated list of rule IDs to include (e.g., G101,G102)")
 	Analyzer.Flags.StringVar(&flagExcludeRules, "exclude", "", "Comma-separated list of rule IDs to exclude (e.g., G104)")
 	Analyzer.Flags.BoolVar(&flagExcludeGenerated, "exclude-generated", true, "Exclude generated code from analysis")
-	Analyzer.Flags.StringVar(&flagMinSeverity, "severity", "low", "Minimum severity: low, medium, or high")
+	Analyzer.Flags.StringVar(&flagMinSeverity, "severity", "low", "Minimum severity: low, medium, high, or critical")
 	Analyzer.Flags.StringVar(&flagMinConfidence, "confidence", "low", "Minimum confidence: low, medium, or high")
 }
 
-func run(pass *analysis.Pass) (any, error) {
-	// Create gosec config and analyzer
+// newGosecAnalyzer creates a gosec analyzer with the rules and the analyzers
+// selected by the flags.
+func newGosecAnalyzer(facts gosec.FactStore) *gosec.Analyzer {
 	config := gosec.NewConfig()
 	logger := log.New(io.Discard, "", 0) // Discard gosec's verbose logging
 	gosecAnalyzer := gosec.NewAnalyzer(config, false, flagExcludeGenerated, false, 1, logger)
+	gosecAnalyzer.SetFactStore(facts)
 
 	// Build filters from include/exclude flags
 	ruleFilters := buildFilters(flagIncludeRules, flagExcludeRules, rules.NewRuleFilter)
@@ -78,6 +97,32 @@ func run(pass *analysis.Pass) (any, error) {
 	analyzerList := analyzers.Generate(false, analyzerFilters...)
 	analyzerDefs, analyzerSuppressed := analyzerList.AnalyzersInfo()
 	gosecAnalyzer.LoadAnalyzers(analyzerDefs, analyzerSuppressed)
+	return gosecAnalyzer
+}
+
+func runSummary(pass *analysis.Pass) (any, error) {
+	// The driver runs the summaryAnalyzer on every dependency, because it
+	// exports facts. The standard library is not summarized.
+	facts := newPassFactStore(pass)
+	if isStandardLibrary(pass) {
+		return facts, nil
+	}
+	if ssaResult, ok := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA); ok && ssaResult != nil {
+		newGosecAnalyzer(facts).ExportFactsWithSSA(convertPassToPackage(pass), ssaResult)
+	}
+	facts.flush()
+	return facts, nil
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if isStandardLibrary(pass) {
+		return nil, nil
+	}
+
+	// The summaries of the imported packages are read from the facts of the
+	// summaryAnalyzer, which exports the summaries of the package as well.
+	facts := importedFactStore{pass.ResultOf[summaryAnalyzer].(*passFactStore)}
+	gosecAnalyzer := newGosecAnalyzer(facts)
 
 	// Convert analysis.Pass to packages.Package
 	pkg := convertPassToPackage(pass)
@@ -97,13 +142,9 @@ func run(pass *analysis.Pass) (any, error) {
 	issues, _, _ := gosecAnalyzer.Report()
 
 	// Report issues as diagnostics, filtering by severity and confidence
-	minSev, err := parseScore(flagMinSeverity)
+	minSev, minConf, err := minScores(flagMinSeverity, flagMinConfidence)
 	if err != nil {
-		return nil, fmt.Errorf("invalid severity %q: %w", flagMinSeverity, err)
-	}
-	minConf, err := parseScore(flagMinConfidence)
-	if err != nil {
-		return nil, fmt.Errorf("invalid confidence %q: %w", flagMinConfidence, err)
+		return nil, err
 	}
 
 	for _, iss := range issues {
@@ -133,6 +174,16 @@ func run(pass *analysis.Pass) (any, error) {
 	return nil, nil
 }
 
+// isStandardLibrary reports whether the pass analyzes a package of the
+// standard library.
+func isStandardLibrary(pass *analysis.Pass) bool {
+	if len(pass.Files) == 0 {
+		return false
+	}
+	goroot := filepath.Join(build.Default.GOROOT, "src") + string(filepath.Separator)
+	return strings.HasPrefix(pass.Fset.File(pass.Files[0].Pos()).Name(), goroot)
+}
+
 // convertPassToPackage converts an analysis.Pass to a packages.Package
 // that gosec expects. This allows us to reuse gosec's existing analysis logic.
 func convertPassToPackage(pass *analysis.Pass) *packages.Package {
@@ -182,18 +233,17 @@ func parseRuleIDs(s string) []string {
 	return ids
 }
 
-// parseScore converts a severity/confidence string to issue.Score
-func parseScore(s string) (issue.Score, error) {
-	switch strings.ToLower(s) {
-	case "high":
-		return issue.High, nil
-	case "medium":
-		return issue.Medium, nil
-	case "low":
-		return issue.Low, nil
-	default:
-		return issue.Low, fmt.Errorf("must be low, medium, or high")
+// minScores parses the minimum severity and confidence of the reported issues
+func minScores(severity, confidence string) (issue.Score, issue.Score, error) {
+	minSev, err := issue.ParseScore(severity)
+	if err != nil {
+		return issue.Low, issue.Low, fmt.Errorf("severity: %w", err)
+	}
+	minConf, err := issue.ParseConfidence(confidence)
+	if err != nil {
+		return issue.Low, issue.Low, fmt.Errorf("confidence: %w", err)
 	}
+	return minSev, minConf, nil
 }
 
 // parsePosition converts a gosec issue location to a token.Pos
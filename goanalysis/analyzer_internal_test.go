@@ -26,6 +26,28 @@ import (
 	"github.com/securego/gosec/v2/issue"
 )
 
+func TestAnalyzerLeavesTheDependenciesToTheSummaryAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	// The drivers run the analyzers with facts on the dependencies too
+	if len(Analyzer.FactTypes) != 0 {
+		t.Fatalf("unexpected facts of the gosec analyzer: %v", Analyzer.FactTypes)
+	}
+	if len(summaryAnalyzer.FactTypes) == 0 {
+		t.Fatalf("expected facts of the summary analyzer")
+	}
+	requiresSummaries := false
+	for _, required := range Analyzer.Requires {
+		requiresSummaries = requiresSummaries || required == summaryAnalyzer
+	}
+	if !requiresSummaries {
+		t.Fatalf("expected the gosec analyzer to require the summary analyzer")
+	}
+	if err := analysis.Validate([]*analysis.Analyzer{Analyzer}); err != nil {
+		t.Fatalf("invalid analyzers: %v", err)
+	}
+}
+
 func TestBuildFilters(t *testing.T) {
 	t.Parallel()
 
@@ -61,33 +83,36 @@ func TestParseRuleIDs(t *testing.T) {
 	}
 }
 
-func TestParseScore(t *testing.T) {
+func TestMinScores(t *testing.T) {
 	t.Parallel()
 
 	cases := []struct {
-		in   string
-		want issue.Score
+		severity, confidence string
+		wantSev, wantConf    issue.Score
 	}{
-		{in: "low", want: issue.Low},
-		{in: "Medium", want: issue.Medium},
-		{in: "HIGH", want: issue.High},
+		{severity: "low", confidence: "low", wantSev: issue.Low, wantConf: issue.Low},
+		{severity: "Medium", confidence: "HIGH", wantSev: issue.Medium, wantConf: issue.High},
+		{severity: "critical", confidence: "medium", wantSev: issue.Critical, wantConf: issue.Medium},
 	}
 
 	for _, tc := range cases {
-		t.Run(tc.in, func(t *testing.T) {
+		t.Run(tc.severity+"/"+tc.confidence, func(t *testing.T) {
 			t.Parallel()
-			got, err := parseScore(tc.in)
+			sev, conf, err := minScores(tc.severity, tc.confidence)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
-			if got != tc.want {
-				t.Fatalf("unexpected score: got %v want %v", got, tc.want)
+			if sev != tc.wantSev || conf != tc.wantConf {
+				t.Fatalf("unexpected scores: got %v/%v want %v/%v", sev, conf, tc.wantSev, tc.wantConf)
 			}
 		})
 	}
 
-	if _, err := parseScore("critical"); err == nil {
-		t.Fatalf("expected error for invalid score")
+	if _, _, err := minScores("urgent", "low"); err == nil {
+		t.Fatalf("expected error for invalid severity")
+	}
+	if _, _, err := minScores("low", "critical"); err == nil {
+		t.Fatalf("expected error for critical confidence")
 	}
 }
 
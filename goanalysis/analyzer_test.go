@@ -25,3 +25,7 @@ import (
 func TestAnalyzer(t *testing.T) {
 	analysistest.Run(t, analysistest.TestData(), goanalysis.Analyzer, "a")
 }
+
+func TestAnalyzerFollowsTaintAcrossPackages(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), goanalysis.Analyzer, "crosspkg/app")
+}
@@ -0,0 +1,102 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goanalysis
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"go/types"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// objectFacts holds the facts exported on an object by the gosec analyzers.
+// The driver only knows about the facts of the gosec Analyzer, so the facts of
+// the analyzers it runs are encoded and packed into this single fact.
+type objectFacts struct {
+	Facts map[string][]byte
+}
+
+// AFact marks objectFacts as an analysis.Fact.
+func (*objectFacts) AFact() {}
+
+func (f *objectFacts) String() string {
+	return fmt.Sprintf("gosec(%d facts)", len(f.Facts))
+}
+
+// factName identifies a fact of an analyzer within objectFacts.
+func factName(analyzer *analysis.Analyzer, fact analysis.Fact) string {
+	return fmt.Sprintf("%s:%T", analyzer.Name, fact)
+}
+
+// passFactStore is a gosec.FactStore backed by the facts of an analysis pass.
+// Exported facts are buffered until flush, because the pass only accepts a
+// single fact of a type per object.
+type passFactStore struct {
+	pass     *analysis.Pass
+	mu       sync.Mutex
+	exported map[types.Object]*objectFacts
+}
+
+func newPassFactStore(pass *analysis.Pass) *passFactStore {
+	return &passFactStore{pass: pass, exported: make(map[types.Object]*objectFacts)}
+}
+
+func (s *passFactStore) ImportObjectFact(analyzer *analysis.Analyzer, obj types.Object, fact analysis.Fact) bool {
+	packed := new(objectFacts)
+	if !s.pass.ImportObjectFact(obj, packed) {
+		return false
+	}
+	data, ok := packed.Facts[factName(analyzer, fact)]
+	if !ok {
+		return false
+	}
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(fact) == nil
+}
+
+func (s *passFactStore) ExportObjectFact(analyzer *analysis.Analyzer, obj types.Object, fact analysis.Fact) {
+	var data bytes.Buffer
+	if err := gob.NewEncoder(&data).Encode(fact); err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	packed, ok := s.exported[obj]
+	if !ok {
+		packed = &objectFacts{Facts: make(map[string][]byte)}
+		s.exported[obj] = packed
+	}
+	packed.Facts[factName(analyzer, fact)] = data.Bytes()
+}
+
+// flush exports the buffered facts to the pass.
+func (s *passFactStore) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for obj, packed := range s.exported {
+		s.pass.ExportObjectFact(obj, packed)
+	}
+}
+
+// importedFactStore is a gosec.FactStore reading the facts of the imported
+// packages from the summaryAnalyzer. The facts exported by the analyzers are
+// dropped, since the summaryAnalyzer exports them already.
+type importedFactStore struct {
+	*passFactStore
+}
+
+func (importedFactStore) ExportObjectFact(*analysis.Analyzer, types.Object, analysis.Fact) {}
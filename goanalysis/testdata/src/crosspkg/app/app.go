@@ -0,0 +1,19 @@
+package app
+
+import (
+	"log"
+	"net/http"
+
+	"crosspkg/lib"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	lib.Audit(name)              // want `G706`
+	log.Println(lib.Label(name)) // want `G706`
+	lib.Audit("constant")
+}
+
+func init() {
+	http.HandleFunc("/", handler)
+}
@@ -0,0 +1,13 @@
+package lib
+
+import "log"
+
+// Audit logs the given message as is.
+func Audit(msg string) {
+	log.Println("audit:", msg)
+}
+
+// Label returns the message with a prefix.
+func Label(msg string) string {
+	return "label: " + msg
+}
@@ -0,0 +1,21 @@
+// Package pathutil provides shared path utilities for the gosec reports.
+package pathutil
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// RelativeFilePath returns the slash-separated path of the file relative to
+// the first root path containing it, or the path of the file when it is
+// outside of the root paths.
+func RelativeFilePath(file string, rootPaths []string) string {
+	for _, rootPath := range rootPaths {
+		rel, err := filepath.Rel(rootPath, file)
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		return filepath.ToSlash(rel)
+	}
+	return file
+}
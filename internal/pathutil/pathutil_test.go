@@ -0,0 +1,32 @@
+package pathutil_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/securego/gosec/v2/internal/pathutil"
+)
+
+func TestRelativeFilePath(t *testing.T) {
+	root := filepath.FromSlash("/home/src/project")
+	tests := []struct {
+		name      string
+		file      string
+		rootPaths []string
+		want      string
+	}{
+		{"inside the root path", filepath.Join(root, "pkg", "main.go"), []string{root}, "pkg/main.go"},
+		{"root path with a trailing separator", filepath.Join(root, "main.go"), []string{root + string(filepath.Separator)}, "main.go"},
+		{"first containing root path", filepath.Join(root, "pkg", "main.go"), []string{filepath.Join(root, "other"), root}, "pkg/main.go"},
+		{"sibling with the same prefix", filepath.FromSlash("/home/src/project2/main.go"), []string{root}, filepath.FromSlash("/home/src/project2/main.go")},
+		{"outside of the root paths", filepath.FromSlash("/tmp/main.go"), []string{root}, filepath.FromSlash("/tmp/main.go")},
+		{"no root paths", filepath.FromSlash("/tmp/main.go"), nil, filepath.FromSlash("/tmp/main.go")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pathutil.RelativeFilePath(tt.file, tt.rootPaths); got != tt.want {
+				t.Errorf("RelativeFilePath(%q, %q) = %q, want %q", tt.file, tt.rootPaths, got, tt.want)
+			}
+		})
+	}
+}
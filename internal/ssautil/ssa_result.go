@@ -4,9 +4,11 @@ package ssautil
 import (
 	"errors"
 	"log"
+	"sync"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
 )
 
 var (
@@ -17,10 +19,69 @@ var (
 // SSAAnalyzerResult contains various information returned by the
 // SSA analysis along with some configuration
 type SSAAnalyzerResult struct {
-	Config map[string]any
-	Logger *log.Logger
-	SSA    *buildssa.SSA
-	Shared *PackageAnalysisCache
+	Config      map[string]any
+	Logger      *log.Logger
+	SSA         *buildssa.SSA
+	Shared      *PackageAnalysisCache
+	Truncations *TruncationCounter
+	// FactsOnly is set when the analyzers only export the facts of the
+	// package, such as the taint summaries, without looking for issues.
+	FactsOnly bool
+	// ExportFacts is set when the facts of the package are wanted by the
+	// packages importing it. The analyzers compute no facts otherwise.
+	ExportFacts bool
+}
+
+// TruncationLimit is the limit at which the analysis of a function stopped.
+// The later limits take precedence when several are hit.
+type TruncationLimit int
+
+const (
+	// TruncatedDepth is the maximum recursion depth
+	TruncatedDepth TruncationLimit = iota + 1
+	// TruncatedVisited is the maximum number of visited values
+	TruncatedVisited
+	// TruncatedBudget is the time budget of the function
+	TruncatedBudget
+)
+
+// TruncationCounter collects the functions whose analysis stopped at a limit
+// across the analyzers run on a package. A function truncated by several
+// analyzers is counted once.
+type TruncationCounter struct {
+	mu        sync.Mutex
+	functions map[*ssa.Function]TruncationLimit
+}
+
+// Add records a function truncated by an analyzer. It is a no-op on a nil
+// counter.
+func (c *TruncationCounter) Add(fn *ssa.Function, limit TruncationLimit) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.functions == nil {
+		c.functions = make(map[*ssa.Function]TruncationLimit)
+	}
+	c.functions[fn] = max(c.functions[fn], limit)
+}
+
+// Counts returns the number of truncated functions by limit.
+func (c *TruncationCounter) Counts() (depth, visited, budget int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, limit := range c.functions {
+		switch limit {
+		case TruncatedDepth:
+			depth++
+		case TruncatedVisited:
+			visited++
+		case TruncatedBudget:
+			budget++
+		}
+	}
+	return depth, visited, budget
 }
 
 // GetSSAResult retrieves the SSA result from analysis pass
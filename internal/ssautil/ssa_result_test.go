@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
 
 	"github.com/securego/gosec/v2/internal/ssautil"
 )
@@ -102,6 +103,26 @@ var _ = Describe("SSA Result Utilities", func() {
 		})
 	})
 
+	Context("TruncationCounter", func() {
+		It("should count each truncated function once, by its latest limit", func() {
+			first, second := &ssa.Function{}, &ssa.Function{}
+			counter := &ssautil.TruncationCounter{}
+			counter.Add(first, ssautil.TruncatedDepth)
+			counter.Add(first, ssautil.TruncatedBudget)
+			counter.Add(first, ssautil.TruncatedVisited)
+			counter.Add(second, ssautil.TruncatedDepth)
+			counter.Add(second, ssautil.TruncatedDepth)
+
+			depth, visited, budget := counter.Counts()
+			Expect([]int{depth, visited, budget}).To(Equal([]int{1, 0, 1}))
+		})
+
+		It("should ignore the functions added to a nil counter", func() {
+			var counter *ssautil.TruncationCounter
+			Expect(func() { counter.Add(&ssa.Function{}, ssautil.TruncatedDepth) }).NotTo(Panic())
+		})
+	})
+
 	Context("Error types", func() {
 		It("should have proper error messages", func() {
 			Expect(ssautil.ErrNoSSAResult.Error()).To(Equal("no SSA result found in the analysis pass"))
@@ -0,0 +1,257 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// FingerprintParts are the properties of an issue its fingerprint is built
+// from. They do not include the line of the issue, so that the fingerprint
+// survives the edits of the code around it.
+type FingerprintParts struct {
+	RuleID string
+	// File is the path of the file qualified by the path of its module, such
+	// as "example.com/project/internal/db/db.go", or the path relative to the
+	// current directory outside of a module.
+	File string
+	// Function is the name of the function enclosing the issue, such as
+	// "Handler" or "(*Server).Handler", or "" at package level.
+	Function string
+	// Snippet is the code of the lines of the issue, with its spacing
+	// normalized.
+	Snippet string
+	// Occurrence tells apart the issues having the same other parts, e.g. the
+	// same call repeated in a function. It is their index in order of
+	// position.
+	Occurrence int
+}
+
+// Fingerprint returns the fingerprint of the issue with these properties.
+func (p FingerprintParts) Fingerprint() string {
+	hash := sha256.New()
+	for _, part := range []string{p.RuleID, p.File, p.Function, p.Snippet, strconv.Itoa(p.Occurrence)} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:16])
+}
+
+// Fingerprinter computes the fingerprints of the issues. It parses each
+// file once to find the functions enclosing the issues.
+type Fingerprinter struct {
+	modules   map[string]moduleInfo
+	functions map[string][]functionRange
+}
+
+// moduleInfo locates the module of a directory.
+type moduleInfo struct {
+	root, path string
+}
+
+// functionRange is the range of lines of a function declaration.
+type functionRange struct {
+	name       string
+	start, end int
+}
+
+// NewFingerprinter returns a new Fingerprinter.
+func NewFingerprinter() *Fingerprinter {
+	return &Fingerprinter{
+		modules:   make(map[string]moduleInfo),
+		functions: make(map[string][]functionRange),
+	}
+}
+
+// Parts returns the properties of the issue its fingerprint is built from,
+// with an occurrence index of 0.
+func (f *Fingerprinter) Parts(i *Issue) FingerprintParts {
+	start, end := issueLines(i.Line)
+	return FingerprintParts{
+		RuleID:   i.RuleID,
+		File:     f.qualifiedPath(i.File),
+		Function: f.enclosingFunction(i.File, start),
+		Snippet:  normalizeSnippet(i.Code, start, end),
+	}
+}
+
+// SetFingerprints sets the fingerprints of the issues. The issues having the
+// same parts are numbered in order of position, hence the fingerprints do not
+// depend on the order of the issues.
+func (f *Fingerprinter) SetFingerprints(issues []*Issue) {
+	groups := make(map[FingerprintParts][]*Issue)
+	for _, i := range issues {
+		parts := f.Parts(i)
+		groups[parts] = append(groups[parts], i)
+	}
+	for parts, group := range groups {
+		sort.SliceStable(group, func(a, b int) bool {
+			lineA, _ := issueLines(group[a].Line)
+			lineB, _ := issueLines(group[b].Line)
+			if lineA != lineB {
+				return lineA < lineB
+			}
+			colA, _ := strconv.Atoi(group[a].Col)
+			colB, _ := strconv.Atoi(group[b].Col)
+			return colA < colB
+		})
+		for index, i := range group {
+			parts.Occurrence = index
+			i.Fingerprint = parts.Fingerprint()
+		}
+	}
+}
+
+// qualifiedPath returns the path of a file qualified by the path of its
+// module, or relative to the current directory outside of a module.
+func (f *Fingerprinter) qualifiedPath(file string) string {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	if module := f.module(filepath.Dir(abs)); module.path != "" {
+		if rel, err := filepath.Rel(module.root, abs); err == nil {
+			return path.Join(module.path, filepath.ToSlash(rel))
+		}
+	}
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, abs); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(abs)
+}
+
+// module finds the module enclosing a directory.
+func (f *Fingerprinter) module(dir string) moduleInfo {
+	if module, ok := f.modules[dir]; ok {
+		return module
+	}
+	var module moduleInfo
+	// #nosec G304 -- the go.mod files of the scanned code
+	if data, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil {
+		module = moduleInfo{root: dir, path: modfile.ModulePath(data)}
+	} else if parent := filepath.Dir(dir); parent != dir {
+		module = f.module(parent)
+	}
+	f.modules[dir] = module
+	return module
+}
+
+func (f *Fingerprinter) enclosingFunction(file string, line int) string {
+	functions, ok := f.functions[file]
+	if !ok {
+		functions = parseFunctions(file)
+		f.functions[file] = functions
+	}
+	for _, function := range functions {
+		if function.start <= line && line <= function.end {
+			return function.name
+		}
+	}
+	return ""
+}
+
+// parseFunctions returns the line ranges of the function declarations of a
+// Go file, or none if it cannot be parsed.
+func parseFunctions(file string) []functionRange {
+	fset := token.NewFileSet()
+	root, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil
+	}
+	var functions []functionRange
+	for _, decl := range root.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			functions = append(functions, functionRange{
+				name:  functionName(fn),
+				start: fset.Position(fn.Pos()).Line,
+				end:   fset.Position(fn.End()).Line,
+			})
+		}
+	}
+	return functions
+}
+
+// functionName returns the name of a function, qualified by the type of its
+// receiver for the methods, e.g. "(*Server).Handler".
+func functionName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	recv := fn.Recv.List[0].Type
+	pointer := false
+	if star, ok := recv.(*ast.StarExpr); ok {
+		pointer = true
+		recv = star.X
+	}
+	switch t := recv.(type) {
+	case *ast.IndexExpr:
+		recv = t.X
+	case *ast.IndexListExpr:
+		recv = t.X
+	}
+	name := "?"
+	if ident, ok := recv.(*ast.Ident); ok {
+		name = ident.Name
+	}
+	if pointer {
+		name = "*" + name
+	}
+	return "(" + name + ")." + fn.Name.Name
+}
+
+// issueLines parses the line of an issue, such as "12" or "12-14".
+func issueLines(line string) (int, int) {
+	first, last, found := strings.Cut(line, "-")
+	start, _ := strconv.Atoi(first)
+	end := start
+	if found {
+		end, _ = strconv.Atoi(last)
+	}
+	return start, end
+}
+
+// normalizeSnippet keeps the lines of the code snippet of an issue between
+// start and end, without their numbers, and collapses their spacing.
+func normalizeSnippet(code string, start, end int) string {
+	var lines []string
+	for _, line := range strings.Split(code, "\n") {
+		number, text, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil || n < start || n > end {
+			continue
+		}
+		if text = strings.Join(strings.Fields(text), " "); text != "" {
+			lines = append(lines, text)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
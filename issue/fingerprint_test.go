@@ -0,0 +1,92 @@
+package issue_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2/issue"
+)
+
+var _ = Describe("Fingerprinter", func() {
+	var dir string
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o750)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("should build the fingerprint from the rule, file, function and code", func() {
+		write("go.mod", "module example.com/project\n")
+		file := write("pkg/server.go", "package pkg\n\ntype Server struct{}\n\nfunc (s *Server) Handle() {\n\texec(  \"ls\" )\n}\n")
+		iss := &issue.Issue{RuleID: "G204", File: file, Line: "6", Code: "5: func (s *Server) Handle() {\n6: \texec(  \"ls\" )\n7: }\n"}
+
+		parts := issue.NewFingerprinter().Parts(iss)
+		Expect(parts).To(Equal(issue.FingerprintParts{
+			RuleID:   "G204",
+			File:     "example.com/project/pkg/server.go",
+			Function: "(*Server).Handle",
+			Snippet:  `exec( "ls" )`,
+		}))
+		Expect(parts.Fingerprint()).To(HaveLen(32))
+	})
+
+	It("should not depend on the line of the issue", func() {
+		before := write("a/main.go", "package main\n\nfunc main() {\n\tcall()\n}\n")
+		after := write("b/main.go", "package main\n\n// main runs.\nfunc main() {\n\n\tcall()\n}\n")
+		fingerprinter := issue.NewFingerprinter()
+
+		first := fingerprinter.Parts(&issue.Issue{RuleID: "G104", File: before, Line: "4", Code: "3: func main() {\n4: \tcall()\n5: }\n"})
+		second := fingerprinter.Parts(&issue.Issue{RuleID: "G104", File: after, Line: "6", Code: "5: \n6: \t\tcall()\n7: }\n"})
+		Expect(first.Function).To(Equal(second.Function))
+		Expect(first.Snippet).To(Equal(second.Snippet))
+		second.File = first.File
+		Expect(second.Fingerprint()).To(Equal(first.Fingerprint()))
+	})
+
+	It("should tell the issues of different functions apart", func() {
+		file := write("main.go", "package main\n\nfunc a() {\n\tcall()\n}\n\nfunc b() {\n\tcall()\n}\n")
+		issues := []*issue.Issue{
+			{RuleID: "G104", File: file, Line: "4", Code: "4: \tcall()\n"},
+			{RuleID: "G104", File: file, Line: "8", Code: "8: \tcall()\n"},
+		}
+
+		issue.NewFingerprinter().SetFingerprints(issues)
+		Expect(issues[0].Fingerprint).NotTo(BeEmpty())
+		Expect(issues[0].Fingerprint).NotTo(Equal(issues[1].Fingerprint))
+	})
+
+	It("should number the identical issues in order of position", func() {
+		file := write("main.go", "package main\n\nfunc main() {\n\tcall()\n\tcall()\n}\n")
+		first := &issue.Issue{RuleID: "G104", File: file, Line: "4", Col: "2", Code: "4: \tcall()\n"}
+		second := &issue.Issue{RuleID: "G104", File: file, Line: "5", Col: "2", Code: "5: \tcall()\n"}
+		issue.NewFingerprinter().SetFingerprints([]*issue.Issue{second, first})
+		Expect(first.Fingerprint).NotTo(Equal(second.Fingerprint))
+
+		// A line inserted above does not change the fingerprints.
+		moved := []*issue.Issue{
+			{RuleID: "G104", File: file, Line: "6", Col: "2", Code: "6: \tcall()\n"},
+			{RuleID: "G104", File: file, Line: "5", Col: "2", Code: "5: \tcall()\n"},
+		}
+		write("main.go", "package main\n\nfunc main() {\n\tprintln()\n\tcall()\n\tcall()\n}\n")
+		issue.NewFingerprinter().SetFingerprints(moved)
+		Expect(moved[1].Fingerprint).To(Equal(first.Fingerprint))
+		Expect(moved[0].Fingerprint).To(Equal(second.Fingerprint))
+	})
+
+	It("should keep the package level issues and the files outside the module and the current directory", func() {
+		file := write("main.go", "package main\n\nvar password = \"secret\"\n")
+
+		parts := issue.NewFingerprinter().Parts(&issue.Issue{RuleID: "G101", File: file, Line: "3", Code: "3: var password = \"secret\"\n"})
+		Expect(parts.Function).To(BeEmpty())
+		Expect(parts.File).To(Equal(filepath.ToSlash(file)))
+	})
+})
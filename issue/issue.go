@@ -23,6 +23,7 @@ import (
 	"go/token"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/securego/gosec/v2/cwe"
 )
@@ -37,6 +38,8 @@ const (
 	Medium
 	// High severity or confidence
 	High
+	// Critical severity, above High. It is not a confidence level.
+	Critical
 )
 
 // SnippetOffset defines the number of lines captured before
@@ -121,18 +124,65 @@ var ruleToCWE = map[string]string{
 
 // Issue is returned by a gosec rule if it discovers an issue with the scanned code.
 type Issue struct {
-	Severity     Score             `json:"severity"`          // issue severity (how problematic it is)
-	Confidence   Score             `json:"confidence"`        // issue confidence (how sure we are we found it)
-	Cwe          *cwe.Weakness     `json:"cwe"`               // Cwe associated with RuleID
-	RuleID       string            `json:"rule_id"`           // Human readable explanation
-	What         string            `json:"details"`           // Human readable explanation
-	File         string            `json:"file"`              // File name we found it in
-	Code         string            `json:"code"`              // Impacted code line
-	Line         string            `json:"line"`              // Line number in file
-	Col          string            `json:"column"`            // Column number in line
-	NoSec        bool              `json:"nosec"`             // true if the issue is nosec
-	Suppressions []SuppressionInfo `json:"suppressions"`      // Suppression info of the issue
-	Autofix      string            `json:"autofix,omitempty"` // Proposed auto fix the issue
+	Severity     Score             `json:"severity"`              // issue severity (how problematic it is)
+	Confidence   Score             `json:"confidence"`            // issue confidence (how sure we are we found it)
+	Cwe          *cwe.Weakness     `json:"cwe"`                   // Cwe associated with RuleID
+	RuleID       string            `json:"rule_id"`               // Human readable explanation
+	What         string            `json:"details"`               // Human readable explanation
+	File         string            `json:"file"`                  // File name we found it in
+	Code         string            `json:"code"`                  // Impacted code line
+	Line         string            `json:"line"`                  // Line number in file
+	Col          string            `json:"column"`                // Column number in line
+	EndCol       string            `json:"end_column,omitempty"`  // Column number after the end of the issue in its last line
+	NoSec        bool              `json:"nosec"`                 // true if the issue is nosec
+	Suppressions []SuppressionInfo `json:"suppressions"`          // Suppression info of the issue
+	Autofix      string            `json:"autofix,omitempty"`     // Proposed auto fix the issue
+	Trace        []TraceStep       `json:"trace,omitempty"`       // Source-to-sink data flow of taint findings
+	Fingerprint  string            `json:"fingerprint,omitempty"` // Stable identity of the issue across the edits of the code
+	Fixes        []Fix             `json:"fixes,omitempty"`       // Changes of the code which solve the issue
+
+	// ExpiredSuppressions lists the nosec directives past their until date,
+	// which no longer suppress the issue.
+	ExpiredSuppressions []SuppressionInfo `json:"expired_suppressions,omitempty"`
+}
+
+// Fix is a change of the file of an issue which solves it.
+type Fix struct {
+	Description  string        `json:"description"`  // What the fix does
+	Replacements []Replacement `json:"replacements"` // Edits of the file, which do not overlap
+}
+
+// Replacement replaces the text between two positions of a file. The lines
+// and the columns start at 1, the columns count bytes and the end position is
+// excluded.
+type Replacement struct {
+	StartLine   int    `json:"start_line"`
+	StartColumn int    `json:"start_column"`
+	EndLine     int    `json:"end_line"`
+	EndColumn   int    `json:"end_column"`
+	Text        string `json:"text"`
+}
+
+// NewReplacement creates a Replacement of the text of an ast.Node
+func NewReplacement(fobj *token.File, node ast.Node, text string) Replacement {
+	start, end := fobj.Position(node.Pos()), fobj.Position(node.End())
+	return Replacement{
+		StartLine:   start.Line,
+		StartColumn: start.Column,
+		EndLine:     end.Line,
+		EndColumn:   end.Column,
+		Text:        text,
+	}
+}
+
+// TraceStep is a step of the data flow which leads tainted data from its
+// source to a sink.
+type TraceStep struct {
+	File        string `json:"file"`        // File name of the step
+	Line        int    `json:"line"`        // Line number in file
+	Column      int    `json:"column"`      // Column number in line
+	Function    string `json:"function"`    // Function in which the step occurs
+	Description string `json:"description"` // What happens to the tainted data
 }
 
 // SuppressionInfo object is to record the kind and the justification that used
@@ -140,6 +190,11 @@ type Issue struct {
 type SuppressionInfo struct {
 	Kind          string `json:"kind"`
 	Justification string `json:"justification"`
+	// Ticket is the reference given with ticket= in the justification.
+	Ticket string `json:"ticket,omitempty"`
+	// Until is the date given with until=, after which the suppression
+	// stops applying.
+	Until string `json:"until,omitempty"`
 }
 
 // FileLocation point out the file path and line number in file
@@ -180,6 +235,8 @@ func (c Score) MarshalJSON() ([]byte, error) {
 // String converts a Score into a string
 func (c Score) String() string {
 	switch c {
+	case Critical:
+		return "CRITICAL"
 	case High:
 		return "HIGH"
 	case Medium:
@@ -190,6 +247,32 @@ func (c Score) String() string {
 	return "UNDEFINED"
 }
 
+// ParseScore converts the name of a score, such as "medium" or "HIGH", into a
+// Score
+func ParseScore(value string) (Score, error) {
+	switch strings.ToLower(value) {
+	case "low":
+		return Low, nil
+	case "medium":
+		return Medium, nil
+	case "high":
+		return High, nil
+	case "critical":
+		return Critical, nil
+	}
+	return Low, fmt.Errorf("invalid score %q, expected low, medium, high or critical", value)
+}
+
+// ParseConfidence converts the name of a confidence level, such as "medium"
+// or "HIGH", into a Score. Unlike ParseScore, it rejects Critical.
+func ParseConfidence(value string) (Score, error) {
+	score, err := ParseScore(value)
+	if err != nil || score == Critical {
+		return Low, fmt.Errorf("invalid confidence %q, expected low, medium or high", value)
+	}
+	return score, nil
+}
+
 // CodeSnippet extracts a code snippet based on the ast reference
 func CodeSnippet(file *os.File, start int64, end int64) (string, error) {
 	var pos int64
@@ -226,6 +309,7 @@ func New(fobj *token.File, node ast.Node, ruleID, desc string, severity, confide
 	name := fobj.Name()
 	var line string
 	var col string
+	var endCol string
 
 	if node == nil {
 		line = "0"
@@ -233,6 +317,7 @@ func New(fobj *token.File, node ast.Node, ruleID, desc string, severity, confide
 	} else {
 		line = GetLine(fobj, node)
 		col = strconv.Itoa(fobj.Position(node.Pos()).Column)
+		endCol = strconv.Itoa(fobj.Position(node.End()).Column)
 	}
 
 	var code string
@@ -253,6 +338,7 @@ func New(fobj *token.File, node ast.Node, ruleID, desc string, severity, confide
 		File:       name,
 		Line:       line,
 		Col:        col,
+		EndCol:     endCol,
 		RuleID:     ruleID,
 		What:       desc,
 		Confidence: confidence,
@@ -268,6 +354,12 @@ func (i *Issue) WithSuppressions(suppressions []SuppressionInfo) *Issue {
 	return i
 }
 
+// WithFixes adds fixes to the issue
+func (i *Issue) WithFixes(fixes ...Fix) *Issue {
+	i.Fixes = append(i.Fixes, fixes...)
+	return i
+}
+
 // GetLine returns the line number of a given ast.Node
 func GetLine(fobj *token.File, node ast.Node) string {
 	start, end := fobj.Line(node.Pos()), fobj.Line(node.End())
@@ -200,6 +200,12 @@ func main() {
 	})
 
 	Describe("Score", func() {
+		It("should convert Critical to string", func() {
+			score := issue.Critical
+			Expect(score.String()).Should(Equal("CRITICAL"))
+			Expect(score).Should(BeNumerically(">", issue.High))
+		})
+
 		It("should convert High to string", func() {
 			score := issue.High
 			Expect(score.String()).Should(Equal("HIGH"))
@@ -215,6 +221,24 @@ func main() {
 			Expect(score.String()).Should(Equal("LOW"))
 		})
 
+		It("should parse the scores", func() {
+			for name, expected := range map[string]issue.Score{"low": issue.Low, "Medium": issue.Medium, "HIGH": issue.High, "critical": issue.Critical} {
+				score, err := issue.ParseScore(name)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(score).Should(Equal(expected))
+			}
+			_, err := issue.ParseScore("urgent")
+			Expect(err).Should(MatchError(`invalid score "urgent", expected low, medium, high or critical`))
+		})
+
+		It("should not parse critical as a confidence", func() {
+			score, err := issue.ParseConfidence("high")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(score).Should(Equal(issue.High))
+			_, err = issue.ParseConfidence("critical")
+			Expect(err).Should(MatchError(`invalid confidence "critical", expected low, medium or high`))
+		})
+
 		It("should convert undefined score to UNDEFINED", func() {
 			score := issue.Score(99)
 			Expect(score.String()).Should(Equal("UNDEFINED"))
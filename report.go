@@ -1,6 +1,8 @@
 package gosec
 
 import (
+	"time"
+
 	"github.com/securego/gosec/v2/issue"
 )
 
@@ -10,6 +12,11 @@ type ReportInfo struct {
 	Issues       []*issue.Issue
 	Stats        *Metrics
 	GosecVersion string
+
+	// StartTime and EndTime bound the scan. They are only used by the formats
+	// which have the scan times, e.g. gitlab-sast.
+	StartTime time.Time `json:"-" yaml:"-"`
+	EndTime   time.Time `json:"-" yaml:"-"`
 }
 
 // NewReportInfo instantiate a ReportInfo
@@ -26,3 +33,10 @@ func (r *ReportInfo) WithVersion(version string) *ReportInfo {
 	r.GosecVersion = version
 	return r
 }
+
+// WithScanTimes defines the start and end times of the scan
+func (r *ReportInfo) WithScanTimes(start, end time.Time) *ReportInfo {
+	r.StartTime = start
+	r.EndTime = end
+	return r
+}
@@ -0,0 +1,65 @@
+package checkstyle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+)
+
+// sourcePrefix qualifies the rule IDs in the source of the errors
+const sourcePrefix = "gosec."
+
+// GenerateReport converts a gosec report into a Checkstyle report. The files
+// are listed in the order of their first issue.
+func GenerateReport(data *gosec.ReportInfo) (*Report, error) {
+	report := &Report{Version: Version}
+	files := make(map[string]*File)
+	for _, issue := range data.Issues {
+		line, err := strconv.Atoi(strings.Split(issue.Line, "-")[0])
+		if err != nil {
+			return nil, err
+		}
+		// The column is left out when it is unknown.
+		column, _ := strconv.Atoi(issue.Col)
+
+		file, ok := files[issue.File]
+		if !ok {
+			file = &File{Name: issue.File}
+			files[issue.File] = file
+			report.Files = append(report.Files, file)
+		}
+		file.Errors = append(file.Errors, &Error{
+			Line:     line,
+			Column:   column,
+			Severity: getSeverity(issue.Severity),
+			Message:  buildMessage(issue),
+			Source:   sourcePrefix + issue.RuleID,
+		})
+	}
+	return report, nil
+}
+
+func buildMessage(i *issue.Issue) string {
+	message := i.What
+	if i.Cwe != nil && i.Cwe.ID != "" {
+		message = fmt.Sprintf("[%s] %s", i.Cwe.SprintID(), i.What)
+	}
+	if i.Autofix != "" {
+		message += "\nAutofix: " + i.Autofix
+	}
+	return message
+}
+
+func getSeverity(score issue.Score) string {
+	switch score {
+	case issue.Critical, issue.High:
+		return "error"
+	case issue.Medium:
+		return "warning"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,30 @@
+package checkstyle
+
+import (
+	"encoding/xml"
+)
+
+// Version is the version of the Checkstyle report format
+const Version = "4.3"
+
+// Report defines a Checkstyle XML report
+type Report struct {
+	XMLName xml.Name `xml:"checkstyle"`
+	Version string   `xml:"version,attr"`
+	Files   []*File  `xml:"file"`
+}
+
+// File defines the errors found in a file
+type File struct {
+	Name   string   `xml:"name,attr"`
+	Errors []*Error `xml:"error"`
+}
+
+// Error defines a Checkstyle error
+type Error struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
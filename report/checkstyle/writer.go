@@ -0,0 +1,25 @@
+package checkstyle
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/securego/gosec/v2"
+)
+
+// WriteReport write a report in Checkstyle XML format to the output writer
+func WriteReport(w io.Writer, data *gosec.ReportInfo) error {
+	report, err := GenerateReport(data)
+	if err != nil {
+		return err
+	}
+	raw, err := xml.MarshalIndent(report, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	xmlHeader := []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	raw = append(xmlHeader, raw...)
+	_, err = w.Write(raw)
+	return err
+}
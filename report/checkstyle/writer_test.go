@@ -0,0 +1,98 @@
+package checkstyle_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+	"github.com/securego/gosec/v2/report/checkstyle"
+)
+
+func TestCheckstyle(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Checkstyle Writer Suite")
+}
+
+var _ = Describe("Checkstyle Writer", func() {
+	var data *gosec.ReportInfo
+
+	BeforeEach(func() {
+		data = &gosec.ReportInfo{
+			Errors: map[string][]gosec.Error{},
+			Issues: []*issue.Issue{
+				{
+					File:       "/home/src/project/main.go",
+					Line:       "11-12",
+					Col:        "14",
+					RuleID:     "G403",
+					What:       "RSA keys should be at least 2048 bits",
+					Confidence: issue.High,
+					Severity:   issue.Medium,
+					Cwe:        issue.GetCweByRule("G403"),
+				},
+				{
+					File:       "/home/src/project/db.go",
+					Line:       "3",
+					Col:        "2",
+					RuleID:     "G202",
+					What:       "SQL string concatenation",
+					Confidence: issue.High,
+					Severity:   issue.Critical,
+					Autofix:    "Use a query parameter",
+				},
+				{
+					File:       "/home/src/project/main.go",
+					Line:       "20",
+					Col:        "",
+					RuleID:     "G104",
+					What:       "Errors unhandled",
+					Confidence: issue.High,
+					Severity:   issue.Low,
+				},
+			},
+			Stats: &gosec.Metrics{},
+		}
+	})
+
+	Context("when converting to Checkstyle errors", func() {
+		It("should group the errors by file", func() {
+			report, err := checkstyle.GenerateReport(data)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(report.Version).To(Equal(checkstyle.Version))
+			Expect(report.Files).To(HaveLen(2))
+
+			Expect(report.Files[0].Name).To(Equal("/home/src/project/main.go"))
+			Expect(report.Files[0].Errors).To(Equal([]*checkstyle.Error{
+				{Line: 11, Column: 14, Severity: "warning", Message: "[CWE-310] RSA keys should be at least 2048 bits", Source: "gosec.G403"},
+				{Line: 20, Severity: "info", Message: "Errors unhandled", Source: "gosec.G104"},
+			}))
+			Expect(report.Files[1].Errors).To(Equal([]*checkstyle.Error{
+				{Line: 3, Column: 2, Severity: "error", Message: "SQL string concatenation\nAutofix: Use a query parameter", Source: "gosec.G202"},
+			}))
+		})
+
+		It("should reject the invalid lines", func() {
+			data.Issues[0].Line = "x"
+			_, err := checkstyle.GenerateReport(data)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("when writing Checkstyle reports", func() {
+		It("should write valid XML", func() {
+			buf := new(bytes.Buffer)
+			Expect(checkstyle.WriteReport(buf, data)).To(Succeed())
+			Expect(buf.String()).To(HavePrefix("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<checkstyle version=\"4.3\">"))
+
+			var report checkstyle.Report
+			Expect(xml.Unmarshal(buf.Bytes(), &report)).To(Succeed())
+			Expect(report.Files).To(HaveLen(2))
+			Expect(report.Files[1].Errors[0].Message).To(ContainSubstring("Autofix"))
+		})
+	})
+})
@@ -0,0 +1,99 @@
+package codeclimate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/cwe"
+	"github.com/securego/gosec/v2/internal/pathutil"
+	"github.com/securego/gosec/v2/issue"
+)
+
+const (
+	issueType        = "issue"
+	securityCategory = "Security"
+)
+
+// GenerateReport converts a gosec report into a list of Code Climate issues
+func GenerateReport(rootPaths []string, data *gosec.ReportInfo) ([]*Issue, error) {
+	issues := []*Issue{}
+	for _, issue := range data.Issues {
+		location, err := parseLocation(issue, rootPaths)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, &Issue{
+			Type:        issueType,
+			CheckName:   issue.RuleID,
+			Description: fmt.Sprintf("[%s] %s", issue.RuleID, issue.What),
+			Content:     buildContent(issue),
+			Categories:  []string{securityCategory},
+			Location:    location,
+			Severity:    getSeverity(issue.Severity),
+			Fingerprint: fingerprint(issue),
+		})
+	}
+	return issues, nil
+}
+
+// buildContent explains the weakness of the issue, if it has one.
+func buildContent(i *issue.Issue) *Content {
+	if i.Cwe == nil {
+		return nil
+	}
+	weakness := cwe.Get(i.Cwe.ID)
+	if weakness == nil {
+		return nil
+	}
+	return &Content{
+		Body: fmt.Sprintf("[%s: %s](%s)\n\n%s", weakness.SprintID(), weakness.Name, weakness.SprintURL(), weakness.Description),
+	}
+}
+
+// fingerprint returns the fingerprint of the issue, or a hash of its location
+// when it has none.
+func fingerprint(i *issue.Issue) string {
+	if i.Fingerprint != "" {
+		return i.Fingerprint
+	}
+	hash := sha256.Sum256([]byte(strings.Join([]string{i.RuleID, i.File, i.Line, i.Col}, ":")))
+	return hex.EncodeToString(hash[:16])
+}
+
+func parseLocation(i *issue.Issue, rootPaths []string) (*Location, error) {
+	lines := strings.Split(i.Line, "-")
+	begin, err := strconv.Atoi(lines[0])
+	if err != nil {
+		return nil, err
+	}
+	end := begin
+	if len(lines) > 1 {
+		end, err = strconv.Atoi(lines[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Location{
+		Path:  pathutil.RelativeFilePath(i.File, rootPaths),
+		Lines: &Lines{Begin: begin, End: end},
+	}, nil
+}
+
+func getSeverity(score issue.Score) string {
+	switch score {
+	case issue.Critical:
+		return "blocker"
+	case issue.High:
+		return "critical"
+	case issue.Medium:
+		return "major"
+	case issue.Low:
+		return "minor"
+	default:
+		return "info"
+	}
+}
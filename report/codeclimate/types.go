@@ -0,0 +1,31 @@
+package codeclimate
+
+// Lines defines the lines of an issue's location
+type Lines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+// Location defines the location of an issue
+type Location struct {
+	Path  string `json:"path"`
+	Lines *Lines `json:"lines"`
+}
+
+// Content defines the explanation of an issue, in markdown
+type Content struct {
+	Body string `json:"body"`
+}
+
+// Issue defines a Code Climate issue, as read by the GitLab Code Quality
+// widget
+type Issue struct {
+	Type        string    `json:"type"`
+	CheckName   string    `json:"check_name"`
+	Description string    `json:"description"`
+	Content     *Content  `json:"content,omitempty"`
+	Categories  []string  `json:"categories"`
+	Location    *Location `json:"location"`
+	Severity    string    `json:"severity"`
+	Fingerprint string    `json:"fingerprint"`
+}
@@ -0,0 +1,95 @@
+package codeclimate_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+	"github.com/securego/gosec/v2/report/codeclimate"
+)
+
+func TestCodeClimate(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Code Climate Writer Suite")
+}
+
+var _ = Describe("Code Climate Writer", func() {
+	var data *gosec.ReportInfo
+
+	BeforeEach(func() {
+		data = &gosec.ReportInfo{
+			Errors: map[string][]gosec.Error{},
+			Issues: []*issue.Issue{
+				{
+					File:        "/home/src/project/db/query.go",
+					Line:        "11",
+					Col:         "3",
+					RuleID:      "G202",
+					What:        "SQL string concatenation",
+					Confidence:  issue.High,
+					Severity:    issue.Medium,
+					Code:        "code",
+					Cwe:         issue.GetCweByRule("G202"),
+					Fingerprint: "0123456789abcdef",
+				},
+			},
+			Stats: &gosec.Metrics{},
+		}
+	})
+
+	Context("when converting to Code Climate issues", func() {
+		It("should describe the issue and its location", func() {
+			issues, err := codeclimate.GenerateReport([]string{"/home/src/project"}, data)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues).To(HaveLen(1))
+
+			issue := issues[0]
+			Expect(issue.Type).To(Equal("issue"))
+			Expect(issue.CheckName).To(Equal("G202"))
+			Expect(issue.Description).To(Equal("[G202] SQL string concatenation"))
+			Expect(issue.Categories).To(Equal([]string{"Security"}))
+			Expect(issue.Severity).To(Equal("major"))
+			Expect(issue.Fingerprint).To(Equal("0123456789abcdef"))
+			Expect(issue.Location).To(Equal(&codeclimate.Location{
+				Path:  "db/query.go",
+				Lines: &codeclimate.Lines{Begin: 11, End: 11},
+			}))
+			Expect(issue.Content.Body).To(ContainSubstring("[CWE-89: "))
+		})
+
+		It("should fingerprint the issues without fingerprint", func() {
+			data.Issues[0].Fingerprint = ""
+			data.Issues[0].Cwe = nil
+			issues, err := codeclimate.GenerateReport([]string{}, data)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issues[0].Fingerprint).To(HaveLen(32))
+			Expect(issues[0].Location.Path).To(Equal("/home/src/project/db/query.go"))
+			Expect(issues[0].Content).To(BeNil())
+		})
+	})
+
+	Context("when writing Code Climate reports", func() {
+		It("should write a JSON array", func() {
+			buf := new(bytes.Buffer)
+			err := codeclimate.WriteReport(buf, data, []string{"/home/src/project"})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var issues []map[string]interface{}
+			Expect(json.Unmarshal(buf.Bytes(), &issues)).To(Succeed())
+			Expect(issues).To(HaveLen(1))
+			Expect(issues[0]).To(HaveKeyWithValue("check_name", "G202"))
+		})
+
+		It("should write an empty array without issues", func() {
+			data.Issues = nil
+			buf := new(bytes.Buffer)
+			Expect(codeclimate.WriteReport(buf, data, []string{})).To(Succeed())
+			Expect(buf.String()).To(Equal("[]"))
+		})
+	})
+})
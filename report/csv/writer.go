@@ -20,6 +20,7 @@ func WriteReport(w io.Writer, data *gosec.ReportInfo) error {
 			issue.Confidence.String(),
 			issue.Code,
 			issue.Cwe.SprintID(),
+			issue.Fingerprint,
 		})
 		if err != nil {
 			return err
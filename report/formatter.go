@@ -15,15 +15,25 @@
 package report
 
 import (
+	"fmt"
 	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
 
 	"github.com/securego/gosec/v2"
 	"github.com/securego/gosec/v2/issue"
+	"github.com/securego/gosec/v2/report/checkstyle"
+	"github.com/securego/gosec/v2/report/codeclimate"
 	"github.com/securego/gosec/v2/report/csv"
+	"github.com/securego/gosec/v2/report/gitlab"
 	"github.com/securego/gosec/v2/report/golint"
 	"github.com/securego/gosec/v2/report/html"
 	"github.com/securego/gosec/v2/report/json"
 	"github.com/securego/gosec/v2/report/junit"
+	"github.com/securego/gosec/v2/report/rdjson"
 	"github.com/securego/gosec/v2/report/sarif"
 	"github.com/securego/gosec/v2/report/sonar"
 	"github.com/securego/gosec/v2/report/text"
@@ -50,36 +60,175 @@ const (
 	ReportSARIF // SARIF format
 )
 
+// TemplateFormat is the format of the reports rendered by a user-provided
+// text/template, given as template:<file>.
+const TemplateFormat = "template"
+
+// Options are the settings of a report.
+type Options struct {
+	// EnableColor colorizes the text reports.
+	EnableColor bool
+	// RootPaths are the root paths of the scanned projects.
+	RootPaths []string
+}
+
+// Formatter writes a report of the findings in its format. The suppressed
+// issues are given as well, with their suppressions.
+type Formatter func(w io.Writer, data *gosec.ReportInfo, opts Options) error
+
+var (
+	formattersMu sync.RWMutex
+	formatters   = map[string]Formatter{
+		"json": func(w io.Writer, data *gosec.ReportInfo, _ Options) error {
+			return json.WriteReport(w, data)
+		},
+		"yaml": withoutSuppressedIssues(func(w io.Writer, data *gosec.ReportInfo, _ Options) error {
+			return yaml.WriteReport(w, data)
+		}),
+		"csv": withoutSuppressedIssues(func(w io.Writer, data *gosec.ReportInfo, _ Options) error {
+			return csv.WriteReport(w, data)
+		}),
+		"junit-xml": withoutSuppressedIssues(func(w io.Writer, data *gosec.ReportInfo, _ Options) error {
+			return junit.WriteReport(w, data)
+		}),
+		"html": withoutSuppressedIssues(func(w io.Writer, data *gosec.ReportInfo, _ Options) error {
+			return html.WriteReport(w, data)
+		}),
+		"text": withoutSuppressedIssues(func(w io.Writer, data *gosec.ReportInfo, opts Options) error {
+			return text.WriteReport(w, data, opts.EnableColor)
+		}),
+		"sonarqube": withoutSuppressedIssues(func(w io.Writer, data *gosec.ReportInfo, opts Options) error {
+			return sonar.WriteReport(w, data, opts.RootPaths)
+		}),
+		"golint": withoutSuppressedIssues(func(w io.Writer, data *gosec.ReportInfo, _ Options) error {
+			return golint.WriteReport(w, data)
+		}),
+		"sarif": func(w io.Writer, data *gosec.ReportInfo, opts Options) error {
+			return sarif.WriteReport(w, data, opts.RootPaths)
+		},
+		"gitlab-sast": func(w io.Writer, data *gosec.ReportInfo, opts Options) error {
+			return gitlab.WriteReport(w, data, opts.RootPaths)
+		},
+		"codeclimate": withoutSuppressedIssues(func(w io.Writer, data *gosec.ReportInfo, opts Options) error {
+			return codeclimate.WriteReport(w, data, opts.RootPaths)
+		}),
+		"checkstyle": withoutSuppressedIssues(func(w io.Writer, data *gosec.ReportInfo, _ Options) error {
+			return checkstyle.WriteReport(w, data)
+		}),
+		"rdjson": withoutSuppressedIssues(func(w io.Writer, data *gosec.ReportInfo, _ Options) error {
+			return rdjson.WriteReport(w, data)
+		}),
+		"rdjsonl": withoutSuppressedIssues(func(w io.Writer, data *gosec.ReportInfo, _ Options) error {
+			return rdjson.WriteLinesReport(w, data)
+		}),
+	}
+)
+
+// RegisterFormatter registers the formatter of a new format, which can then
+// be used by CreateReport and the -fmt flag.
+func RegisterFormatter(format string, formatter Formatter) error {
+	if format == "" || strings.Contains(format, ":") {
+		return fmt.Errorf("invalid format name %q", format)
+	}
+	if format == TemplateFormat {
+		return fmt.Errorf("format %q is reserved", format)
+	}
+	if formatter == nil {
+		return fmt.Errorf("format %q has no formatter", format)
+	}
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+	if _, ok := formatters[format]; ok {
+		return fmt.Errorf("format %q is already registered", format)
+	}
+	formatters[format] = formatter
+	return nil
+}
+
+// Formats returns the sorted names of the registered formats.
+func Formats() []string {
+	formattersMu.RLock()
+	defer formattersMu.RUnlock()
+	formats := make([]string, 0, len(formatters))
+	for format := range formatters {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// ValidateFormat checks that the format is registered, or that the template
+// of a template:<file> format can be parsed.
+func ValidateFormat(format string) error {
+	if file, ok := strings.CutPrefix(format, TemplateFormat+":"); ok {
+		_, err := loadTemplate(file, false)
+		return err
+	}
+	if _, ok := lookupFormatter(format); !ok {
+		return fmt.Errorf("invalid format %q, valid options are: %s or %s:<file>", format, strings.Join(Formats(), ", "), TemplateFormat)
+	}
+	return nil
+}
+
+func lookupFormatter(format string) (Formatter, bool) {
+	formattersMu.RLock()
+	defer formattersMu.RUnlock()
+	formatter, ok := formatters[format]
+	return formatter, ok
+}
+
 // CreateReport generates a report based for the supplied issues and metrics given
-// the specified format. The formats currently accepted are: json, yaml, csv, junit-xml, html, sonarqube, golint and text.
+// the specified format. The formats currently accepted are the registered ones, json,
+// yaml, csv, junit-xml, html, sonarqube, golint, sarif, gitlab-sast, codeclimate,
+// checkstyle, rdjson, rdjsonl and text by default, and template:<file>. The unknown
+// formats default to text.
+// The data is not modified, so that several reports can be created from it.
 func CreateReport(w io.Writer, format string, enableColor bool, rootPaths []string, data *gosec.ReportInfo) error {
-	var err error
-	if format != "json" && format != "sarif" {
-		data.Issues = filterOutSuppressedIssues(data.Issues)
-	}
-	switch format {
-	case "json":
-		err = json.WriteReport(w, data)
-	case "yaml":
-		err = yaml.WriteReport(w, data)
-	case "csv":
-		err = csv.WriteReport(w, data)
-	case "junit-xml":
-		err = junit.WriteReport(w, data)
-	case "html":
-		err = html.WriteReport(w, data)
-	case "text":
-		err = text.WriteReport(w, data, enableColor)
-	case "sonarqube":
-		err = sonar.WriteReport(w, data, rootPaths)
-	case "golint":
-		err = golint.WriteReport(w, data)
-	case "sarif":
-		err = sarif.WriteReport(w, data, rootPaths)
-	default:
-		err = text.WriteReport(w, data, enableColor)
-	}
-	return err
+	opts := Options{EnableColor: enableColor, RootPaths: rootPaths}
+	if file, ok := strings.CutPrefix(format, TemplateFormat+":"); ok {
+		return withoutSuppressedIssues(templateFormatter(file))(w, data, opts)
+	}
+	formatter, ok := lookupFormatter(format)
+	if !ok {
+		formatter, _ = lookupFormatter("text")
+	}
+	return formatter(w, data, opts)
+}
+
+// templateFormatter returns the formatter rendering the report through the
+// text/template of the file, which has the functions of the text format.
+func templateFormatter(file string) Formatter {
+	return func(w io.Writer, data *gosec.ReportInfo, opts Options) error {
+		t, err := loadTemplate(file, opts.EnableColor)
+		if err != nil {
+			return err
+		}
+		return t.Execute(w, data)
+	}
+}
+
+func loadTemplate(file string, enableColor bool) (*template.Template, error) {
+	if file == "" {
+		return nil, fmt.Errorf("missing template file in format %q", TemplateFormat+":")
+	}
+	content, err := os.ReadFile(file) // #nosec G304 -- the template is given by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the report template: %w", err)
+	}
+	t, err := text.ParseTemplate(string(content), enableColor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the report template %s: %w", file, err)
+	}
+	return t, nil
+}
+
+// withoutSuppressedIssues wraps a formatter of the non suppressed issues.
+func withoutSuppressedIssues(formatter Formatter) Formatter {
+	return func(w io.Writer, data *gosec.ReportInfo, opts Options) error {
+		filtered := *data
+		filtered.Issues = filterOutSuppressedIssues(data.Issues)
+		return formatter(w, &filtered, opts)
+	}
 }
 
 func filterOutSuppressedIssues(issues []*issue.Issue) []*issue.Issue {
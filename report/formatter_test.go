@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -362,7 +365,7 @@ var _ = Describe("Formatter", func() {
 				reportInfo := gosec.NewReportInfo([]*issue.Issue{&newissue}, &gosec.Metrics{}, errors)
 				err := CreateReport(buf, "csv", false, []string{}, reportInfo)
 				Expect(err).ShouldNot(HaveOccurred())
-				pattern := "/home/src/project/test.go,1,test,HIGH,HIGH,1: testcode,CWE-%s\n"
+				pattern := "/home/src/project/test.go,1,test,HIGH,HIGH,1: testcode,CWE-%s,\n"
 				expect := fmt.Sprintf(pattern, cwe.ID)
 				Expect(buf.String()).To(Equal(expect))
 			}
@@ -690,6 +693,54 @@ var _ = Describe("Formatter", func() {
 			// Golint output should be generated
 			Expect(buf.Len()).To(BeNumerically(">", 0))
 		})
+
+		It("should keep the suppressed issues for the next reports", func() {
+			regularIssue := createIssue("G102", issue.GetCweByRule("G102"))
+			errors := map[string][]gosec.Error{}
+			reportInfo := gosec.NewReportInfo([]*issue.Issue{&suppressedIssue, &regularIssue}, &gosec.Metrics{}, errors)
+
+			err := CreateReport(new(bytes.Buffer), "text", false, []string{}, reportInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(reportInfo.Issues).To(HaveLen(2))
+
+			buf := new(bytes.Buffer)
+			err = CreateReport(buf, "json", false, []string{}, reportInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).To(ContainSubstring("G101"))
+		})
+	})
+
+	Context("When converting fingerprinted issues", func() {
+		fingerprintedIssue := createIssue("G101", issue.GetCweByRule("G101"))
+		fingerprintedIssue.Fingerprint = "0123456789abcdef0123456789abcdef"
+
+		report := func(format string) string {
+			reportInfo := gosec.NewReportInfo([]*issue.Issue{&fingerprintedIssue}, &gosec.Metrics{}, map[string][]gosec.Error{})
+			buf := new(bytes.Buffer)
+			err := CreateReport(buf, format, false, []string{"/home/src/project"}, reportInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+			return buf.String()
+		}
+
+		It("json formatted report should contain the fingerprint", func() {
+			Expect(stripString(report("json"))).To(ContainSubstring(`"fingerprint":"0123456789abcdef0123456789abcdef"`))
+		})
+
+		It("yaml formatted report should contain the fingerprint", func() {
+			Expect(report("yaml")).To(ContainSubstring("fingerprint: 0123456789abcdef0123456789abcdef"))
+		})
+
+		It("csv formatted report should end the rows with the fingerprint", func() {
+			Expect(report("csv")).To(HaveSuffix(",CWE-798,0123456789abcdef0123456789abcdef\n"))
+		})
+
+		It("sarif formatted report should contain the fingerprint among the partial fingerprints", func() {
+			Expect(stripString(report("sarif"))).To(ContainSubstring(`"partialFingerprints":{"gosecFingerprint/v1":"0123456789abcdef0123456789abcdef"}`))
+		})
+
+		It("sonarqube formatted report should use the fingerprint as the issue key", func() {
+			Expect(stripString(report("sonarqube"))).To(ContainSubstring(`"key":"0123456789abcdef0123456789abcdef"`))
+		})
 	})
 
 	Context("When using default format", func() {
@@ -795,4 +846,88 @@ var _ = Describe("Formatter", func() {
 			Expect(result).ToNot(BeEmpty())
 		})
 	})
+
+	Context("When registering formatters", func() {
+		It("should create the reports of the registered formats", func() {
+			err := RegisterFormatter("rule-ids", func(w io.Writer, data *gosec.ReportInfo, _ Options) error {
+				for _, issue := range data.Issues {
+					fmt.Fprintln(w, issue.RuleID)
+				}
+				return nil
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(Formats()).To(ContainElement("rule-ids"))
+			Expect(ValidateFormat("rule-ids")).To(Succeed())
+
+			testIssue := createIssue("G101", issue.GetCweByRule("G101"))
+			reportInfo := gosec.NewReportInfo([]*issue.Issue{&testIssue}, &gosec.Metrics{}, map[string][]gosec.Error{})
+			buf := new(bytes.Buffer)
+			err = CreateReport(buf, "rule-ids", false, []string{}, reportInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).To(Equal("G101\n"))
+		})
+
+		It("should register the built-in formats", func() {
+			Expect(Formats()).To(ContainElements("json", "sarif", "sonarqube", "gitlab-sast", "codeclimate", "checkstyle", "rdjson", "rdjsonl", "text"))
+
+			testIssue := createIssue("G101", issue.GetCweByRule("G101"))
+			testIssue.WithSuppressions([]issue.SuppressionInfo{{Kind: "inSource"}})
+			reportInfo := gosec.NewReportInfo([]*issue.Issue{&testIssue}, &gosec.Metrics{}, map[string][]gosec.Error{})
+
+			buf := new(bytes.Buffer)
+			Expect(CreateReport(buf, "gitlab-sast", false, []string{"/home/src/project"}, reportInfo)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("flagged-as-likely-false-positive"))
+
+			buf.Reset()
+			Expect(CreateReport(buf, "codeclimate", false, []string{"/home/src/project"}, reportInfo)).To(Succeed())
+			Expect(buf.String()).To(Equal("[]"))
+		})
+
+		It("should reject the invalid formats", func() {
+			formatter := func(io.Writer, *gosec.ReportInfo, Options) error { return nil }
+			Expect(RegisterFormatter("json", formatter)).To(MatchError(ContainSubstring("already registered")))
+			Expect(RegisterFormatter(TemplateFormat, formatter)).To(MatchError(ContainSubstring("reserved")))
+			Expect(RegisterFormatter("a:b", formatter)).To(HaveOccurred())
+			Expect(RegisterFormatter("none", nil)).To(HaveOccurred())
+			Expect(ValidateFormat("xml")).To(MatchError(ContainSubstring("invalid format")))
+		})
+	})
+
+	Context("When using a template", func() {
+		It("should render the report through the template", func() {
+			file := filepath.Join(GinkgoT().TempDir(), "report.tmpl")
+			content := "{{ range .Issues }}* {{ .RuleID }} {{ .File }}:{{ .Line }}\n{{ end }}{{ notice \"Issues\" }}: {{ .Stats.NumFound }}\n"
+			Expect(os.WriteFile(file, []byte(content), 0o600)).To(Succeed())
+
+			testIssue := createIssue("G101", issue.GetCweByRule("G101"))
+			reportInfo := gosec.NewReportInfo([]*issue.Issue{&testIssue}, &gosec.Metrics{NumFound: 1}, map[string][]gosec.Error{})
+			buf := new(bytes.Buffer)
+			err := CreateReport(buf, TemplateFormat+":"+file, false, []string{}, reportInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).To(Equal("* G101 /home/src/project/test.go:1\nIssues: 1\n"))
+		})
+
+		It("should filter out the suppressed issues", func() {
+			file := filepath.Join(GinkgoT().TempDir(), "report.tmpl")
+			Expect(os.WriteFile(file, []byte("{{ range .Issues }}* {{ .RuleID }}\n{{ end }}"), 0o600)).To(Succeed())
+
+			suppressedIssue := createIssue("G101", issue.GetCweByRule("G101"))
+			suppressedIssue.WithSuppressions([]issue.SuppressionInfo{{Kind: "inSource"}})
+			regularIssue := createIssue("G102", issue.GetCweByRule("G102"))
+			reportInfo := gosec.NewReportInfo([]*issue.Issue{&suppressedIssue, &regularIssue}, &gosec.Metrics{}, map[string][]gosec.Error{})
+			buf := new(bytes.Buffer)
+			err := CreateReport(buf, TemplateFormat+":"+file, false, []string{}, reportInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).To(Equal("* G102\n"))
+		})
+
+		It("should reject the invalid templates", func() {
+			file := filepath.Join(GinkgoT().TempDir(), "report.tmpl")
+			Expect(os.WriteFile(file, []byte("{{ range .Issues }}"), 0o600)).To(Succeed())
+
+			Expect(ValidateFormat(TemplateFormat + ":" + file)).To(MatchError(ContainSubstring("failed to parse")))
+			Expect(ValidateFormat(TemplateFormat + ":missing.tmpl")).To(MatchError(ContainSubstring("failed to read")))
+			Expect(ValidateFormat(TemplateFormat + ":")).To(HaveOccurred())
+		})
+	})
 })
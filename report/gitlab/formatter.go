@@ -0,0 +1,165 @@
+package gitlab
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/cwe"
+	"github.com/securego/gosec/v2/internal/pathutil"
+	"github.com/securego/gosec/v2/issue"
+	"github.com/securego/gosec/v2/rules"
+)
+
+const (
+	// Version is the version of the GitLab security report schema
+	Version = "15.2.1"
+
+	// timeFormat is the format of the scan times required by the schema
+	timeFormat = "2006-01-02T15:04:05"
+
+	gosecID   = "gosec"
+	gosecName = "gosec"
+	gosecURL  = "https://github.com/securego/gosec"
+
+	falsePositiveFlag = "flagged-as-likely-false-positive"
+)
+
+// GenerateReport converts a gosec report into a GitLab SAST report of the scan
+// between startTime and endTime. The suppressed issues are flagged as likely
+// false positives.
+func GenerateReport(rootPaths []string, data *gosec.ReportInfo, startTime, endTime time.Time) (*Report, error) {
+	ruleDefinitions := rules.Generate(false).Rules
+	vulnerabilities := []*Vulnerability{}
+	for _, issue := range data.Issues {
+		location, err := parseLocation(issue, rootPaths)
+		if err != nil {
+			return nil, err
+		}
+
+		name := issue.What
+		if def, found := ruleDefinitions[issue.RuleID]; found && def.Description != "" {
+			name = def.Description
+		} else if issue.Cwe != nil {
+			if weakness := cwe.Get(issue.Cwe.ID); weakness != nil {
+				name = weakness.Name
+			}
+		}
+
+		vulnerabilities = append(vulnerabilities, &Vulnerability{
+			ID:          vulnerabilityID(issue),
+			Name:        name,
+			Description: issue.What,
+			Severity:    getSeverity(issue.Severity),
+			Solution:    issue.Autofix,
+			Identifiers: buildIdentifiers(issue),
+			Location:    location,
+			Flags:       buildFlags(issue.Suppressions),
+		})
+	}
+
+	tool := &Tool{
+		ID:      gosecID,
+		Name:    gosecName,
+		URL:     gosecURL,
+		Version: data.GosecVersion,
+		Vendor:  &Vendor{Name: gosecName},
+	}
+	scan := &Scan{
+		Analyzer:  tool,
+		Scanner:   tool,
+		Type:      "sast",
+		StartTime: startTime.UTC().Format(timeFormat),
+		EndTime:   endTime.UTC().Format(timeFormat),
+		Status:    "success",
+	}
+	return &Report{
+		Version:         Version,
+		Scan:            scan,
+		Vulnerabilities: vulnerabilities,
+	}, nil
+}
+
+// vulnerabilityID returns a UUID derived from the fingerprint of the issue,
+// which identifies it across the scans.
+func vulnerabilityID(i *issue.Issue) string {
+	key := i.Fingerprint
+	if key == "" {
+		key = strings.Join([]string{i.RuleID, i.File, i.Line, i.Col}, ":")
+	}
+	return uuid.NewMD5(uuid.Nil, []byte(key)).String()
+}
+
+func buildIdentifiers(i *issue.Issue) []*Identifier {
+	identifiers := []*Identifier{{
+		Type:  "gosec_rule_id",
+		Name:  "Gosec Rule ID " + i.RuleID,
+		Value: i.RuleID,
+	}}
+	if i.Cwe != nil {
+		if weakness := cwe.Get(i.Cwe.ID); weakness != nil {
+			identifiers = append(identifiers, &Identifier{
+				Type:  "cwe",
+				Name:  weakness.SprintID(),
+				Value: weakness.ID,
+				URL:   weakness.SprintURL(),
+			})
+		}
+	}
+	return identifiers
+}
+
+func buildFlags(suppressions []issue.SuppressionInfo) []*Flag {
+	var flags []*Flag
+	for _, s := range suppressions {
+		description := fmt.Sprintf("Suppressed (%s)", s.Kind)
+		if s.Justification != "" {
+			description += ": " + s.Justification
+		}
+		flags = append(flags, &Flag{
+			Type:        falsePositiveFlag,
+			Origin:      gosecID,
+			Description: description,
+		})
+	}
+	return flags
+}
+
+func parseLocation(i *issue.Issue, rootPaths []string) (*Location, error) {
+	lines := strings.Split(i.Line, "-")
+	startLine, err := strconv.Atoi(lines[0])
+	if err != nil {
+		return nil, err
+	}
+	endLine := startLine
+	if len(lines) > 1 {
+		endLine, err = strconv.Atoi(lines[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Location{
+		File:      pathutil.RelativeFilePath(i.File, rootPaths),
+		StartLine: startLine,
+		EndLine:   endLine,
+	}, nil
+}
+
+func getSeverity(score issue.Score) string {
+	switch score {
+	case issue.Critical:
+		return "Critical"
+	case issue.High:
+		return "High"
+	case issue.Medium:
+		return "Medium"
+	case issue.Low:
+		return "Low"
+	default:
+		return "Unknown"
+	}
+}
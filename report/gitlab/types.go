@@ -0,0 +1,66 @@
+package gitlab
+
+// Vendor defines the vendor of a tool
+type Vendor struct {
+	Name string `json:"name"`
+}
+
+// Tool defines the analyzer or the scanner of a scan
+type Tool struct {
+	ID      string  `json:"id"`
+	Name    string  `json:"name"`
+	URL     string  `json:"url,omitempty"`
+	Version string  `json:"version"`
+	Vendor  *Vendor `json:"vendor"`
+}
+
+// Scan defines the scan which produced the report
+type Scan struct {
+	Analyzer  *Tool  `json:"analyzer"`
+	Scanner   *Tool  `json:"scanner"`
+	Type      string `json:"type"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	Status    string `json:"status"`
+}
+
+// Identifier defines an identifier of a vulnerability, such as its CWE
+type Identifier struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+// Location defines the location of a vulnerability
+type Location struct {
+	File      string `json:"file"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line,omitempty"`
+}
+
+// Flag defines a flag of a vulnerability, such as its suppression
+type Flag struct {
+	Type        string `json:"type"`
+	Origin      string `json:"origin"`
+	Description string `json:"description"`
+}
+
+// Vulnerability defines a GitLab vulnerability
+type Vulnerability struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Severity    string        `json:"severity"`
+	Solution    string        `json:"solution,omitempty"`
+	Identifiers []*Identifier `json:"identifiers"`
+	Location    *Location     `json:"location"`
+	Flags       []*Flag       `json:"flags,omitempty"`
+}
+
+// Report defines a GitLab SAST report
+type Report struct {
+	Version         string           `json:"version"`
+	Scan            *Scan            `json:"scan"`
+	Vulnerabilities []*Vulnerability `json:"vulnerabilities"`
+}
@@ -0,0 +1,33 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/securego/gosec/v2"
+)
+
+// WriteReport write a report in GitLab SAST format to the output writer. The
+// scan times default to the current time when the report does not have them.
+func WriteReport(w io.Writer, data *gosec.ReportInfo, rootPaths []string) error {
+	endTime := data.EndTime
+	if endTime.IsZero() {
+		endTime = time.Now()
+	}
+	startTime := data.StartTime
+	if startTime.IsZero() {
+		startTime = endTime
+	}
+	gr, err := GenerateReport(rootPaths, data, startTime, endTime)
+	if err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(gr, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(raw)
+	return err
+}
@@ -0,0 +1,126 @@
+package gitlab_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+	"github.com/securego/gosec/v2/report/gitlab"
+)
+
+func TestGitlab(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GitLab Writer Suite")
+}
+
+var _ = Describe("GitLab Writer", func() {
+	var data *gosec.ReportInfo
+
+	BeforeEach(func() {
+		data = &gosec.ReportInfo{
+			Errors: map[string][]gosec.Error{},
+			Issues: []*issue.Issue{
+				{
+					File:        "/home/src/project/db/query.go",
+					Line:        "11-12",
+					Col:         "3",
+					RuleID:      "G202",
+					What:        "SQL string concatenation",
+					Confidence:  issue.High,
+					Severity:    issue.Critical,
+					Code:        "code",
+					Cwe:         issue.GetCweByRule("G202"),
+					Fingerprint: "0123456789abcdef",
+				},
+			},
+			Stats:        &gosec.Metrics{},
+			GosecVersion: "v2.22.0",
+		}
+	})
+
+	Context("when converting to GitLab vulnerabilities", func() {
+		It("should describe the issue, its identifiers and its location", func() {
+			startTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+			report, err := gitlab.GenerateReport([]string{"/home/src/project"}, data, startTime, startTime.Add(90*time.Second))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(report.Version).To(Equal(gitlab.Version))
+			Expect(report.Scan.Type).To(Equal("sast"))
+			Expect(report.Scan.StartTime).To(Equal("2026-01-02T03:04:05"))
+			Expect(report.Scan.EndTime).To(Equal("2026-01-02T03:05:35"))
+			Expect(report.Scan.Scanner.ID).To(Equal("gosec"))
+			Expect(report.Scan.Scanner.Version).To(Equal("v2.22.0"))
+
+			Expect(report.Vulnerabilities).To(HaveLen(1))
+			vulnerability := report.Vulnerabilities[0]
+			Expect(vulnerability.ID).To(HaveLen(36))
+			Expect(vulnerability.Name).To(Equal("SQL query construction using string concatenation"))
+			Expect(vulnerability.Description).To(Equal("SQL string concatenation"))
+			Expect(vulnerability.Severity).To(Equal("Critical"))
+			Expect(vulnerability.Location).To(Equal(&gitlab.Location{File: "db/query.go", StartLine: 11, EndLine: 12}))
+			Expect(vulnerability.Identifiers).To(Equal([]*gitlab.Identifier{
+				{Type: "gosec_rule_id", Name: "Gosec Rule ID G202", Value: "G202"},
+				{Type: "cwe", Name: "CWE-89", Value: "89", URL: "https://cwe.mitre.org/data/definitions/89.html"},
+			}))
+			Expect(vulnerability.Flags).To(BeEmpty())
+		})
+
+		It("should keep the same ID for the same fingerprint", func() {
+			first, err := gitlab.GenerateReport([]string{}, data, time.Now(), time.Now())
+			Expect(err).ShouldNot(HaveOccurred())
+			data.Issues[0].Line = "20"
+			second, err := gitlab.GenerateReport([]string{}, data, time.Now(), time.Now())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(second.Vulnerabilities[0].ID).To(Equal(first.Vulnerabilities[0].ID))
+		})
+
+		It("should flag the suppressed issues", func() {
+			data.Issues[0].Suppressions = []issue.SuppressionInfo{{Kind: "inSource", Justification: "validated input"}}
+			report, err := gitlab.GenerateReport([]string{}, data, time.Now(), time.Now())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(report.Vulnerabilities[0].Flags).To(Equal([]*gitlab.Flag{{
+				Type:        "flagged-as-likely-false-positive",
+				Origin:      "gosec",
+				Description: "Suppressed (inSource): validated input",
+			}}))
+		})
+
+		It("should reject the invalid lines", func() {
+			data.Issues[0].Line = "x"
+			_, err := gitlab.GenerateReport([]string{}, data, time.Now(), time.Now())
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("when writing GitLab SAST reports", func() {
+		It("should write valid JSON", func() {
+			buf := new(bytes.Buffer)
+			err := gitlab.WriteReport(buf, data, []string{"/home/src/project"})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var report map[string]interface{}
+			Expect(json.Unmarshal(buf.Bytes(), &report)).To(Succeed())
+			Expect(report).To(HaveKey("vulnerabilities"))
+			Expect(report["scan"]).To(HaveKeyWithValue("status", "success"))
+		})
+
+		It("should use the scan times of the report", func() {
+			startTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+			data.WithScanTimes(startTime, startTime.Add(time.Minute))
+			buf := new(bytes.Buffer)
+			err := gitlab.WriteReport(buf, data, []string{"/home/src/project"})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var report map[string]interface{}
+			Expect(json.Unmarshal(buf.Bytes(), &report)).To(Succeed())
+			Expect(report["scan"]).To(HaveKeyWithValue("start_time", "2026-01-02T03:04:05"))
+			Expect(report["scan"]).To(HaveKeyWithValue("end_time", "2026-01-02T03:05:05"))
+		})
+	})
+})
@@ -15,6 +15,12 @@ func NewFailure(message string, text string) *Failure {
 	}
 }
 
+// WithType defines the type of the failure
+func (f *Failure) WithType(failureType string) *Failure {
+	f.Type = failureType
+	return f
+}
+
 // NewTestcase instantiate a Testcase
 func NewTestcase(name string, failure *Failure) *Testcase {
 	return &Testcase{
@@ -33,7 +33,8 @@ func GenerateReport(data *gosec.ReportInfo) Report {
 			index = len(xmlReport.Testsuites) - 1
 			testsuites[issue.What] = index
 		}
-		failure := NewFailure("Found 1 vulnerability. See stacktrace for details.", generatePlaintext(issue))
+		failure := NewFailure("Found 1 vulnerability. See stacktrace for details.", generatePlaintext(issue)).
+			WithType(issue.Severity.String())
 		testcase := NewTestcase(issue.File, failure)
 
 		xmlReport.Testsuites[index].Testcases = append(xmlReport.Testsuites[index].Testcases, testcase)
@@ -29,5 +29,6 @@ type Testcase struct {
 type Failure struct {
 	XMLName xml.Name `xml:"failure"`
 	Message string   `xml:"message,attr"`
+	Type    string   `xml:"type,attr,omitempty"`
 	Text    string   `xml:",innerxml"`
 }
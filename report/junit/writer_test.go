@@ -237,6 +237,31 @@ var _ = Describe("JUnit Writer", func() {
 			result := buf.String()
 			Expect(result).To(ContainSubstring("Severity:"))
 			Expect(result).To(ContainSubstring("Confidence:"))
+			Expect(result).To(ContainSubstring(`type="HIGH"`))
+		})
+
+		It("should tell the critical issues apart", func() {
+			data := &gosec.ReportInfo{
+				Errors: map[string][]gosec.Error{},
+				Issues: []*issue.Issue{
+					{
+						File:       "/test.go",
+						Line:       "1",
+						Col:        "1",
+						RuleID:     "G702",
+						What:       "Command injection",
+						Confidence: issue.High,
+						Severity:   issue.Critical,
+						Code:       "code",
+					},
+				},
+				Stats: &gosec.Metrics{},
+			}
+
+			buf := new(bytes.Buffer)
+			err := junit.WriteReport(buf, data)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).To(ContainSubstring(`type="CRITICAL"`))
 		})
 	})
 })
@@ -0,0 +1,115 @@
+package rdjson
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+)
+
+var gosecSource = &Source{
+	Name: "gosec",
+	URL:  "https://github.com/securego/gosec",
+}
+
+// GenerateReport converts a gosec report into a reviewdog diagnostic result.
+// The fixes of the issues are suggested as replacements of the code.
+func GenerateReport(data *gosec.ReportInfo) (*Report, error) {
+	diagnostics := []*Diagnostic{}
+	for _, issue := range data.Issues {
+		diagnostic, err := buildDiagnostic(issue)
+		if err != nil {
+			return nil, err
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return &Report{Source: gosecSource, Diagnostics: diagnostics}, nil
+}
+
+func buildDiagnostic(i *issue.Issue) (*Diagnostic, error) {
+	issueRange, err := parseRange(i)
+	if err != nil {
+		return nil, err
+	}
+
+	message := i.What
+	if i.Cwe != nil && i.Cwe.ID != "" {
+		message = "[" + i.Cwe.SprintID() + "] " + i.What
+	}
+	if i.Autofix != "" {
+		message += "\n\nAutofix: " + i.Autofix
+	}
+
+	code := &Code{Value: i.RuleID}
+	if i.Cwe != nil && i.Cwe.ID != "" {
+		code.URL = i.Cwe.SprintURL()
+	}
+
+	var suggestions []*Suggestion
+	for _, fix := range i.Fixes {
+		for _, r := range fix.Replacements {
+			suggestions = append(suggestions, &Suggestion{
+				Range: &Range{
+					Start: &Position{Line: r.StartLine, Column: r.StartColumn},
+					End:   &Position{Line: r.EndLine, Column: r.EndColumn},
+				},
+				Text: r.Text,
+			})
+		}
+	}
+
+	return &Diagnostic{
+		Message:     message,
+		Location:    &Location{Path: i.File, Range: issueRange},
+		Severity:    getSeverity(i.Severity),
+		Source:      gosecSource,
+		Code:        code,
+		Suggestions: suggestions,
+	}, nil
+}
+
+// parseRange returns the range of the issue, from its column to its end
+// column. The end of the range is left out for the issues on a single line
+// whose end column is unknown.
+func parseRange(i *issue.Issue) (*Range, error) {
+	lines := strings.Split(i.Line, "-")
+	startLine, err := strconv.Atoi(lines[0])
+	if err != nil {
+		return nil, err
+	}
+	endLine := startLine
+	if len(lines) > 1 {
+		endLine, err = strconv.Atoi(lines[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	// The columns are left out when they are unknown.
+	column, _ := strconv.Atoi(i.Col)
+	var endColumn int
+	if i.EndCol != "" {
+		endColumn, err = strconv.Atoi(i.EndCol)
+		if err != nil {
+			return nil, err
+		}
+	}
+	r := &Range{Start: &Position{Line: startLine, Column: column}}
+	if endColumn > 0 || endLine > startLine {
+		r.End = &Position{Line: endLine, Column: endColumn}
+	}
+	return r, nil
+}
+
+func getSeverity(score issue.Score) string {
+	switch score {
+	case issue.Critical, issue.High:
+		return "ERROR"
+	case issue.Medium:
+		return "WARNING"
+	case issue.Low:
+		return "INFO"
+	default:
+		return "UNKNOWN_SEVERITY"
+	}
+}
@@ -0,0 +1,54 @@
+package rdjson
+
+// Source defines the tool which reported the diagnostics
+type Source struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Position defines a position in a file. The line and the column start at 1,
+// and the column counts bytes.
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column,omitempty"`
+}
+
+// Range defines a range of a file, whose end is excluded
+type Range struct {
+	Start *Position `json:"start"`
+	End   *Position `json:"end,omitempty"`
+}
+
+// Location defines the location of a diagnostic
+type Location struct {
+	Path  string `json:"path"`
+	Range *Range `json:"range,omitempty"`
+}
+
+// Code defines the rule of a diagnostic
+type Code struct {
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+// Suggestion defines a replacement of a range of the file of a diagnostic
+type Suggestion struct {
+	Range *Range `json:"range"`
+	Text  string `json:"text"`
+}
+
+// Diagnostic defines a reviewdog diagnostic
+type Diagnostic struct {
+	Message     string        `json:"message"`
+	Location    *Location     `json:"location"`
+	Severity    string        `json:"severity,omitempty"`
+	Source      *Source       `json:"source,omitempty"`
+	Code        *Code         `json:"code,omitempty"`
+	Suggestions []*Suggestion `json:"suggestions,omitempty"`
+}
+
+// Report defines a reviewdog diagnostic result
+type Report struct {
+	Source      *Source       `json:"source"`
+	Diagnostics []*Diagnostic `json:"diagnostics"`
+}
@@ -0,0 +1,39 @@
+package rdjson
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/securego/gosec/v2"
+)
+
+// WriteReport write a report in reviewdog rdjson format to the output writer
+func WriteReport(w io.Writer, data *gosec.ReportInfo) error {
+	report, err := GenerateReport(data)
+	if err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(report, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(raw)
+	return err
+}
+
+// WriteLinesReport write a report in reviewdog rdjsonl format, one diagnostic
+// per line, to the output writer
+func WriteLinesReport(w io.Writer, data *gosec.ReportInfo) error {
+	report, err := GenerateReport(data)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	for _, diagnostic := range report.Diagnostics {
+		if err := enc.Encode(diagnostic); err != nil {
+			return err
+		}
+	}
+	return nil
+}
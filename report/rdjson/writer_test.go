@@ -0,0 +1,140 @@
+package rdjson_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+	"github.com/securego/gosec/v2/report/rdjson"
+)
+
+func TestRdjson(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Rdjson Writer Suite")
+}
+
+var _ = Describe("Rdjson Writer", func() {
+	var data *gosec.ReportInfo
+
+	BeforeEach(func() {
+		data = &gosec.ReportInfo{
+			Errors: map[string][]gosec.Error{},
+			Issues: []*issue.Issue{
+				{
+					File:       "/home/src/project/main.go",
+					Line:       "11-12",
+					Col:        "14",
+					RuleID:     "G403",
+					What:       "RSA keys should be at least 2048 bits",
+					Confidence: issue.High,
+					Severity:   issue.High,
+					Cwe:        issue.GetCweByRule("G403"),
+					Autofix:    "Use 2048 bits",
+				},
+				{
+					File:       "/home/src/project/main.go",
+					Line:       "20",
+					Col:        "9",
+					RuleID:     "nosec",
+					What:       "Unused nosec directive: no issue to suppress",
+					Confidence: issue.High,
+					Severity:   issue.Low,
+					Fixes: []issue.Fix{{
+						Description:  "Remove the unused nosec directive",
+						Replacements: []issue.Replacement{{StartLine: 20, StartColumn: 9, EndLine: 20, EndColumn: 18}},
+					}},
+				},
+			},
+			Stats: &gosec.Metrics{},
+		}
+	})
+
+	Context("when converting to reviewdog diagnostics", func() {
+		It("should describe the issues and suggest their fixes", func() {
+			report, err := rdjson.GenerateReport(data)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(report.Source.Name).To(Equal("gosec"))
+			Expect(report.Diagnostics).To(HaveLen(2))
+
+			diagnostic := report.Diagnostics[0]
+			Expect(diagnostic.Message).To(Equal("[CWE-310] RSA keys should be at least 2048 bits\n\nAutofix: Use 2048 bits"))
+			Expect(diagnostic.Severity).To(Equal("ERROR"))
+			Expect(diagnostic.Code).To(Equal(&rdjson.Code{Value: "G403", URL: "https://cwe.mitre.org/data/definitions/310.html"}))
+			Expect(diagnostic.Location).To(Equal(&rdjson.Location{
+				Path: "/home/src/project/main.go",
+				Range: &rdjson.Range{
+					Start: &rdjson.Position{Line: 11, Column: 14},
+					End:   &rdjson.Position{Line: 12},
+				},
+			}))
+			Expect(diagnostic.Suggestions).To(BeEmpty())
+
+			diagnostic = report.Diagnostics[1]
+			Expect(diagnostic.Severity).To(Equal("INFO"))
+			Expect(diagnostic.Location.Range.End).To(BeNil())
+			Expect(diagnostic.Suggestions).To(Equal([]*rdjson.Suggestion{{
+				Range: &rdjson.Range{
+					Start: &rdjson.Position{Line: 20, Column: 9},
+					End:   &rdjson.Position{Line: 20, Column: 18},
+				},
+			}}))
+		})
+
+		It("should end the range at the end column of the issue", func() {
+			data.Issues[1].EndCol = "18"
+			report, err := rdjson.GenerateReport(data)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(report.Diagnostics[1].Location.Range).To(Equal(&rdjson.Range{
+				Start: &rdjson.Position{Line: 20, Column: 9},
+				End:   &rdjson.Position{Line: 20, Column: 18},
+			}))
+
+			data.Issues[0].EndCol = "3"
+			report, err = rdjson.GenerateReport(data)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(report.Diagnostics[0].Location.Range.End).To(Equal(&rdjson.Position{Line: 12, Column: 3}))
+		})
+
+		It("should reject the invalid end columns", func() {
+			data.Issues[0].EndCol = "x"
+			_, err := rdjson.GenerateReport(data)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should reject the invalid lines", func() {
+			data.Issues[0].Line = "x"
+			_, err := rdjson.GenerateReport(data)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("when writing rdjson reports", func() {
+		It("should write a single JSON document", func() {
+			buf := new(bytes.Buffer)
+			Expect(rdjson.WriteReport(buf, data)).To(Succeed())
+
+			var report map[string]interface{}
+			Expect(json.Unmarshal(buf.Bytes(), &report)).To(Succeed())
+			Expect(report["diagnostics"]).To(HaveLen(2))
+		})
+
+		It("should write a diagnostic per line", func() {
+			buf := new(bytes.Buffer)
+			Expect(rdjson.WriteLinesReport(buf, data)).To(Succeed())
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			Expect(lines).To(HaveLen(2))
+			for _, line := range lines {
+				var diagnostic map[string]interface{}
+				Expect(json.Unmarshal([]byte(line), &diagnostic)).To(Succeed())
+				Expect(diagnostic).To(HaveKey("source"))
+			}
+		})
+	})
+})
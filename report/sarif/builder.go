@@ -21,6 +21,12 @@ func NewMultiformatMessageString(text string) *MultiformatMessageString {
 	}
 }
 
+// WithMarkdown defines the Markdown rendering of the current MultiformatMessageString
+func (m *MultiformatMessageString) WithMarkdown(markdown string) *MultiformatMessageString {
+	m.Markdown = markdown
+	return m
+}
+
 // NewRun instantiate a Run
 func NewRun(tool *Tool) *Run {
 	return &Run{
@@ -79,41 +85,55 @@ func NewTool(driver *ToolComponent) *Tool {
 }
 
 // NewResult instantiate a Result
-func NewResult(ruleID string, ruleIndex int, level Level, message string, suppressions []*Suppression, autofix string) *Result {
-	result := &Result{
+func NewResult(ruleID string, ruleIndex int, level Level, message string, suppressions []*Suppression) *Result {
+	return &Result{
 		RuleID:       ruleID,
 		RuleIndex:    ruleIndex,
 		Level:        level,
 		Message:      NewMessage(message),
 		Suppressions: suppressions,
 	}
+}
+
+// WithFixes defines the fixes of the current result
+func (r *Result) WithFixes(fixes ...*Fix) *Result {
+	r.Fixes = fixes
+	return r
+}
+
+// WithProperties defines the properties of the current result
+func (r *Result) WithProperties(properties PropertyBag) *Result {
+	r.Properties = &properties
+	return r
+}
+
+// NewFix instantiate a Fix
+func NewFix(description string, artifactChanges ...*ArtifactChange) *Fix {
+	return &Fix{
+		Description:     NewMessage(description),
+		ArtifactChanges: artifactChanges,
+	}
+}
+
+// NewArtifactChange instantiate an ArtifactChange
+func NewArtifactChange(artifactLocation *ArtifactLocation, replacements ...*Replacement) *ArtifactChange {
+	return &ArtifactChange{
+		ArtifactLocation: artifactLocation,
+		Replacements:     replacements,
+	}
+}
 
-	// Only create Fix when autofix content exists
-	// Fixes with nil/null ArtifactChanges violate SARIF 2.1.0 schema
-	if autofix != "" {
-		result.Fixes = []*Fix{
-			{
-				Description: &Message{
-					Text:     autofix,
-					Markdown: autofix,
-				},
-				// ArtifactChanges MUST be a non-empty array per SARIF 2.1.0 schema
-				ArtifactChanges: []*ArtifactChange{
-					{
-						ArtifactLocation: &ArtifactLocation{
-							Description: NewMessage("File requiring changes"),
-						},
-						Replacements: []*Replacement{
-							{
-								DeletedRegion: NewRegion(1, 1, 1, 1, ""),
-							},
-						},
-					},
-				},
-			},
-		}
-	}
-	return result
+// NewReplacement instantiate a Replacement
+func NewReplacement(deletedRegion *Region) *Replacement {
+	return &Replacement{
+		DeletedRegion: deletedRegion,
+	}
+}
+
+// WithInsertedContent defines the content inserted by the current Replacement
+func (r *Replacement) WithInsertedContent(content *ArtifactContent) *Replacement {
+	r.InsertedContent = content
+	return r
 }
 
 // NewMessage instantiate a Message
@@ -129,6 +149,39 @@ func (r *Result) WithLocations(locations ...*Location) *Result {
 	return r
 }
 
+// WithPartialFingerprints define the current result's partial fingerprints
+func (r *Result) WithPartialFingerprints(partialFingerprints map[string]string) *Result {
+	r.PartialFingerprints = partialFingerprints
+	return r
+}
+
+// WithCodeFlows define the current result's code flows
+func (r *Result) WithCodeFlows(codeFlows ...*CodeFlow) *Result {
+	r.CodeFlows = codeFlows
+	return r
+}
+
+// NewCodeFlow instantiate a CodeFlow
+func NewCodeFlow(threadFlows ...*ThreadFlow) *CodeFlow {
+	return &CodeFlow{
+		ThreadFlows: threadFlows,
+	}
+}
+
+// NewThreadFlow instantiate a ThreadFlow
+func NewThreadFlow(locations ...*ThreadFlowLocation) *ThreadFlow {
+	return &ThreadFlow{
+		Locations: locations,
+	}
+}
+
+// NewThreadFlowLocation instantiate a ThreadFlowLocation
+func NewThreadFlowLocation(location *Location) *ThreadFlowLocation {
+	return &ThreadFlowLocation{
+		Location: location,
+	}
+}
+
 // NewLocation instantiate a Location
 func NewLocation(physicalLocation *PhysicalLocation) *Location {
 	return &Location{
@@ -136,6 +189,12 @@ func NewLocation(physicalLocation *PhysicalLocation) *Location {
 	}
 }
 
+// WithMessage defines the Message for the current Location
+func (l *Location) WithMessage(message *Message) *Location {
+	l.Message = message
+	return l
+}
+
 // NewPhysicalLocation instantiate a PhysicalLocation
 func NewPhysicalLocation(artifactLocation *ArtifactLocation, region *Region) *PhysicalLocation {
 	return &PhysicalLocation{
@@ -235,3 +294,9 @@ func NewSuppression(kind string, justification string) *Suppression {
 		Justification: justification,
 	}
 }
+
+// WithProperties sets the properties of a Suppression
+func (s *Suppression) WithProperties(properties PropertyBag) *Suppression {
+	s.Properties = &properties
+	return s
+}
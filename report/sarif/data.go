@@ -19,4 +19,6 @@ const (
 	Version = "2.1.0"
 	// Schema : SARIF Schema URL
 	Schema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/main/sarif-2.1/schema/sarif-schema-2.1.0.json"
+	// FingerprintKey : Key of the gosec fingerprint among the partial fingerprints of a result
+	FingerprintKey = "gosecFingerprint/v1"
 )
@@ -10,6 +10,7 @@ import (
 
 	"github.com/securego/gosec/v2"
 	"github.com/securego/gosec/v2/cwe"
+	"github.com/securego/gosec/v2/internal/pathutil"
 	"github.com/securego/gosec/v2/issue"
 )
 
@@ -47,9 +48,31 @@ func GenerateReport(rootPaths []string, data *gosec.ReportInfo) (*Report, error)
 			getSarifLevel(issue.Severity.String()),
 			issue.What,
 			buildSarifSuppressions(issue.Suppressions),
-			issue.Autofix,
 		).WithLocations(location)
 
+		if fixes := buildSarifFixes(issue.Fixes, issue.File, rootPaths); len(fixes) > 0 {
+			result.WithFixes(fixes...)
+		}
+
+		properties := PropertyBag{}
+		if issue.Autofix != "" {
+			properties["autofix"] = issue.Autofix
+		}
+		if len(issue.ExpiredSuppressions) > 0 {
+			properties["expiredSuppressions"] = buildSarifSuppressions(issue.ExpiredSuppressions)
+		}
+		if len(properties) > 0 {
+			result.WithProperties(properties)
+		}
+
+		if issue.Fingerprint != "" {
+			result.WithPartialFingerprints(map[string]string{FingerprintKey: issue.Fingerprint})
+		}
+
+		if len(issue.Trace) > 0 {
+			result.WithCodeFlows(buildSarifCodeFlow(issue.Trace, rootPaths))
+		}
+
 		results = append(results, result)
 	}
 
@@ -95,16 +118,20 @@ func parseSarifRule(i *issue.Issue) *ReportingDescriptor {
 		name = cwe.Name
 	}
 	relationship := buildSarifReportingDescriptorRelationship(i.Cwe)
+	helpURI := getHelpURI(i.RuleID, i.Cwe)
 	rule := &ReportingDescriptor{
 		ID:               i.RuleID,
 		Name:             name,
 		ShortDescription: NewMultiformatMessageString(i.What),
 		FullDescription:  NewMultiformatMessageString(i.What),
 		Help: NewMultiformatMessageString(fmt.Sprintf("%s\nSeverity: %s\nConfidence: %s\n",
-			i.What, i.Severity.String(), i.Confidence.String())),
+			i.What, i.Severity.String(), i.Confidence.String())).
+			WithMarkdown(buildSarifHelpMarkdown(i, helpURI)),
+		HelpURI: helpURI,
 		Properties: &PropertyBag{
-			"tags":      []string{"security", i.Severity.String()},
-			"precision": strings.ToLower(i.Confidence.String()),
+			"tags":              []string{"security", i.Severity.String()},
+			"precision":         strings.ToLower(i.Confidence.String()),
+			"security-severity": getSecuritySeverity(i.Severity.String(), i.Cwe),
 		},
 		DefaultConfiguration: &ReportingConfiguration{
 			Level: getSarifLevel(i.Severity.String()),
@@ -116,6 +143,58 @@ func parseSarifRule(i *issue.Issue) *ReportingDescriptor {
 	return rule
 }
 
+// rulesDocURI is the documentation of the gosec rules, whose sections list the
+// rules by their first digit.
+const rulesDocURI = "https://github.com/securego/gosec/blob/master/RULES.md"
+
+var rulesDocSections = map[byte]string{
+	'1': "g1xx-general-secure-coding",
+	'2': "g2xx-injection-patterns",
+	'3': "g3xx-filesystem-and-permissions",
+	'4': "g4xx-crypto-and-protocol-security",
+	'5': "g5xx-import-blocklist",
+	'6': "g6xx-languageruntime-safety",
+	'7': "g7xx-taint-analysis",
+}
+
+// getHelpURI returns the documentation of a rule. The rules which are not
+// part of gosec, such as the custom taint rules, link to their CWE instead.
+func getHelpURI(ruleID string, weakness *cwe.Weakness) string {
+	if ruleID == gosec.UnusedNoSecRuleID {
+		return "https://github.com/securego/gosec#unused-annotations"
+	}
+	if len(ruleID) == 4 && ruleID[0] == 'G' {
+		if _, err := strconv.Atoi(ruleID[1:]); err == nil {
+			if section, ok := rulesDocSections[ruleID[1]]; ok {
+				return rulesDocURI + "#" + section
+			}
+		}
+	}
+	if weakness != nil && weakness.ID != "" {
+		return weakness.SprintURL()
+	}
+	return ""
+}
+
+// buildSarifHelpMarkdown returns the help of a rule in Markdown
+func buildSarifHelpMarkdown(i *issue.Issue, helpURI string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s**: %s\n\n", i.RuleID, i.What)
+	fmt.Fprintf(&b, "| Severity | Confidence |\n| --- | --- |\n| %s | %s |\n",
+		i.Severity.String(), i.Confidence.String())
+	if i.Cwe != nil && i.Cwe.ID != "" {
+		weakness := i.Cwe.SprintID()
+		if w := cwe.Get(i.Cwe.ID); w != nil {
+			weakness += ": " + w.Name
+		}
+		fmt.Fprintf(&b, "\nWeakness: [%s](%s)\n", weakness, i.Cwe.SprintURL())
+	}
+	if helpURI != "" {
+		fmt.Fprintf(&b, "\nSee the [documentation](%s) for more details.\n", helpURI)
+	}
+	return b.String()
+}
+
 func buildSarifReportingDescriptorRelationship(weakness *cwe.Weakness) *ReportingDescriptorRelationship {
 	if weakness == nil {
 		return nil
@@ -185,13 +264,21 @@ func parseSarifLocation(i *issue.Issue, rootPaths []string) (*Location, error) {
 }
 
 func parseSarifArtifactLocation(i *issue.Issue, rootPaths []string) *ArtifactLocation {
-	var filePath string
-	for _, rootPath := range rootPaths {
-		if strings.HasPrefix(i.File, rootPath) {
-			filePath = strings.Replace(i.File, rootPath+"/", "", 1)
-		}
+	return NewArtifactLocation(pathutil.RelativeFilePath(i.File, rootPaths))
+}
+
+// buildSarifCodeFlow converts the source-to-sink trace of an issue into a code flow
+// with a single thread flow
+func buildSarifCodeFlow(trace []issue.TraceStep, rootPaths []string) *CodeFlow {
+	locations := make([]*ThreadFlowLocation, 0, len(trace))
+	for _, step := range trace {
+		location := NewLocation(NewPhysicalLocation(
+			NewArtifactLocation(pathutil.RelativeFilePath(step.File, rootPaths)),
+			NewRegion(step.Line, step.Line, step.Column, step.Column, "go"),
+		)).WithMessage(NewMessage(step.Description))
+		locations = append(locations, NewThreadFlowLocation(location))
 	}
-	return NewArtifactLocation(filePath)
+	return NewCodeFlow(NewThreadFlow(locations...))
 }
 
 func parseSarifRegion(i *issue.Issue) (*Region, error) {
@@ -229,7 +316,36 @@ func parseSarifRegion(i *issue.Issue) (*Region, error) {
 		}
 	}
 	snippet := NewArtifactContent(code)
-	return NewRegion(startLine, endLine, col, col, "go").WithSnippet(snippet), nil
+	endCol := col
+	if i.EndCol != "" {
+		endCol, err = strconv.Atoi(i.EndCol)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return NewRegion(startLine, endLine, col, endCol, "go").WithSnippet(snippet), nil
+}
+
+// buildSarifFixes converts the fixes of an issue into SARIF fixes, which
+// replace regions of the file of the issue
+func buildSarifFixes(fixes []issue.Fix, file string, rootPaths []string) []*Fix {
+	var sarifFixes []*Fix
+	for _, fix := range fixes {
+		if len(fix.Replacements) == 0 {
+			continue
+		}
+		replacements := make([]*Replacement, 0, len(fix.Replacements))
+		for _, r := range fix.Replacements {
+			replacement := NewReplacement(NewRegion(r.StartLine, r.EndLine, r.StartColumn, r.EndColumn, ""))
+			if r.Text != "" {
+				replacement.WithInsertedContent(NewArtifactContent(r.Text))
+			}
+			replacements = append(replacements, replacement)
+		}
+		artifactChange := NewArtifactChange(NewArtifactLocation(pathutil.RelativeFilePath(file, rootPaths)), replacements...)
+		sarifFixes = append(sarifFixes, NewFix(fix.Description, artifactChange))
+	}
+	return sarifFixes
 }
 
 func getSarifLevel(s string) Level {
@@ -240,15 +356,77 @@ func getSarifLevel(s string) Level {
 		return Error
 	case "HIGH":
 		return Error
+	case "CRITICAL":
+		return Error
 	default:
 		return Note
 	}
 }
 
+// topWeaknesses are the CWE Top 25 Most Dangerous Software Weaknesses which
+// gosec reports.
+var topWeaknesses = map[string]bool{
+	"22":  true,
+	"78":  true,
+	"79":  true,
+	"89":  true,
+	"94":  true,
+	"190": true,
+	"200": true,
+	"287": true,
+	"400": true,
+	"502": true,
+	"798": true,
+	"918": true,
+}
+
+// getSecuritySeverity returns the score, from 0.0 to 10.0, by which GitHub code
+// scanning ranks the alerts as low (< 4.0), medium, high (>= 7.0) or critical
+// (>= 9.0). Within its severity, an issue whose weakness is one of the CWE Top
+// 25 scores higher.
+func getSecuritySeverity(s string, weakness *cwe.Weakness) string {
+	top := weakness != nil && topWeaknesses[weakness.ID]
+	switch s {
+	case "LOW":
+		if top {
+			return "3.9"
+		}
+		return "3.0"
+	case "MEDIUM":
+		if top {
+			return "6.5"
+		}
+		return "5.5"
+	case "HIGH":
+		if top {
+			return "8.8"
+		}
+		return "8.0"
+	case "CRITICAL":
+		if top {
+			return "9.8"
+		}
+		return "9.5"
+	default:
+		return "0.0"
+	}
+}
+
 func buildSarifSuppressions(suppressions []issue.SuppressionInfo) []*Suppression {
 	var sarifSuppressionList []*Suppression
 	for _, s := range suppressions {
-		sarifSuppressionList = append(sarifSuppressionList, NewSuppression(s.Kind, s.Justification))
+		suppression := NewSuppression(s.Kind, s.Justification)
+		if s.Ticket != "" || s.Until != "" {
+			properties := PropertyBag{}
+			if s.Ticket != "" {
+				properties["ticket"] = s.Ticket
+			}
+			if s.Until != "" {
+				properties["until"] = s.Until
+			}
+			suppression = suppression.WithProperties(properties)
+		}
+		sarifSuppressionList = append(sarifSuppressionList, suppression)
 	}
 	return sarifSuppressionList
 }
@@ -62,6 +62,79 @@ var _ = Describe("Sarif Formatter", func() {
 			Expect(validateSarifSchema(sarifReport)).To(Succeed())
 		})
 
+		It("sarif formatted report should contain the code flow of taint findings", func() {
+			taintIssue := []*issue.Issue{
+				{
+					File:       "/home/src/project/main.go",
+					Line:       "9",
+					Col:        "17",
+					RuleID:     "G703",
+					What:       "Path traversal via taint analysis",
+					Confidence: issue.High,
+					Severity:   issue.High,
+					Code:       "9: f, _ := os.Open(name)",
+					Cwe:        issue.GetCweByRule("G703"),
+					Trace: []issue.TraceStep{
+						{File: "/home/src/project/main.go", Line: 13, Column: 37, Function: "main.handler", Description: "parameter r *http.Request"},
+						{File: "/home/src/project/main.go", Line: 9, Column: 17, Function: "main.open", Description: "passed to sink os.Open"},
+					},
+				},
+			}
+			reportInfo := gosec.NewReportInfo(taintIssue, &gosec.Metrics{}, map[string][]gosec.Error{}).WithVersion("v2.7.0")
+			sarifReport, err := sarif.GenerateReport([]string{"/home/src/project"}, reportInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(validateSarifSchema(sarifReport)).To(Succeed())
+
+			codeFlows := sarifReport.Runs[0].Results[0].CodeFlows
+			Expect(codeFlows).To(HaveLen(1))
+			Expect(codeFlows[0].ThreadFlows).To(HaveLen(1))
+			locations := codeFlows[0].ThreadFlows[0].Locations
+			Expect(locations).To(HaveLen(2))
+			Expect(locations[0].Location.Message.Text).To(Equal("parameter r *http.Request"))
+			Expect(locations[0].Location.PhysicalLocation.ArtifactLocation.URI).To(Equal("main.go"))
+			Expect(locations[0].Location.PhysicalLocation.Region.StartLine).To(Equal(13))
+			Expect(locations[1].Location.Message.Text).To(Equal("passed to sink os.Open"))
+		})
+
+		It("sarif formatted report should not include code flows without a trace", func() {
+			plainIssue := []*issue.Issue{
+				{
+					File:       "/home/src/project/test.go",
+					Line:       "1",
+					Col:        "1",
+					RuleID:     "G101",
+					What:       "test",
+					Confidence: issue.High,
+					Severity:   issue.High,
+					Code:       "1: testcode",
+				},
+			}
+			reportInfo := gosec.NewReportInfo(plainIssue, &gosec.Metrics{}, map[string][]gosec.Error{}).WithVersion("v2.7.0")
+			buf := new(bytes.Buffer)
+			Expect(sarif.WriteReport(buf, reportInfo, []string{})).To(Succeed())
+			Expect(buf.String()).NotTo(ContainSubstring("codeFlows"))
+		})
+
+		It("sarif formatted report should rank the critical issues above the high ones", func() {
+			issues := []*issue.Issue{
+				{File: "/home/src/project/test.go", Line: "1", Col: "1", RuleID: "G702", What: "command injection", Confidence: issue.High, Severity: issue.Critical, Code: "1: testcode"},
+				{File: "/home/src/project/test.go", Line: "2", Col: "1", RuleID: "G401", What: "weak hash", Confidence: issue.High, Severity: issue.High, Code: "2: testcode"},
+			}
+			reportInfo := gosec.NewReportInfo(issues, &gosec.Metrics{}, map[string][]gosec.Error{}).WithVersion("v2.7.0")
+			sarifReport, err := sarif.GenerateReport([]string{}, reportInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(validateSarifSchema(sarifReport)).To(Succeed())
+
+			rules := sarifReport.Runs[0].Tool.Driver.Rules
+			Expect(rules).To(HaveLen(2))
+			Expect(rules[0].ID).To(Equal("G401"))
+			Expect((*rules[0].Properties)["security-severity"]).To(Equal("8.0"))
+			Expect(rules[1].ID).To(Equal("G702"))
+			Expect((*rules[1].Properties)["security-severity"]).To(Equal("9.5"))
+			Expect(rules[1].DefaultConfiguration.Level).To(Equal(sarif.Error))
+			Expect(sarifReport.Runs[0].Results[0].Level).To(Equal(sarif.Error))
+		})
+
 		It("sarif formatted report should not include null relationships when CWE is missing (issue #1568)", func() {
 			issueWithoutCWE := []*issue.Issue{
 				{
@@ -127,7 +200,7 @@ var _ = Describe("Sarif Formatter", func() {
 			Expect(output).NotTo(ContainSubstring(`"artifactChanges":null`))
 		})
 
-		It("sarif formatted report should have valid artifactChanges array when autofix exists (issue #1482)", func() {
+		It("sarif formatted report should keep the autofix out of the fixes", func() {
 			ruleID := "G304"
 			cwe := issue.GetCweByRule(ruleID)
 			issueWithAutofix := []*issue.Issue{
@@ -149,21 +222,96 @@ var _ = Describe("Sarif Formatter", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(validateSarifSchema(sarifReport)).To(Succeed())
 
-			// Verify fixes array exists with valid ArtifactChanges
-			Expect(sarifReport.Runs[0].Results[0].Fixes).NotTo(BeNil())
-			Expect(sarifReport.Runs[0].Results[0].Fixes).To(HaveLen(1))
-			Expect(sarifReport.Runs[0].Results[0].Fixes[0].ArtifactChanges).NotTo(BeNil())
-			Expect(sarifReport.Runs[0].Results[0].Fixes[0].ArtifactChanges).To(HaveLen(1))
+			result := sarifReport.Runs[0].Results[0]
+			Expect(result.Fixes).To(BeNil())
+			Expect((*result.Properties)["autofix"]).To(Equal("Consider using os.Root to scope file access"))
+		})
 
-			// Verify JSON output has artifactChanges as array, not null
-			buf := new(bytes.Buffer)
-			err = sarif.WriteReport(buf, reportInfo, []string{})
+		It("sarif formatted report should contain the replacements of the fixes", func() {
+			issues := []*issue.Issue{
+				{
+					File:       "/home/src/project/test.go",
+					Line:       "10",
+					Col:        "5",
+					EndCol:     "38",
+					RuleID:     "G306",
+					What:       "Expect WriteFile permissions to be 0600 or less",
+					Confidence: issue.High,
+					Severity:   issue.Medium,
+					Code:       "10: os.WriteFile(name, data, 0o644)",
+					Cwe:        issue.GetCweByRule("G306"),
+					Fixes: []issue.Fix{{
+						Description:  "Restrict the permissions to 0600",
+						Replacements: []issue.Replacement{{StartLine: 10, StartColumn: 32, EndLine: 10, EndColumn: 37, Text: "0600"}},
+					}},
+				},
+				{
+					File:       "/home/src/project/test.go",
+					Line:       "12",
+					Col:        "2",
+					RuleID:     gosec.UnusedNoSecRuleID,
+					What:       "Unused #nosec directive",
+					Confidence: issue.High,
+					Severity:   issue.Low,
+					Code:       "12: x := 1 // #nosec",
+					Fixes: []issue.Fix{{
+						Description:  "Remove the #nosec directive",
+						Replacements: []issue.Replacement{{StartLine: 12, StartColumn: 9, EndLine: 12, EndColumn: 20}},
+					}},
+				},
+			}
+			reportInfo := gosec.NewReportInfo(issues, &gosec.Metrics{}, map[string][]gosec.Error{}).WithVersion("v2.22.0")
+			sarifReport, err := sarif.GenerateReport([]string{"/home/src/project"}, reportInfo)
 			Expect(err).ShouldNot(HaveOccurred())
-			output := buf.String()
-			Expect(output).NotTo(ContainSubstring(`"artifactChanges":null`))
-			Expect(output).NotTo(ContainSubstring(`"artifactChanges": null`))
-			// Should have fixes with non-null artifactChanges
-			Expect(output).To(ContainSubstring(`"fixes"`))
+			Expect(validateSarifSchema(sarifReport)).To(Succeed())
+
+			results := sarifReport.Runs[0].Results
+			region := results[0].Locations[0].PhysicalLocation.Region
+			Expect(region.StartColumn).To(Equal(5))
+			Expect(region.EndColumn).To(Equal(38))
+
+			Expect(results[0].Fixes).To(HaveLen(1))
+			fix := results[0].Fixes[0]
+			Expect(fix.Description.Text).To(Equal("Restrict the permissions to 0600"))
+			Expect(fix.ArtifactChanges).To(HaveLen(1))
+			Expect(fix.ArtifactChanges[0].ArtifactLocation.URI).To(Equal("test.go"))
+			Expect(fix.ArtifactChanges[0].Replacements).To(HaveLen(1))
+			replacement := fix.ArtifactChanges[0].Replacements[0]
+			Expect(replacement.DeletedRegion).To(Equal(sarif.NewRegion(10, 10, 32, 37, "")))
+			Expect(replacement.InsertedContent.Text).To(Equal("0600"))
+
+			Expect(results[1].Locations[0].PhysicalLocation.Region.EndColumn).To(Equal(2))
+			Expect(results[1].Fixes).To(HaveLen(1))
+			Expect(results[1].Fixes[0].ArtifactChanges[0].Replacements[0].InsertedContent).To(BeNil())
+		})
+
+		It("sarif formatted report should document the rules", func() {
+			issues := []*issue.Issue{
+				{File: "/home/src/project/test.go", Line: "3", Col: "1", RuleID: "X001", What: "custom", Confidence: issue.Low, Severity: issue.Low, Code: "3: testcode"},
+				{File: "/home/src/project/test.go", Line: "2", Col: "1", RuleID: "G401", What: "weak hash", Confidence: issue.High, Severity: issue.Medium, Code: "2: testcode", Cwe: issue.GetCweByRule("G401")},
+				{File: "/home/src/project/test.go", Line: "1", Col: "1", RuleID: "G204", What: "Subprocess launched with variable", Confidence: issue.High, Severity: issue.Medium, Code: "1: testcode", Cwe: issue.GetCweByRule("G204")},
+			}
+			reportInfo := gosec.NewReportInfo(issues, &gosec.Metrics{}, map[string][]gosec.Error{}).WithVersion("v2.22.0")
+			sarifReport, err := sarif.GenerateReport([]string{}, reportInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(validateSarifSchema(sarifReport)).To(Succeed())
+
+			rules := sarifReport.Runs[0].Tool.Driver.Rules
+			Expect(rules).To(HaveLen(3))
+			Expect(rules[0].ID).To(Equal("G204"))
+			Expect(rules[0].HelpURI).To(Equal("https://github.com/securego/gosec/blob/master/RULES.md#g2xx-injection-patterns"))
+			Expect(rules[0].Help.Markdown).To(ContainSubstring("**G204**: Subprocess launched with variable"))
+			Expect(rules[0].Help.Markdown).To(ContainSubstring("| MEDIUM | HIGH |"))
+			Expect(rules[0].Help.Markdown).To(ContainSubstring("[CWE-78: Improper Neutralization of Special Elements used in an OS Command ('OS Command Injection')](https://cwe.mitre.org/data/definitions/78.html)"))
+			Expect((*rules[0].Properties)["security-severity"]).To(Equal("6.5"))
+
+			Expect(rules[1].ID).To(Equal("G401"))
+			Expect(rules[1].HelpURI).To(Equal("https://github.com/securego/gosec/blob/master/RULES.md#g4xx-crypto-and-protocol-security"))
+			Expect((*rules[1].Properties)["security-severity"]).To(Equal("5.5"))
+
+			Expect(rules[2].ID).To(Equal("X001"))
+			Expect(rules[2].HelpURI).To(BeEmpty())
+			Expect(rules[2].Help.Markdown).NotTo(ContainSubstring("documentation"))
 		})
 
 		It("sarif formatted report should contain the suppressed results", func() {
@@ -202,6 +350,66 @@ var _ = Describe("Sarif Formatter", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(validateSarifSchema(sarifReport)).To(Succeed())
 		})
+		It("sarif formatted report should contain the ticket and the date of the suppressions", func() {
+			suppressedIssue := issue.Issue{
+				File:       "/home/src/project/test.go",
+				Line:       "1",
+				Col:        "1",
+				RuleID:     "G101",
+				What:       "test",
+				Confidence: issue.High,
+				Severity:   issue.High,
+				Code:       "1: testcode",
+				Cwe:        issue.GetCweByRule("G101"),
+				Suppressions: []issue.SuppressionInfo{
+					{
+						Kind:          "inSource",
+						Justification: "until=2099-12-31 ticket=SEC-123 legacy",
+						Ticket:        "SEC-123",
+						Until:         "2099-12-31",
+					},
+				},
+			}
+
+			reportInfo := gosec.NewReportInfo([]*issue.Issue{&suppressedIssue}, &gosec.Metrics{}, map[string][]gosec.Error{}).WithVersion("v2.7.0")
+			sarifReport, err := sarif.GenerateReport([]string{}, reportInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+			suppression := sarifReport.Runs[0].Results[0].Suppressions[0]
+			Expect(*suppression.Properties).To(Equal(sarif.PropertyBag{"ticket": "SEC-123", "until": "2099-12-31"}))
+			Expect(validateSarifSchema(sarifReport)).To(Succeed())
+		})
+		It("sarif formatted report should contain the expired suppressions of the results", func() {
+			ruleID := "G401"
+			expiredIssue := issue.Issue{
+				File:       "/home/src/project/test.go",
+				Line:       "10",
+				Col:        "7",
+				RuleID:     ruleID,
+				What:       "Use of weak cryptographic primitive",
+				Confidence: issue.High,
+				Severity:   issue.Medium,
+				Code:       "10: h := md5.New()\n",
+				Cwe:        issue.GetCweByRule(ruleID),
+				ExpiredSuppressions: []issue.SuppressionInfo{
+					{
+						Kind:          "inSource",
+						Justification: "until=2020-01-31 ticket=SEC-123 legacy",
+						Ticket:        "SEC-123",
+						Until:         "2020-01-31",
+					},
+				},
+			}
+
+			reportInfo := gosec.NewReportInfo([]*issue.Issue{&expiredIssue}, &gosec.Metrics{}, map[string][]gosec.Error{}).WithVersion("v2.7.0")
+			sarifReport, err := sarif.GenerateReport([]string{}, reportInfo)
+			Expect(err).ShouldNot(HaveOccurred())
+			result := sarifReport.Runs[0].Results[0]
+			Expect(result.Suppressions).To(BeEmpty())
+			expired := (*result.Properties)["expiredSuppressions"].([]*sarif.Suppression)
+			Expect(expired).To(HaveLen(1))
+			Expect(*expired[0].Properties).To(Equal(sarif.PropertyBag{"ticket": "SEC-123", "until": "2020-01-31"}))
+			Expect(validateSarifSchema(sarifReport)).To(Succeed())
+		})
 		It("sarif formatted report should contain the formatted one line code snippet", func() {
 			ruleID := "G101"
 			cwe := issue.GetCweByRule(ruleID)
@@ -26,6 +26,12 @@ func NewIssue(ruleID string, primaryLocation *Location, effortMinutes int) *Issu
 	}
 }
 
+// WithKey defines the key identifying the issue across the analyses
+func (i *Issue) WithKey(key string) *Issue {
+	i.Key = key
+	return i
+}
+
 // NewImpact instantiate an Impact.
 func NewImpact(softwareQuality string, severity string) *Impact {
 	return &Impact{
@@ -58,7 +58,7 @@ func GenerateReport(rootPaths []string, data *gosec.ReportInfo) (*Report, error)
 			si.Rules = append(si.Rules, newRule)
 		}
 
-		s := NewIssue(issue.RuleID, primaryLocation, EffortMinutes)
+		s := NewIssue(issue.RuleID, primaryLocation, EffortMinutes).WithKey(issue.Fingerprint)
 		si.Issues = append(si.Issues, s)
 	}
 	return si, nil
@@ -98,6 +98,8 @@ func getImpactSeverity(s string) string {
 		return "MEDIUM"
 	case "HIGH":
 		return "HIGH"
+	case "CRITICAL":
+		return "BLOCKER"
 	default:
 		return "INFO"
 	}
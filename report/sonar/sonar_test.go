@@ -73,6 +73,22 @@ var _ = Describe("Sonar Formatter", func() {
 			Expect(*issues).To(Equal(*want))
 		})
 
+		It("it should report the critical issues as blockers", func() {
+			data := &gosec.ReportInfo{
+				Errors: map[string][]gosec.Error{},
+				Issues: []*issue.Issue{
+					{Severity: issue.High, RuleID: "G702", What: "test", File: "/home/src/project/a.go", Line: "1"},
+					{Severity: issue.Critical, RuleID: "G702", What: "test", File: "/home/src/project/b.go", Line: "1"},
+				},
+				Stats: &gosec.Metrics{},
+			}
+
+			report, err := sonar.GenerateReport([]string{"/home/src/project"}, data)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(report.Rules).To(HaveLen(1))
+			Expect(report.Rules[0].Impacts).To(Equal([]*sonar.Impact{{SoftwareQuality: "SECURITY", Severity: "BLOCKER"}}))
+		})
+
 		It("it should enrich rules with metadata when available", func() {
 			data := &gosec.ReportInfo{
 				Errors: map[string][]gosec.Error{},
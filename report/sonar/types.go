@@ -17,6 +17,7 @@ type Location struct {
 
 // Issue defines a sonar issue
 type Issue struct {
+	Key                string      `json:"key,omitempty"`
 	RuleID             string      `json:"ruleId"`
 	PrimaryLocation    *Location   `json:"primaryLocation"`
 	EffortMinutes      int         `json:"effortMinutes"`
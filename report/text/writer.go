@@ -17,9 +17,10 @@ import (
 )
 
 var (
-	errorTheme   = color.New(color.FgLightWhite, color.BgRed)
-	warningTheme = color.New(color.FgBlack, color.BgYellow)
-	defaultTheme = color.New(color.FgWhite, color.BgBlack)
+	criticalTheme = color.New(color.FgLightWhite, color.BgMagenta, color.OpBold)
+	errorTheme    = color.New(color.FgLightWhite, color.BgRed)
+	warningTheme  = color.New(color.FgBlack, color.BgYellow)
+	defaultTheme  = color.New(color.FgWhite, color.BgBlack)
 
 	//go:embed template.txt
 	templateContent string
@@ -27,10 +28,7 @@ var (
 
 // WriteReport write a (colorized) report in text format
 func WriteReport(w io.Writer, data *gosec.ReportInfo, enableColor bool) error {
-	t, e := template.
-		New("gosec").
-		Funcs(plainTextFuncMap(enableColor)).
-		Parse(templateContent)
+	t, e := ParseTemplate(templateContent, enableColor)
 	if e != nil {
 		return e
 	}
@@ -38,6 +36,15 @@ func WriteReport(w io.Writer, data *gosec.ReportInfo, enableColor bool) error {
 	return t.Execute(w, data)
 }
 
+// ParseTemplate parses a text/template of a report, with the functions of the
+// text format: highlight, danger, notice, success and printCode.
+func ParseTemplate(content string, enableColor bool) (*template.Template, error) {
+	return template.
+		New("gosec").
+		Funcs(plainTextFuncMap(enableColor)).
+		Parse(content)
+}
+
 func plainTextFuncMap(enableColor bool) template.FuncMap {
 	if enableColor {
 		return template.FuncMap{
@@ -67,6 +74,8 @@ func highlight(t string, s issue.Score, ignored bool) string {
 		return defaultTheme.Sprint(t)
 	}
 	switch s {
+	case issue.Critical:
+		return criticalTheme.Sprint(t)
 	case issue.High:
 		return errorTheme.Sprint(t)
 	case issue.Medium:
@@ -94,6 +94,27 @@ var _ = Describe("Text Writer", func() {
 			Expect(result).To(ContainSubstring("5"))
 		})
 
+		It("should report the functions truncated by the taint analysis", func() {
+			data := &gosec.ReportInfo{
+				Errors: map[string][]gosec.Error{},
+				Issues: []*issue.Issue{},
+				Stats: &gosec.Metrics{
+					NumFiles:     1,
+					NumTruncated: &gosec.TruncationMetrics{Depth: 2, Budget: 1},
+				},
+			}
+
+			buf := new(bytes.Buffer)
+			err := text.WriteReport(buf, data, false)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).To(ContainSubstring("Truncated : 3 functions (depth: 2, visited values: 0, time budget: 1)\n  Issues"))
+
+			buf.Reset()
+			data.Stats.NumTruncated = nil
+			Expect(text.WriteReport(buf, data, false)).To(Succeed())
+			Expect(buf.String()).NotTo(ContainSubstring("Truncated"))
+		})
+
 		It("should support color output when enabled", func() {
 			data := &gosec.ReportInfo{
 				Errors: map[string][]gosec.Error{},
@@ -121,6 +142,55 @@ var _ = Describe("Text Writer", func() {
 			Expect(result).ToNot(BeEmpty())
 		})
 
+		It("should display the critical severity", func() {
+			data := &gosec.ReportInfo{
+				Errors: map[string][]gosec.Error{},
+				Issues: []*issue.Issue{
+					{
+						File:       "/test.go",
+						Line:       "1",
+						Col:        "1",
+						RuleID:     "G702",
+						What:       "Command injection",
+						Confidence: issue.High,
+						Severity:   issue.Critical,
+						Code:       "code",
+					},
+				},
+				Stats: &gosec.Metrics{},
+			}
+
+			buf := new(bytes.Buffer)
+			Expect(text.WriteReport(buf, data, true)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("Severity: CRITICAL"))
+		})
+
+		It("should display the expired suppressions", func() {
+			data := &gosec.ReportInfo{
+				Errors: map[string][]gosec.Error{},
+				Issues: []*issue.Issue{
+					{
+						File:       "/test.go",
+						Line:       "1",
+						Col:        "1",
+						RuleID:     "G401",
+						What:       "Use of weak cryptographic primitive",
+						Confidence: issue.High,
+						Severity:   issue.Medium,
+						Code:       "code",
+						ExpiredSuppressions: []issue.SuppressionInfo{
+							{Kind: "inSource", Justification: "until=2020-01-31 ticket=SEC-123", Ticket: "SEC-123", Until: "2020-01-31"},
+						},
+					},
+				},
+				Stats: &gosec.Metrics{},
+			}
+
+			buf := new(bytes.Buffer)
+			Expect(text.WriteReport(buf, data, false)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("Expired suppression: until 2020-01-31, ticket SEC-123\nAutofix: "))
+		})
+
 		It("should format code snippets correctly", func() {
 			data := &gosec.ReportInfo{
 				Errors: map[string][]gosec.Error{},
@@ -56,7 +56,13 @@ func (r *filePermissions) Match(n ast.Node, c *gosec.Context) (*issue.Issue, err
 	for _, pkg := range r.pkgs {
 		if callexpr, matched := gosec.MatchCallByPackage(n, c, pkg, r.calls...); matched {
 			modeArg := callexpr.Args[len(callexpr.Args)-1]
-			if mode, err := gosec.GetInt(modeArg); err == nil && !modeIsSubset(mode, r.mode) || isOsPerm(modeArg) {
+			if mode, err := gosec.GetInt(modeArg); err == nil && !modeIsSubset(mode, r.mode) {
+				iss := c.NewIssue(n, r.ID(), r.What, r.Severity, r.Confidence)
+				if _, ok := modeArg.(*ast.BasicLit); ok {
+					iss.WithFixes(r.restrictMode(modeArg, mode, c))
+				}
+				return iss, nil
+			} else if isOsPerm(modeArg) {
 				return c.NewIssue(n, r.ID(), r.What, r.Severity, r.Confidence), nil
 			}
 		}
@@ -64,6 +70,16 @@ func (r *filePermissions) Match(n ast.Node, c *gosec.Context) (*issue.Issue, err
 	return nil, nil
 }
 
+// restrictMode returns the fix which removes from the literal mode the
+// permissions outside of the configured mode.
+func (r *filePermissions) restrictMode(modeArg ast.Expr, mode int64, c *gosec.Context) issue.Fix {
+	text := fmt.Sprintf("%#o", mode&r.mode)
+	return issue.Fix{
+		Description:  fmt.Sprintf("Restrict the permissions to %s", text),
+		Replacements: []issue.Replacement{issue.NewReplacement(c.GetFileAtNodePos(modeArg), modeArg, text)},
+	}
+}
+
 // isOsPerm check if the provide ast node contains a os.PermMode symbol
 func isOsPerm(n ast.Node) bool {
 	if node, ok := n.(*ast.SelectorExpr); ok {
@@ -96,7 +96,7 @@ func Generate(trackSuppressions bool, filters ...RuleFilter) RuleList {
 
 		// crypto
 		{"G401", "Detect the usage of MD5 or SHA1", NewUsesWeakCryptographyHash},
-		{"G402", "Look for bad TLS connection settings", NewIntermediateTLSCheck},
+		{"G402", "Look for bad TLS connection settings", NewTLSCheck},
 		{"G403", "Ensure minimum RSA key length of 2048 bits", NewWeakKeyStrength},
 		{"G404", "Insecure random number source (rand)", NewWeakRandCheck},
 		{"G405", "Detect the usage of DES or RC4", NewUsesWeakCryptographyEncryption},
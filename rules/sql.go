@@ -27,12 +27,13 @@ import (
 
 type sqlStatement struct {
 	issue.MetaData
-	gosec.CallList
 
 	// Contains a list of patterns which must all match for the rule to match.
 	patterns []*regexp.Regexp
 }
 
+// sqlCallIdents maps the types, or package paths for functions, of the SQL
+// APIs to the methods taking raw SQL and the index of their SQL argument.
 var sqlCallIdents = map[string]map[string]int{
 	"*database/sql.Conn": {
 		"ExecContext":     1,
@@ -60,6 +61,82 @@ var sqlCallIdents = map[string]map[string]int{
 		"Prepare":         0,
 		"PrepareContext":  1,
 	},
+	"*github.com/jmoiron/sqlx.DB": sqlxCallIdents,
+	"*github.com/jmoiron/sqlx.Tx": sqlxCallIdents,
+	"*github.com/jackc/pgx/v5.Conn": {
+		"Exec":     1,
+		"Query":    1,
+		"QueryRow": 1,
+	},
+	"*github.com/jackc/pgx/v5/pgxpool.Pool": {
+		"Exec":     1,
+		"Query":    1,
+		"QueryRow": 1,
+	},
+	"github.com/jackc/pgx/v5.Tx": {
+		"Exec":     1,
+		"Query":    1,
+		"QueryRow": 1,
+	},
+	"*gorm.io/gorm.DB": {
+		"Raw":  0,
+		"Exec": 0,
+	},
+}
+
+// sqlFragmentCallIdents maps the SQL APIs taking a fragment of SQL, such as
+// the condition of a query builder, like sqlCallIdents. Fragments do not need
+// to contain SQL keywords to be reported.
+var sqlFragmentCallIdents = map[string]map[string]int{
+	"*gorm.io/gorm.DB": {
+		"Where": 0,
+		"Or":    0,
+		"Not":   0,
+		"Order": 0,
+	},
+	"github.com/Masterminds/squirrel": {
+		"Expr": 0,
+	},
+	"github.com/Masterminds/squirrel.SelectBuilder": squirrelCallIdents,
+	"github.com/Masterminds/squirrel.UpdateBuilder": squirrelCallIdents,
+	"github.com/Masterminds/squirrel.DeleteBuilder": squirrelCallIdents,
+}
+
+// sqlxCallIdents holds the methods of the sqlx DB and Tx, which include the
+// methods of the embedded database/sql types.
+var sqlxCallIdents = map[string]int{
+	"Exec":              0,
+	"ExecContext":       1,
+	"Query":             0,
+	"QueryContext":      1,
+	"QueryRow":          0,
+	"QueryRowContext":   1,
+	"Prepare":           0,
+	"PrepareContext":    1,
+	"Queryx":            0,
+	"QueryxContext":     1,
+	"QueryRowx":         0,
+	"QueryRowxContext":  1,
+	"MustExec":          0,
+	"MustExecContext":   1,
+	"Select":            1,
+	"SelectContext":     2,
+	"Get":               1,
+	"GetContext":        2,
+	"NamedExec":         0,
+	"NamedExecContext":  1,
+	"NamedQuery":        0,
+	"NamedQueryContext": 1,
+	"Preparex":          0,
+	"PreparexContext":   1,
+}
+
+// squirrelCallIdents holds the methods of the squirrel builders taking raw SQL.
+var squirrelCallIdents = map[string]int{
+	"Where":   0,
+	"Prefix":  0,
+	"Suffix":  0,
+	"OrderBy": 0,
 }
 
 var (
@@ -67,25 +144,99 @@ var (
 	sqlFormatRegexp = regexp.MustCompile("%[^bdoxXfFp]")
 )
 
-// findQueryArg locates the argument taking raw SQL.
-func findQueryArg(call *ast.CallExpr, ctx *gosec.Context) (ast.Expr, error) {
-	typeName, fnName, err := gosec.GetCallInfo(call, ctx)
+// sqlCallInfo resolves the type of the receiver, or the package path of the
+// function, and the name of a called function from the type information, which
+// also resolves calls on fields and chained calls such as the query builders.
+func sqlCallInfo(call *ast.CallExpr, ctx *gosec.Context) (string, string, error) {
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && ctx.Info != nil {
+		if selection, ok := ctx.Info.Selections[sel]; ok && selection.Kind() == types.MethodVal {
+			return selection.Recv().String(), sel.Sel.Name, nil
+		}
+		if fn, ok := ctx.Info.Uses[sel.Sel].(*types.Func); ok && fn.Pkg() != nil {
+			return fn.Pkg().Path(), fn.Name(), nil
+		}
+	}
+	return gosec.GetCallInfo(call, ctx)
+}
+
+// sqlQueryArgIndex returns the index of the argument taking raw SQL, and
+// whether it is a SQL fragment, if the call is a call to a known SQL API.
+func sqlQueryArgIndex(call *ast.CallExpr, ctx *gosec.Context) (int, bool, bool) {
+	typeName, fnName, err := sqlCallInfo(call, ctx)
 	if err != nil {
-		return nil, err
+		return 0, false, false
+	}
+	if i, ok := lookupSQLCall(sqlCallIdents, typeName, fnName); ok {
+		return i, false, true
 	}
+	if i, ok := lookupSQLCall(sqlFragmentCallIdents, typeName, fnName); ok {
+		return i, true, true
+	}
+	return 0, false, false
+}
 
-	if methods, ok := sqlCallIdents[typeName]; ok {
-		if i, ok := methods[fnName]; ok && i < len(call.Args) {
-			return call.Args[i], nil
+func lookupSQLCall(idents map[string]map[string]int, typeName, fnName string) (int, bool) {
+	methods, ok := idents[typeName]
+	if !ok {
+		methods, ok = idents["*"+typeName]
+	}
+	if !ok {
+		return 0, false
+	}
+	i, ok := methods[fnName]
+	return i, ok
+}
+
+// sqlCallsInStmt returns the calls to SQL APIs made by an assignment or an
+// expression statement. The chains of method calls, such as
+// db.Where(query).Find(&users) or db.QueryRow(query).Scan(&id), are searched
+// from the outermost call.
+func sqlCallsInStmt(n ast.Node, ctx *gosec.Context) []*ast.CallExpr {
+	var exprs []ast.Expr
+	switch stmt := n.(type) {
+	case *ast.AssignStmt:
+		exprs = stmt.Rhs
+	case *ast.ExprStmt:
+		exprs = []ast.Expr{stmt.X}
+	}
+
+	var calls []*ast.CallExpr
+	for _, expr := range exprs {
+		for {
+			call, ok := expr.(*ast.CallExpr)
+			if !ok {
+				break
+			}
+			if _, _, ok := sqlQueryArgIndex(call, ctx); ok {
+				calls = append(calls, call)
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				break
+			}
+			expr = sel.X
 		}
 	}
+	return calls
+}
 
-	return nil, fmt.Errorf("SQL argument index not found for %s.%s", typeName, fnName)
+// findQueryArg locates the argument taking raw SQL, and reports whether it is
+// a SQL fragment.
+func findQueryArg(call *ast.CallExpr, ctx *gosec.Context) (ast.Expr, bool, error) {
+	if i, fragment, ok := sqlQueryArgIndex(call, ctx); ok && i < len(call.Args) {
+		return call.Args[i], fragment, nil
+	}
+	typeName, fnName, _ := sqlCallInfo(call, ctx)
+	return nil, false, fmt.Errorf("SQL argument index not found for %s.%s", typeName, fnName)
 }
 
-// MatchPatterns checks if the string matches all required SQL patterns.
-func (s *sqlStatement) MatchPatterns(str string) bool {
+// MatchPatterns checks if the string matches all required SQL patterns. A SQL
+// fragment does not need to contain SQL keywords.
+func (s *sqlStatement) MatchPatterns(str string, fragment bool) bool {
 	for _, pattern := range s.patterns {
+		if fragment && pattern == sqlRegexp {
+			continue
+		}
 		if !gosec.RegexMatchWithCache(pattern, str) {
 			return false
 		}
@@ -119,7 +270,7 @@ func (s *sqlStrConcat) findInjectionInBranch(ctx *gosec.Context, branch []ast.Ex
 
 // checkQuery verifies if the query parameter involves risky string concatenation.
 func (s *sqlStrConcat) checkQuery(call *ast.CallExpr, ctx *gosec.Context) (*issue.Issue, error) {
-	query, err := findQueryArg(call, ctx)
+	query, fragment, err := findQueryArg(call, ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -128,7 +279,7 @@ func (s *sqlStrConcat) checkQuery(call *ast.CallExpr, ctx *gosec.Context) (*issu
 	if be, ok := query.(*ast.BinaryExpr); ok {
 		operands := gosec.GetBinaryExprOperands(be)
 		if start, ok := operands[0].(*ast.BasicLit); ok {
-			if str, e := gosec.GetString(start); e == nil && s.MatchPatterns(str) {
+			if str, e := gosec.GetString(start); e == nil && s.MatchPatterns(str, fragment) {
 				for _, op := range operands[1:] {
 					if gosec.TryResolve(op, ctx) {
 						continue
@@ -213,7 +364,7 @@ func (s *sqlStrConcat) checkQuery(call *ast.CallExpr, ctx *gosec.Context) (*issu
 		// Check for SQL patterns in initial values
 		hasSQLPattern := false
 		for _, val := range declRHS {
-			if str, err := gosec.GetStringRecursive(val); err == nil && s.MatchPatterns(str) {
+			if str, err := gosec.GetStringRecursive(val); err == nil && s.MatchPatterns(str, fragment) {
 				hasSQLPattern = true
 				break
 			}
@@ -279,16 +430,9 @@ func (s *sqlStrConcat) checkQuery(call *ast.CallExpr, ctx *gosec.Context) (*issu
 
 // Match looks for SQL execution calls and checks for concatenation issues.
 func (s *sqlStrConcat) Match(n ast.Node, ctx *gosec.Context) (*issue.Issue, error) {
-	switch stmt := n.(type) {
-	case *ast.AssignStmt:
-		for _, expr := range stmt.Rhs {
-			if call, ok := expr.(*ast.CallExpr); ok && s.ContainsCallExpr(expr, ctx) != nil {
-				return s.checkQuery(call, ctx)
-			}
-		}
-	case *ast.ExprStmt:
-		if call, ok := stmt.X.(*ast.CallExpr); ok && s.ContainsCallExpr(call, ctx) != nil {
-			return s.checkQuery(call, ctx)
+	for _, call := range sqlCallsInStmt(n, ctx) {
+		if iss, err := s.checkQuery(call, ctx); iss != nil || err != nil {
+			return iss, err
 		}
 	}
 	return nil, nil
@@ -302,20 +446,12 @@ func NewSQLStrConcat(id string, _ gosec.Config) (gosec.Rule, []ast.Node) {
 				sqlRegexp,
 			},
 			MetaData: issue.NewMetaData(id, "SQL string concatenation", issue.Medium, issue.High),
-			CallList: gosec.NewCallList(),
 		},
 	}
-
-	for typ, methods := range sqlCallIdents {
-		for method := range methods {
-			rule.Add(typ, method)
-		}
-	}
 	return rule, []ast.Node{(*ast.AssignStmt)(nil), (*ast.ExprStmt)(nil)}
 }
 
 type sqlStrFormat struct {
-	gosec.CallList
 	sqlStatement
 	fmtCalls      gosec.CallList
 	noIssue       gosec.CallList
@@ -324,7 +460,7 @@ type sqlStrFormat struct {
 
 // checkQuery verifies if the query parameter involves risky formatting.
 func (s *sqlStrFormat) checkQuery(call *ast.CallExpr, ctx *gosec.Context) (*issue.Issue, error) {
-	query, err := findQueryArg(call, ctx)
+	query, fragment, err := findQueryArg(call, ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -364,7 +500,7 @@ func (s *sqlStrFormat) checkQuery(call *ast.CallExpr, ctx *gosec.Context) (*issu
 					if expr == nil {
 						continue
 					}
-					if iss := s.checkFormatting(expr, ctx); iss != nil {
+					if iss := s.checkFormatting(expr, fragment, ctx); iss != nil {
 						foundIssue = iss
 						return false // Stop entire inspection
 					}
@@ -379,7 +515,7 @@ func (s *sqlStrFormat) checkQuery(call *ast.CallExpr, ctx *gosec.Context) (*issu
 }
 
 // checkFormatting checks if a formatting call builds a risky SQL query.
-func (s *sqlStrFormat) checkFormatting(n ast.Node, ctx *gosec.Context) *issue.Issue {
+func (s *sqlStrFormat) checkFormatting(n ast.Node, fragment bool, ctx *gosec.Context) *issue.Issue {
 	// argIndex changes the function argument which gets matched to the regex
 	argIndex := 0
 	if node := s.fmtCalls.ContainsPkgCallExpr(n, ctx, false); node != nil {
@@ -419,7 +555,7 @@ func (s *sqlStrFormat) checkFormatting(n ast.Node, ctx *gosec.Context) *issue.Is
 			}
 		}
 
-		if s.MatchPatterns(formatter) {
+		if s.MatchPatterns(formatter, fragment) {
 			return ctx.NewIssue(n, s.ID(), s.What, s.Severity, s.Confidence)
 		}
 	}
@@ -428,23 +564,9 @@ func (s *sqlStrFormat) checkFormatting(n ast.Node, ctx *gosec.Context) *issue.Is
 
 // Match looks for SQL calls involving formatted strings.
 func (s *sqlStrFormat) Match(n ast.Node, ctx *gosec.Context) (*issue.Issue, error) {
-	switch stmt := n.(type) {
-	case *ast.AssignStmt:
-		for _, expr := range stmt.Rhs {
-			if call, ok := expr.(*ast.CallExpr); ok {
-				if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-					if sqlCall, ok := sel.X.(*ast.CallExpr); ok && s.ContainsCallExpr(sqlCall, ctx) != nil {
-						return s.checkQuery(sqlCall, ctx)
-					}
-				}
-				if s.ContainsCallExpr(expr, ctx) != nil {
-					return s.checkQuery(call, ctx)
-				}
-			}
-		}
-	case *ast.ExprStmt:
-		if call, ok := stmt.X.(*ast.CallExpr); ok && s.ContainsCallExpr(call, ctx) != nil {
-			return s.checkQuery(call, ctx)
+	for _, call := range sqlCallsInStmt(n, ctx) {
+		if iss, err := s.checkQuery(call, ctx); iss != nil || err != nil {
+			return iss, err
 		}
 	}
 	return nil, nil
@@ -453,7 +575,6 @@ func (s *sqlStrFormat) Match(n ast.Node, ctx *gosec.Context) (*issue.Issue, erro
 // NewSQLStrFormat creates a rule for detecting SQL string formatting.
 func NewSQLStrFormat(id string, _ gosec.Config) (gosec.Rule, []ast.Node) {
 	rule := &sqlStrFormat{
-		CallList:      gosec.NewCallList(),
 		fmtCalls:      gosec.NewCallList(),
 		noIssue:       gosec.NewCallList(),
 		noIssueQuoted: gosec.NewCallList(),
@@ -465,11 +586,6 @@ func NewSQLStrFormat(id string, _ gosec.Config) (gosec.Rule, []ast.Node) {
 			MetaData: issue.NewMetaData(id, "SQL string formatting", issue.Medium, issue.High),
 		},
 	}
-	for typ, methods := range sqlCallIdents {
-		for method := range methods {
-			rule.Add(typ, method)
-		}
-	}
 	rule.fmtCalls.AddAll("fmt", "Sprint", "Sprintf", "Sprintln", "Fprintf")
 	rule.noIssue.AddAll("os", "Stdout", "Stderr")
 	rule.noIssueQuoted.Add("github.com/lib/pq", "QuoteIdentifier")
@@ -301,3 +301,17 @@ func (t *insecureConfigTLS) Match(n ast.Node, c *gosec.Context) (*issue.Issue, e
 
 	return nil, nil
 }
+
+// NewTLSCheck creates the check of the TLS configuration level selected by the
+// "level" setting of the rule: "modern", "intermediate" (default) or "old".
+func NewTLSCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	if settings, ok := conf[id].(map[string]interface{}); ok {
+		switch settings["level"] {
+		case "modern":
+			return NewModernTLSCheck(id, conf)
+		case "old":
+			return NewOldTLSCheck(id, conf)
+		}
+	}
+	return NewIntermediateTLSCheck(id, conf)
+}
@@ -0,0 +1,159 @@
+package gosec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/securego/gosec/v2/issue"
+)
+
+// ScoreOverride changes the severity or the confidence of the issues of a
+// rule, e.g. to follow the policy of a team. An override without paths
+// applies to all the files, otherwise only to those matching one of its globs.
+type ScoreOverride struct {
+	Rule       string   `json:"rule"`                 // Rule ID
+	Paths      []string `json:"paths,omitempty"`      // Globs of the file paths, relative to the current directory
+	Severity   string   `json:"severity,omitempty"`   // New severity: low, medium, high or critical
+	Confidence string   `json:"confidence,omitempty"` // New confidence: low, medium or high
+}
+
+// compiledScoreOverride is a ScoreOverride ready to be matched
+type compiledScoreOverride struct {
+	rule          string
+	paths         []*regexp.Regexp
+	severity      issue.Score
+	confidence    issue.Score
+	setSeverity   bool
+	setConfidence bool
+}
+
+// ScoreOverrides applies the score overrides of a configuration to the issues
+type ScoreOverrides struct {
+	overrides []compiledScoreOverride
+	baseDir   string
+}
+
+// NewScoreOverrides compiles the given overrides. Returns an error if one of
+// them has no rule ID, no score or an invalid score.
+func NewScoreOverrides(overrides []ScoreOverride) (*ScoreOverrides, error) {
+	if len(overrides) == 0 {
+		return &ScoreOverrides{}, nil
+	}
+
+	compiled := make([]compiledScoreOverride, 0, len(overrides))
+	for i, override := range overrides {
+		if override.Rule == "" {
+			return nil, fmt.Errorf("%s[%d]: rule cannot be empty", ScoreOverridesKey, i)
+		}
+		if override.Severity == "" && override.Confidence == "" {
+			return nil, fmt.Errorf("%s[%d]: severity or confidence is required", ScoreOverridesKey, i)
+		}
+
+		c := compiledScoreOverride{rule: override.Rule}
+		if override.Severity != "" {
+			score, err := issue.ParseScore(override.Severity)
+			if err != nil {
+				return nil, fmt.Errorf("%s[%d]: severity: %w", ScoreOverridesKey, i, err)
+			}
+			c.severity, c.setSeverity = score, true
+		}
+		if override.Confidence != "" {
+			score, err := issue.ParseConfidence(override.Confidence)
+			if err != nil {
+				return nil, fmt.Errorf("%s[%d]: confidence: %w", ScoreOverridesKey, i, err)
+			}
+			c.confidence, c.setConfidence = score, true
+		}
+		for _, glob := range override.Paths {
+			if glob == "" {
+				return nil, fmt.Errorf("%s[%d]: path cannot be empty", ScoreOverridesKey, i)
+			}
+			c.paths = append(c.paths, globRegexp(strings.TrimPrefix(glob, "./")))
+		}
+		compiled = append(compiled, c)
+	}
+
+	baseDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	return &ScoreOverrides{overrides: compiled, baseDir: baseDir}, nil
+}
+
+// Apply changes the scores of the issue according to the overrides matching
+// its rule and file. When several overrides set a score, the last one wins.
+func (o *ScoreOverrides) Apply(iss *issue.Issue) {
+	if o == nil || len(o.overrides) == 0 || iss == nil {
+		return
+	}
+
+	path := o.relativePath(iss.File)
+	for _, override := range o.overrides {
+		if override.rule != iss.RuleID || !override.matches(path) {
+			continue
+		}
+		if override.setSeverity {
+			iss.Severity = override.severity
+		}
+		if override.setConfidence {
+			iss.Confidence = override.confidence
+		}
+	}
+}
+
+// relativePath returns the slash separated path of a file relative to the
+// base directory, or its absolute path when it is outside of it.
+func (o *ScoreOverrides) relativePath(file string) string {
+	if abs, err := filepath.Abs(file); err == nil {
+		file = abs
+		if rel, err := filepath.Rel(o.baseDir, abs); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			file = rel
+		}
+	}
+	return filepath.ToSlash(file)
+}
+
+func (c compiledScoreOverride) matches(path string) bool {
+	if len(c.paths) == 0 {
+		return true
+	}
+	for _, glob := range c.paths {
+		if glob.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// globRegexp converts a path glob into a regular expression. "*" and "?" do
+// not match the slashes, while "**" matches any number of directories.
+func globRegexp(glob string) *regexp.Regexp {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			pattern.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			pattern.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			pattern.WriteString("[^/]*")
+		case glob[i] == '?':
+			pattern.WriteString("[^/]")
+		default:
+			literal := strings.IndexAny(glob[i:], "*?")
+			if literal < 0 {
+				literal = len(glob) - i
+			}
+			pattern.WriteString(regexp.QuoteMeta(glob[i : i+literal]))
+			i += literal - 1
+		}
+	}
+	pattern.WriteString("$")
+	return regexp.MustCompile(pattern.String())
+}
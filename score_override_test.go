@@ -0,0 +1,97 @@
+package gosec_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+)
+
+var _ = Describe("ScoreOverrides", func() {
+	var wd string
+
+	newIssue := func(ruleID, file string) *issue.Issue {
+		return &issue.Issue{RuleID: ruleID, File: filepath.Join(wd, file), Severity: issue.High, Confidence: issue.High}
+	}
+
+	BeforeEach(func() {
+		var err error
+		wd, err = os.Getwd()
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should override the scores of a rule in all the files", func() {
+		overrides, err := gosec.NewScoreOverrides([]gosec.ScoreOverride{{Rule: "G404", Severity: "low"}})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		iss := newIssue("G404", "main.go")
+		overrides.Apply(iss)
+		Expect(iss.Severity).To(Equal(issue.Low))
+		Expect(iss.Confidence).To(Equal(issue.High))
+
+		other := newIssue("G104", "main.go")
+		overrides.Apply(other)
+		Expect(other.Severity).To(Equal(issue.High))
+	})
+
+	It("should only override the scores in the files matching the globs", func() {
+		overrides, err := gosec.NewScoreOverrides([]gosec.ScoreOverride{
+			{Rule: "G104", Paths: []string{"./payments/**"}, Severity: "MEDIUM"},
+			{Rule: "G404", Paths: []string{"**/*_test.go"}, Confidence: "low"},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		for file, expected := range map[string]issue.Score{
+			"payments/card.go":          issue.Medium,
+			"payments/api/v1/refund.go": issue.Medium,
+			"billing/payments.go":       issue.High,
+		} {
+			iss := newIssue("G104", file)
+			overrides.Apply(iss)
+			Expect(iss.Severity).To(Equal(expected), file)
+		}
+		for file, expected := range map[string]issue.Score{
+			"rand_test.go":          issue.Low,
+			"internal/rand_test.go": issue.Low,
+			"internal/rand.go":      issue.High,
+		} {
+			iss := newIssue("G404", file)
+			overrides.Apply(iss)
+			Expect(iss.Confidence).To(Equal(expected), file)
+		}
+	})
+
+	It("should apply the last matching override", func() {
+		overrides, err := gosec.NewScoreOverrides([]gosec.ScoreOverride{
+			{Rule: "G104", Severity: "medium"},
+			{Rule: "G104", Paths: []string{"legacy/*.go"}, Severity: "low"},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		iss := newIssue("G104", "legacy/old.go")
+		overrides.Apply(iss)
+		Expect(iss.Severity).To(Equal(issue.Low))
+
+		iss = newIssue("G104", "legacy/sub/old.go")
+		overrides.Apply(iss)
+		Expect(iss.Severity).To(Equal(issue.Medium))
+	})
+
+	It("should reject the invalid overrides", func() {
+		_, err := gosec.NewScoreOverrides([]gosec.ScoreOverride{{Severity: "low"}})
+		Expect(err).To(MatchError("score-overrides[0]: rule cannot be empty"))
+
+		_, err = gosec.NewScoreOverrides([]gosec.ScoreOverride{{Rule: "G104"}})
+		Expect(err).To(MatchError("score-overrides[0]: severity or confidence is required"))
+
+		_, err = gosec.NewScoreOverrides([]gosec.ScoreOverride{{Rule: "G104", Confidence: "sure"}})
+		Expect(err).To(MatchError(ContainSubstring(`score-overrides[0]: confidence: invalid confidence "sure"`)))
+
+		_, err = gosec.NewScoreOverrides([]gosec.ScoreOverride{{Rule: "G104", Confidence: "critical"}})
+		Expect(err).To(MatchError(ContainSubstring(`invalid confidence "critical"`)))
+	})
+})
@@ -5,11 +5,13 @@ import (
 	"go/token"
 	"os"
 	"strconv"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/ssa"
 
+	"github.com/securego/gosec/v2/cwe"
 	"github.com/securego/gosec/v2/internal/ssautil"
 	"github.com/securego/gosec/v2/issue"
 )
@@ -26,10 +28,11 @@ type RuleInfo struct {
 // compatible with gosec's analyzer framework.
 func NewGosecAnalyzer(rule *RuleInfo, config *Config) *analysis.Analyzer {
 	return &analysis.Analyzer{
-		Name:     rule.ID,
-		Doc:      rule.Description,
-		Run:      makeAnalyzerRunner(rule, config),
-		Requires: []*analysis.Analyzer{buildssa.Analyzer},
+		Name:      rule.ID,
+		Doc:       rule.Description,
+		Run:       makeAnalyzerRunner(rule, config),
+		Requires:  []*analysis.Analyzer{buildssa.Analyzer},
+		FactTypes: []analysis.Fact{new(FuncSummary)},
 	}
 }
 
@@ -54,12 +57,44 @@ func makeAnalyzerRunner(rule *RuleInfo, config *Config) func(*analysis.Pass) (in
 			return nil, nil // No functions to analyze - this is OK
 		}
 
+		limits, err := ParseLimits(ssaResult.Config[LimitsConfigKey])
+		if err != nil {
+			return nil, fmt.Errorf("taint analysis %s: %w", rule.ID, err)
+		}
+
 		// Run taint analysis
 		analyzer := New(config)
+		analyzer.SetLimits(limits)
 		if ssaResult.Shared != nil {
 			analyzer.SetCallGraph(ssaResult.Shared.CallGraph())
 		}
-		results := analyzer.Analyze(srcFuncs[0].Prog, srcFuncs)
+		if pass.ImportObjectFact != nil {
+			analyzer.SetSummaryLookup(func(fn *ssa.Function) *FuncSummary {
+				summary := new(FuncSummary)
+				if obj := fn.Object(); obj == nil || !pass.ImportObjectFact(obj, summary) {
+					return nil
+				}
+				return summary
+			})
+		}
+		// A package analyzed only for the packages importing it needs its
+		// summaries, not its results
+		var results []Result
+		if !ssaResult.FactsOnly {
+			results = analyzer.Analyze(srcFuncs[0].Prog, srcFuncs)
+		}
+
+		// Export the summaries of the package for the packages importing it
+		if ssaResult.ExportFacts && pass.ExportObjectFact != nil {
+			for fn, summary := range analyzer.Summarize(srcFuncs[0].Prog, srcFuncs) {
+				if fn.Object().Pkg() == pass.Pkg {
+					pass.ExportObjectFact(fn.Object(), summary)
+				}
+			}
+		}
+		for fn, limit := range analyzer.TruncatedFunctions() {
+			ssaResult.Truncations.Add(fn, limit)
+		}
 
 		// Convert results to gosec issues
 		var issues []*issue.Issue
@@ -74,7 +109,7 @@ func makeAnalyzerRunner(rule *RuleInfo, config *Config) func(*analysis.Pass) (in
 			case "HIGH":
 				severity = issue.High
 			case "CRITICAL":
-				severity = issue.High // gosec uses High for critical
+				severity = issue.Critical
 			default:
 				severity = issue.Medium
 			}
@@ -88,6 +123,10 @@ func makeAnalyzerRunner(rule *RuleInfo, config *Config) func(*analysis.Pass) (in
 				severity,
 				issue.High, // confidence
 			)
+			if newIssue.Cwe == nil {
+				newIssue.Cwe = ruleWeakness(rule)
+			}
+			newIssue.Trace = issueTrace(pass.Fset, result.Trace)
 
 			issues = append(issues, newIssue)
 
@@ -102,6 +141,40 @@ func makeAnalyzerRunner(rule *RuleInfo, config *Config) func(*analysis.Pass) (in
 	}
 }
 
+// ruleWeakness resolves the CWE declared in the rule metadata (e.g. "CWE-89").
+// It is used for rules unknown to gosec's rule-to-CWE mapping, such as
+// user-defined taint rules loaded from the configuration.
+func ruleWeakness(rule *RuleInfo) *cwe.Weakness {
+	id := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule.CWE)), cwe.Acronym+"-")
+	if id == "" {
+		return nil
+	}
+	return cwe.Get(id)
+}
+
+// issueTrace converts the steps of a taint flow into issue trace steps.
+func issueTrace(fileSet *token.FileSet, steps []TraceStep) []issue.TraceStep {
+	trace := make([]issue.TraceStep, 0, len(steps))
+	for _, step := range steps {
+		if !step.Pos.IsValid() {
+			continue
+		}
+		position := fileSet.Position(step.Pos)
+		var function string
+		if step.Function != nil {
+			function = step.Function.String()
+		}
+		trace = append(trace, issue.TraceStep{
+			File:        position.Filename,
+			Line:        position.Line,
+			Column:      position.Column,
+			Function:    function,
+			Description: step.Description,
+		})
+	}
+	return trace
+}
+
 // newIssue creates a new gosec issue
 func newIssue(analyzerID string, desc string, fileSet *token.FileSet,
 	pos token.Pos, severity, confidence issue.Score,
@@ -9,6 +9,7 @@ import (
 	"go/types"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -54,6 +55,41 @@ func TestMakeAnalyzerRunnerReturnsNilWhenNoSourceFunctions(t *testing.T) {
 	}
 }
 
+func TestMakeAnalyzerRunnerExportsSummariesOnlyWhenWanted(t *testing.T) {
+	t.Parallel()
+
+	fset, ssaPkg := buildFixturePackage(t, `package p
+
+func sink(s string) {}
+
+func Run(s string) { sink(s) }
+`)
+	rule := &RuleInfo{ID: "T001", Description: "desc", Severity: "HIGH"}
+	runner := makeAnalyzerRunner(rule, &Config{Sinks: []Sink{{Package: "p", Method: "sink"}}})
+
+	for _, exportFacts := range []bool{false, true} {
+		exported := 0
+		pass := &analysis.Pass{
+			Fset: fset,
+			Pkg:  ssaPkg.Pkg,
+			ResultOf: map[*analysis.Analyzer]interface{}{
+				buildssa.Analyzer: &ssautil.SSAAnalyzerResult{
+					SSA:         &buildssa.SSA{Pkg: ssaPkg, SrcFuncs: []*ssa.Function{ssaPkg.Func("sink"), ssaPkg.Func("Run")}},
+					ExportFacts: exportFacts,
+				},
+			},
+			ImportObjectFact: func(types.Object, analysis.Fact) bool { return false },
+			ExportObjectFact: func(types.Object, analysis.Fact) { exported++ },
+		}
+		if _, err := runner(pass); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := map[bool]int{false: 0, true: 1}[exportFacts]; exported != want {
+			t.Errorf("ExportFacts=%v: expected %d exported summaries, got %d", exportFacts, want, exported)
+		}
+	}
+}
+
 func TestNewIssuePopulatesFields(t *testing.T) {
 	t.Parallel()
 
@@ -382,6 +418,26 @@ func TestGuardsSatisfiedConcreteTypeNotSatisfied(t *testing.T) {
 	}
 }
 
+func TestGuardsSatisfiedPredeclaredType(t *testing.T) {
+	t.Parallel()
+	prog := ssa.NewProgram(token.NewFileSet(), 0)
+	sink := Sink{ArgTypeGuards: map[int]string{0: "string"}}
+
+	// A string, and a type defined on string, satisfy the guard.
+	str := ssa.NewConst(constant.MakeString("x"), types.Typ[types.String])
+	query := types.NewNamed(types.NewTypeName(token.NoPos, nil, "Query", nil), types.Typ[types.String], nil)
+	named := ssa.NewConst(constant.MakeString("x"), query)
+	if !guardsSatisfied([]ssa.Value{str}, sink, prog) || !guardsSatisfied([]ssa.Value{named}, sink, prog) {
+		t.Fatal("expected true when arg is a string")
+	}
+
+	// A map, such as the conditions of a query builder, does not.
+	conds := ssa.NewConst(nil, types.NewMap(types.Typ[types.String], types.Typ[types.Int]))
+	if guardsSatisfied([]ssa.Value{conds}, sink, prog) {
+		t.Fatal("expected false when arg is not a string")
+	}
+}
+
 // ── resolveOriginalType ───────────────────────────────────────────────────────
 
 func TestResolveOriginalTypeDefault(t *testing.T) {
@@ -1135,3 +1191,529 @@ func lonely(r *http.Request) {}
 		t.Fatal("expected cache hit to return true")
 	}
 }
+
+func TestAnalyzeRecordsSourceToSinkTrace(t *testing.T) {
+	t.Parallel()
+
+	src := `package p
+
+type Req struct{ Path string }
+
+func sink(s string)   {}
+func helper(s string) { sink(s) }
+func Handle(r *Req)   { helper(r.Path) }
+`
+	fset, ssaPkg := buildFixturePackage(t, src)
+	prog := ssaPkg.Prog
+
+	source := Source{Package: "p", Name: "Req", Pointer: true}
+	analyzer := New(&Config{
+		Sources: []Source{source},
+		Sinks:   []Sink{{Package: "p", Method: "sink"}},
+	})
+	results := analyzer.Analyze(prog, []*ssa.Function{ssaPkg.Func("Handle"), ssaPkg.Func("helper")})
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	result := results[0]
+	if !reflect.DeepEqual(result.Source, source) {
+		t.Errorf("expected source %+v, got %+v", source, result.Source)
+	}
+
+	expected := []struct {
+		line     int
+		function string
+		desc     string
+	}{
+		{7, "p.Handle", "parameter r *p.Req"},
+		{7, "p.Handle", "field access Path"},
+		{6, "p.helper", "parameter s string"},
+		{6, "p.helper", "passed to sink p.sink"},
+	}
+	if len(result.Trace) != len(expected) {
+		t.Fatalf("expected %d trace steps, got %+v", len(expected), result.Trace)
+	}
+	for i, want := range expected {
+		step := result.Trace[i]
+		if got := fset.Position(step.Pos).Line; got != want.line {
+			t.Errorf("step %d: expected line %d, got %d", i, want.line, got)
+		}
+		if got := step.Function.String(); got != want.function {
+			t.Errorf("step %d: expected function %q, got %q", i, want.function, got)
+		}
+		if step.Description != want.desc {
+			t.Errorf("step %d: expected description %q, got %q", i, want.desc, step.Description)
+		}
+	}
+}
+
+// buildFixturePackage type-checks and builds the SSA form of a single-file package p.
+func buildFixturePackage(tb testing.TB, src string) (*token.FileSet, *ssa.Package) {
+	tb.Helper()
+
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		tb.Fatalf("parse: %v", err)
+	}
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Scopes:     make(map[ast.Node]*types.Scope),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	pkg, err := (&types.Config{}).Check("p", fset, []*ast.File{parsed}, info)
+	if err != nil {
+		tb.Fatalf("type-check: %v", err)
+	}
+	prog := ssa.NewProgram(fset, ssa.BuilderMode(0))
+	ssaPkg := prog.CreatePackage(pkg, []*ast.File{parsed}, info, true)
+	prog.Build()
+	return fset, ssaPkg
+}
+
+// taintedFunctions returns the names of the functions with a taint flow into a sink.
+func taintedFunctions(results []Result) map[string]bool {
+	names := make(map[string]bool, len(results))
+	for _, result := range results {
+		names[result.Path[len(result.Path)-1].Name()] = true
+	}
+	return names
+}
+
+func TestAnalyzeScopedSanitizers(t *testing.T) {
+	t.Parallel()
+
+	src := `package p
+
+type Req struct{ Q string }
+
+func sink(s string) {}
+
+func escape(s string) string                   { return s }
+func join(a, b string) string                  { return a + b }
+func split(s string) (clean string, raw string) { return s, s }
+
+func Escaped(r *Req)       { sink(escape(r.Q)) }
+func CleanedArg(r *Req)    { sink(join(r.Q, "x")) }
+func OtherArg(r *Req)      { sink(join("x", r.Q)) }
+func CleanedResult(r *Req) { c, _ := split(r.Q); sink(c) }
+func OtherResult(r *Req)   { _, raw := split(r.Q); sink(raw) }
+`
+	_, ssaPkg := buildFixturePackage(t, src)
+	var srcFuncs []*ssa.Function
+	for _, name := range []string{"Escaped", "CleanedArg", "OtherArg", "CleanedResult", "OtherResult"} {
+		srcFuncs = append(srcFuncs, ssaPkg.Func(name))
+	}
+
+	analyze := func(class string) map[string]bool {
+		analyzer := New(&Config{
+			Class:   class,
+			Sources: []Source{{Package: "p", Name: "Req", Pointer: true}},
+			Sinks:   []Sink{{Package: "p", Method: "sink"}},
+			Sanitizers: []Sanitizer{
+				{Package: "p", Method: "escape", Classes: []string{"XSS"}},
+				{Package: "p", Method: "join", Args: []int{0}},
+				{Package: "p", Method: "split", Results: []int{0}},
+			},
+		})
+		return taintedFunctions(analyzer.Analyze(ssaPkg.Prog, srcFuncs))
+	}
+
+	tainted := analyze("xss")
+	for name, want := range map[string]bool{
+		"Escaped":       false,
+		"CleanedArg":    false,
+		"OtherArg":      true,
+		"CleanedResult": false,
+		"OtherResult":   true,
+	} {
+		if tainted[name] != want {
+			t.Errorf("%s: expected tainted=%v, got %v", name, want, tainted[name])
+		}
+	}
+
+	if tainted := analyze("sql"); !tainted["Escaped"] {
+		t.Error("Escaped: expected the xss sanitizer not to apply to the sql class")
+	}
+}
+
+func TestAnalyzeGuards(t *testing.T) {
+	t.Parallel()
+
+	src := `package p
+
+type Req struct{ Q string }
+
+func sink(s string)         {}
+func valid(s string) bool   { return true }
+func invalid(s string) bool { return false }
+
+func EarlyReturn(r *Req) {
+	q := r.Q
+	if !valid(q) {
+		return
+	}
+	sink(q)
+}
+
+func ThenBranch(r *Req) {
+	q := r.Q
+	if valid(q) {
+		sink(q)
+	}
+}
+
+func AfterBranch(r *Req) {
+	q := r.Q
+	if valid(q) {
+		println("ok")
+	}
+	sink(q)
+}
+
+func OnFalse(r *Req) {
+	q := r.Q
+	if invalid(q) {
+		return
+	}
+	sink(q)
+}
+
+func OtherValue(r *Req) {
+	q := r.Q
+	if !valid("constant") {
+		return
+	}
+	sink(q)
+}
+
+func helper(s string) { sink(s) }
+
+func GuardedCaller(r *Req) {
+	q := r.Q
+	if valid(q) {
+		helper(q)
+	}
+}
+`
+	_, ssaPkg := buildFixturePackage(t, src)
+	var srcFuncs []*ssa.Function
+	for _, name := range []string{"EarlyReturn", "ThenBranch", "AfterBranch", "OnFalse", "OtherValue", "helper", "GuardedCaller"} {
+		srcFuncs = append(srcFuncs, ssaPkg.Func(name))
+	}
+
+	analyzer := New(&Config{
+		Sources: []Source{{Package: "p", Name: "Req", Pointer: true}},
+		Sinks:   []Sink{{Package: "p", Method: "sink"}},
+		Guards: []Guard{
+			{Package: "p", Method: "valid"},
+			{Package: "p", Method: "invalid", OnFalse: true},
+		},
+	})
+	tainted := taintedFunctions(analyzer.Analyze(ssaPkg.Prog, srcFuncs))
+	for name, want := range map[string]bool{
+		"EarlyReturn": false,
+		"ThenBranch":  false,
+		"AfterBranch": true,
+		"OnFalse":     false,
+		"OtherValue":  true,
+		"helper":      false,
+	} {
+		if tainted[name] != want {
+			t.Errorf("%s: expected tainted=%v, got %v", name, want, tainted[name])
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	src := `package p
+
+type Req struct{ Q string }
+
+type T struct{}
+
+func input() string { return "" }
+func sink(s string) {}
+func helper(s string) { sink(s) }
+
+func Run(s string)                { helper(s) }
+func Echo(a, b string) string     { return a }
+func Fetch() string               { return input() }
+func Handle(r *Req)               { sink(r.Q) }
+func Constant(s string)           { sink("constant") }
+func unexported(s string) string  { return s }
+func (T) Exec(prefix, cmd string) { sink(cmd) }
+`
+	_, ssaPkg := buildFixturePackage(t, src)
+	prog := ssaPkg.Prog
+	var srcFuncs []*ssa.Function
+	for _, name := range []string{"input", "sink", "helper", "Run", "Echo", "Fetch", "Handle", "Constant", "unexported"} {
+		srcFuncs = append(srcFuncs, ssaPkg.Func(name))
+	}
+	exec := prog.MethodValue(prog.MethodSets.MethodSet(ssaPkg.Type("T").Type()).Lookup(ssaPkg.Pkg, "Exec"))
+	srcFuncs = append(srcFuncs, exec)
+
+	analyzer := New(&Config{
+		Sources: []Source{
+			{Package: "p", Name: "Req", Pointer: true},
+			{Package: "p", Name: "input", IsFunc: true},
+		},
+		Sinks: []Sink{{Package: "p", Method: "sink"}},
+	})
+	summaries := make(map[string]*FuncSummary)
+	for fn, summary := range analyzer.Summarize(prog, srcFuncs) {
+		summaries[fn.Name()] = summary
+	}
+
+	for name, want := range map[string]*FuncSummary{
+		"Run":   {SinkParams: map[int]string{0: "p.sink"}},
+		"Echo":  {ReturnParams: []int{0}},
+		"Fetch": {ReturnsSource: true},
+		"Exec":  {SinkParams: map[int]string{2: "p.sink"}},
+	} {
+		if got := summaries[name]; got == nil || got.String() != want.String() {
+			t.Errorf("%s: expected summary %v, got %v", name, want, got)
+		}
+	}
+	for _, name := range []string{"Handle", "Constant", "unexported", "helper"} {
+		if summary, ok := summaries[name]; ok {
+			t.Errorf("%s: expected no summary, got %v", name, summary)
+		}
+	}
+}
+
+func TestAnalyzeWithSummaries(t *testing.T) {
+	t.Parallel()
+
+	src := `package p
+
+type Req struct{ Q string }
+
+func sink(s string) {}
+
+func ext(s string)
+func extEcho(a, b string) string
+
+func Direct(r *Req) { ext(r.Q) }
+func Echo0(r *Req)  { sink(extEcho(r.Q, "x")) }
+func Echo1(r *Req)  { sink(extEcho("x", r.Q)) }
+`
+	_, ssaPkg := buildFixturePackage(t, src)
+	var srcFuncs []*ssa.Function
+	for _, name := range []string{"Direct", "Echo0", "Echo1"} {
+		srcFuncs = append(srcFuncs, ssaPkg.Func(name))
+	}
+
+	analyzer := New(&Config{
+		Sources: []Source{{Package: "p", Name: "Req", Pointer: true}},
+		Sinks:   []Sink{{Package: "p", Method: "sink"}},
+	})
+	analyzer.SetSummaryLookup(func(fn *ssa.Function) *FuncSummary {
+		switch fn.Name() {
+		case "ext":
+			return &FuncSummary{SinkParams: map[int]string{0: "os/exec.Command"}}
+		case "extEcho":
+			return &FuncSummary{ReturnParams: []int{1}}
+		}
+		return nil
+	})
+	results := analyzer.Analyze(ssaPkg.Prog, srcFuncs)
+
+	tainted := taintedFunctions(results)
+	for name, want := range map[string]bool{
+		"Direct": true,
+		"Echo0":  false,
+		"Echo1":  true,
+	} {
+		if tainted[name] != want {
+			t.Errorf("%s: expected tainted=%v, got %v", name, want, tainted[name])
+		}
+	}
+
+	for _, result := range results {
+		if result.Path[len(result.Path)-1].Name() != "Direct" {
+			continue
+		}
+		last := result.Trace[len(result.Trace)-1].Description
+		if want := "passed to p.ext, which passes it to sink os/exec.Command"; last != want {
+			t.Errorf("expected the trace to end with %q, got %q", want, last)
+		}
+	}
+}
+
+func TestAnalyzeLimits(t *testing.T) {
+	t.Parallel()
+
+	src := `package p
+
+type Req struct{ Q string }
+
+func sink(s string) {}
+
+func a(s string) string { return b(s) }
+func b(s string) string { return c(s) }
+func c(s string) string { return s + "!" }
+
+func Deep(r *Req)    { sink(a(r.Q)) }
+func Shallow(r *Req) { sink(r.Q) }
+`
+	_, ssaPkg := buildFixturePackage(t, src)
+	var srcFuncs []*ssa.Function
+	for _, name := range []string{"a", "b", "c", "Deep", "Shallow"} {
+		srcFuncs = append(srcFuncs, ssaPkg.Func(name))
+	}
+
+	analyze := func(limits Limits) (map[string]bool, Truncations) {
+		analyzer := New(&Config{
+			Sources: []Source{{Package: "p", Name: "Req", Pointer: true}},
+			Sinks:   []Sink{{Package: "p", Method: "sink"}},
+		})
+		analyzer.SetLimits(limits)
+		return taintedFunctions(analyzer.Analyze(ssaPkg.Prog, srcFuncs)), analyzer.Truncations()
+	}
+
+	tainted, truncations := analyze(Limits{})
+	if !tainted["Deep"] || !tainted["Shallow"] || truncations.Total() != 0 {
+		t.Errorf("default limits: expected both flows without truncation, got %v, %+v", tainted, truncations)
+	}
+
+	tainted, truncations = analyze(Limits{MaxDepth: 2})
+	if tainted["Deep"] || !tainted["Shallow"] {
+		t.Errorf("max depth: expected only the shallow flow, got %v", tainted)
+	}
+	if truncations.Depth != 1 || truncations.Total() != 1 {
+		t.Errorf("max depth: expected one function truncated by depth, got %+v", truncations)
+	}
+
+	tainted, truncations = analyze(Limits{MaxVisited: 1})
+	if tainted["Deep"] || tainted["Shallow"] {
+		t.Errorf("max visited: expected no flow, got %v", tainted)
+	}
+	if truncations.Visited != 2 {
+		t.Errorf("max visited: expected two functions truncated by visited values, got %+v", truncations)
+	}
+
+	analyzer := New(&Config{
+		Sources: []Source{{Package: "p", Name: "Req", Pointer: true}},
+		Sinks:   []Sink{{Package: "p", Method: "sink"}},
+	})
+	analyzer.SetLimits(Limits{MaxVisited: 1})
+	analyzer.Summarize(ssaPkg.Prog, srcFuncs)
+	if truncations := analyzer.Truncations(); truncations.Total() != 0 {
+		t.Errorf("summaries: expected no truncation to be counted, got %+v", truncations)
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	t.Parallel()
+
+	limits, err := ParseLimits(map[string]any{"max-depth": 10, "function-budget": "250ms"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if limits.MaxDepth != 10 || limits.MaxVisited != 0 || limits.FunctionBudget != 250*time.Millisecond {
+		t.Errorf("unexpected limits %+v", limits)
+	}
+	if limits, err := ParseLimits(nil); err != nil || limits != (Limits{}) {
+		t.Errorf("expected the default limits, got %+v, %v", limits, err)
+	}
+	for _, value := range []any{
+		map[string]any{"max-visited": -1},
+		map[string]any{"function-budget": "-1s"},
+		map[string]any{"depth": 3},
+		"fast",
+	} {
+		if _, err := ParseLimits(value); err == nil {
+			t.Errorf("%v: expected an error", value)
+		}
+	}
+}
+
+func TestAnalyzeArgSources(t *testing.T) {
+	t.Parallel()
+
+	src := `package p
+
+type Rows struct{}
+
+func (r *Rows) Scan(dest ...any) error { return nil }
+func (r *Rows) Err() error            { return nil }
+
+type Decoder struct{}
+
+func (d *Decoder) Decode(v any) error { return nil }
+
+type User struct{ Name, Bio string }
+
+func sink(s string) {}
+
+func Scanned(rows *Rows) {
+	var id int
+	var name string
+	rows.Scan(&id, &name)
+	sink(name)
+}
+
+func ScannedField(rows *Rows) {
+	var u User
+	rows.Scan(&u.Name)
+	sink(u.Name)
+}
+
+func Decoded(dec *Decoder) {
+	var u User
+	dec.Decode(&u)
+	sink(u.Bio)
+}
+
+func OtherField(rows *Rows) {
+	var u User
+	rows.Scan(&u.Name)
+	sink(u.Bio)
+}
+
+func NotFilled(rows *Rows) {
+	name := "fixed"
+	rows.Err()
+	sink(name)
+}
+`
+	_, ssaPkg := buildFixturePackage(t, src)
+	names := []string{"Scanned", "ScannedField", "Decoded", "OtherField", "NotFilled"}
+	var srcFuncs []*ssa.Function
+	for _, name := range names {
+		srcFuncs = append(srcFuncs, ssaPkg.Func(name))
+	}
+
+	analyzer := New(&Config{
+		Sources: []Source{
+			{Package: "p", Receiver: "Rows", Name: "Scan", Pointer: true, IsFunc: true, Args: []int{1}},
+			{Package: "p", Receiver: "Decoder", Name: "Decode", Pointer: true, IsFunc: true, Args: []int{1}},
+		},
+		Sinks: []Sink{{Package: "p", Method: "sink"}},
+	})
+	results := analyzer.Analyze(ssaPkg.Prog, srcFuncs)
+	tainted := taintedFunctions(results)
+	for name, want := range map[string]bool{
+		"Scanned":      true,
+		"ScannedField": true,
+		"Decoded":      true,
+		"OtherField":   false,
+		"NotFilled":    false,
+	} {
+		if tainted[name] != want {
+			t.Errorf("%s: expected tainted=%v, got %v", name, want, tainted[name])
+		}
+	}
+
+	for _, result := range results {
+		if result.Source.Name == "" || len(result.Source.Args) == 0 {
+			t.Errorf("expected the result to name the filling source, got %+v", result.Source)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package taint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/securego/gosec/v2/internal/ssautil"
+)
+
+// LimitsConfigKey is the key of the taint analysis limits in the gosec
+// configuration.
+const LimitsConfigKey = "taint-limits"
+
+// budgetCheckInterval is the number of values visited between two checks of
+// the time budget of a function.
+const budgetCheckInterval = 128
+
+// Limits bounds the work of the taint analysis on every function calling a
+// sink. Zero values select the defaults: a recursion depth of 50, and no limit
+// on the visited values and the time spent.
+type Limits struct {
+	// MaxDepth is the maximum recursion depth when tracing a value back to a source
+	MaxDepth int
+	// MaxVisited is the maximum number of values visited per function
+	MaxVisited int
+	// FunctionBudget is the maximum time spent per function
+	FunctionBudget time.Duration
+}
+
+// limitsConfig is the configuration form of Limits.
+type limitsConfig struct {
+	MaxDepth       int    `json:"max-depth"`
+	MaxVisited     int    `json:"max-visited"`
+	FunctionBudget string `json:"function-budget"`
+}
+
+// ParseLimits reads the limits from the value of the "taint-limits" entry of
+// the gosec configuration, e.g.
+//
+//	{"max-depth": 80, "max-visited": 100000, "function-budget": "2s"}
+//
+// A nil value yields the default limits.
+func ParseLimits(value any) (Limits, error) {
+	if value == nil {
+		return Limits{}, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return Limits{}, fmt.Errorf("%s: %w", LimitsConfigKey, err)
+	}
+	var config limitsConfig
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return Limits{}, fmt.Errorf("%s: %w", LimitsConfigKey, err)
+	}
+
+	limits := Limits{MaxDepth: config.MaxDepth, MaxVisited: config.MaxVisited}
+	if limits.MaxDepth < 0 {
+		return Limits{}, fmt.Errorf("%s: max-depth %d cannot be negative", LimitsConfigKey, limits.MaxDepth)
+	}
+	if limits.MaxVisited < 0 {
+		return Limits{}, fmt.Errorf("%s: max-visited %d cannot be negative", LimitsConfigKey, limits.MaxVisited)
+	}
+	if config.FunctionBudget != "" {
+		limits.FunctionBudget, err = time.ParseDuration(config.FunctionBudget)
+		if err != nil {
+			return Limits{}, fmt.Errorf("%s: invalid function-budget: %w", LimitsConfigKey, err)
+		}
+		if limits.FunctionBudget < 0 {
+			return Limits{}, fmt.Errorf("%s: function-budget %s cannot be negative", LimitsConfigKey, config.FunctionBudget)
+		}
+	}
+	return limits, nil
+}
+
+// Truncations counts the functions whose analysis stopped at a limit, by
+// limit. A truncated function may hide taint flows.
+type Truncations struct {
+	Depth   int
+	Visited int
+	Budget  int
+}
+
+// Total returns the number of truncated functions.
+func (t Truncations) Total() int {
+	return t.Depth + t.Visited + t.Budget
+}
+
+// truncation records the limits hit while analyzing the current function.
+type truncation struct {
+	depth, visited, budget bool
+}
+
+// SetLimits sets the limits of the analysis.
+func (a *Analyzer) SetLimits(limits Limits) {
+	a.limits = limits
+}
+
+// Truncations returns the number of functions whose analysis hit a limit.
+func (a *Analyzer) Truncations() Truncations {
+	var truncations Truncations
+	for _, limit := range a.truncatedFuncs {
+		switch limit {
+		case ssautil.TruncatedDepth:
+			truncations.Depth++
+		case ssautil.TruncatedVisited:
+			truncations.Visited++
+		case ssautil.TruncatedBudget:
+			truncations.Budget++
+		}
+	}
+	return truncations
+}
+
+// TruncatedFunctions returns the functions whose analysis hit a limit, with
+// that limit. Only the analysis looking for issues is accounted for, not the
+// computation of the summaries.
+func (a *Analyzer) TruncatedFunctions() map[*ssa.Function]ssautil.TruncationLimit {
+	return a.truncatedFuncs
+}
+
+// maxDepth returns the maximum recursion depth.
+func (a *Analyzer) maxDepth() int {
+	if a.limits.MaxDepth > 0 {
+		return a.limits.MaxDepth
+	}
+	return maxTaintDepth
+}
+
+// startFunction resets the budget before analyzing a function.
+func (a *Analyzer) startFunction() {
+	a.truncated = truncation{}
+	a.visitedCount = 0
+	a.deadline = time.Time{}
+	if a.limits.FunctionBudget > 0 {
+		a.deadline = time.Now().Add(a.limits.FunctionBudget)
+	}
+}
+
+// endFunction records the limit hit while analyzing a function.
+func (a *Analyzer) endFunction(fn *ssa.Function) {
+	var limit ssautil.TruncationLimit
+	switch {
+	case a.truncated.budget:
+		limit = ssautil.TruncatedBudget
+	case a.truncated.visited:
+		limit = ssautil.TruncatedVisited
+	case a.truncated.depth:
+		limit = ssautil.TruncatedDepth
+	default:
+		return
+	}
+	if a.truncatedFuncs == nil {
+		a.truncatedFuncs = make(map[*ssa.Function]ssautil.TruncationLimit)
+	}
+	a.truncatedFuncs[fn] = limit
+}
+
+// limitReached reports whether the analysis must stop at the given depth,
+// because it is too deep or the budget of the function is exhausted.
+func (a *Analyzer) limitReached(depth int) bool {
+	if a.truncated.visited || a.truncated.budget {
+		return true
+	}
+	if depth > a.maxDepth() {
+		a.truncated.depth = true
+		return true
+	}
+	return false
+}
+
+// countVisit counts a newly visited value against the budget of the function
+// and reports whether the budget is exhausted.
+func (a *Analyzer) countVisit() bool {
+	a.visitedCount++
+	if a.limits.MaxVisited > 0 && a.visitedCount > a.limits.MaxVisited {
+		a.truncated.visited = true
+		return true
+	}
+	if !a.deadline.IsZero() && a.visitedCount%budgetCheckInterval == 0 && time.Now().After(a.deadline) {
+		a.truncated.budget = true
+		return true
+	}
+	return false
+}
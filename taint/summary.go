@@ -0,0 +1,306 @@
+package taint
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/ssa"
+)
+
+// FuncSummary describes how taint flows through a function. The taint
+// analyzers export it as an analysis.Fact on the exported functions of a
+// package, which lets the packages importing it follow the flows crossing the
+// package boundary. Parameter positions follow the SSA parameters, so the
+// receiver of a method is at position 0.
+type FuncSummary struct {
+	// ReturnsSource reports whether the results carry data from a source
+	ReturnsSource bool
+	// ReturnParams lists the parameters whose data flows to the results
+	ReturnParams []int
+	// SinkParams maps the parameters whose data reaches a sink to that sink
+	SinkParams map[int]string
+}
+
+// AFact marks FuncSummary as an analysis.Fact.
+func (*FuncSummary) AFact() {}
+
+func (s *FuncSummary) String() string {
+	var parts []string
+	if s.ReturnsSource {
+		parts = append(parts, "returns source")
+	}
+	if len(s.ReturnParams) > 0 {
+		parts = append(parts, fmt.Sprintf("returns params %v", s.ReturnParams))
+	}
+	for _, idx := range s.sinkParams() {
+		parts = append(parts, fmt.Sprintf("param %d reaches %s", idx, s.SinkParams[idx]))
+	}
+	return "taint(" + strings.Join(parts, ", ") + ")"
+}
+
+// sinkParams returns the positions of SinkParams in increasing order.
+func (s *FuncSummary) sinkParams() []int {
+	params := make([]int, 0, len(s.SinkParams))
+	for idx := range s.SinkParams {
+		params = append(params, idx)
+	}
+	sort.Ints(params)
+	return params
+}
+
+// SummaryLookup returns the summary of a function defined in another package,
+// or nil when none is known.
+type SummaryLookup func(fn *ssa.Function) *FuncSummary
+
+// SetSummaryLookup provides the summaries of the functions of the imported
+// packages. Calls to those functions are then resolved with their summary
+// instead of assuming that any tainted argument taints the results.
+func (a *Analyzer) SetSummaryLookup(lookup SummaryLookup) {
+	a.summaryLookup = lookup
+	a.summaryCache = make(map[*ssa.Function]*FuncSummary)
+}
+
+// summaryFor returns the summary of a function without a body, if known.
+func (a *Analyzer) summaryFor(fn *ssa.Function) (*FuncSummary, bool) {
+	if a.summaryLookup == nil || fn == nil || len(fn.Blocks) > 0 {
+		return nil, false
+	}
+	if origin := fn.Origin(); origin != nil {
+		fn = origin
+	}
+	summary, ok := a.summaryCache[fn]
+	if !ok {
+		summary = a.summaryLookup(fn)
+		a.summaryCache[fn] = summary
+	}
+	return summary, summary != nil
+}
+
+// isSummarizedCallTainted checks whether the results of a call to a function
+// with a known summary are tainted.
+func (a *Analyzer) isSummarizedCallTainted(call *ssa.Call, summary *FuncSummary, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	if summary.ReturnsSource {
+		return true
+	}
+	for _, idx := range summary.ReturnParams {
+		if idx >= len(call.Call.Args) || isContextType(call.Call.Args[idx].Type()) {
+			continue
+		}
+		if a.isTainted(call.Call.Args[idx], fn, visited, depth) {
+			return true
+		}
+	}
+	return false
+}
+
+// summarySink returns a sink for a call to a function whose summary reports
+// parameters reaching a sink, along with the configured sink they reach.
+func (a *Analyzer) summarySink(call *ssa.Call) (Sink, string, bool) {
+	callee := call.Call.StaticCallee()
+	summary, ok := a.summaryFor(callee)
+	if !ok || len(summary.SinkParams) == 0 {
+		return Sink{}, "", false
+	}
+	sink := Sink{Method: callee.Name(), CheckArgs: summary.sinkParams()}
+	if callee.Pkg != nil && callee.Pkg.Pkg != nil {
+		sink.Package = callee.Pkg.Pkg.Path()
+	}
+	if recv := callee.Signature.Recv(); recv != nil {
+		t := recv.Type()
+		if ptr, isPtr := t.(*types.Pointer); isPtr {
+			sink.Pointer = true
+			t = ptr.Elem()
+		}
+		if named, isNamed := t.(*types.Named); isNamed {
+			sink.Receiver = named.Obj().Name()
+		}
+	}
+	return sink, summary.SinkParams[sink.CheckArgs[0]], true
+}
+
+// Summarize computes the summaries of the exported functions and methods
+// among srcFuncs, which are usually the functions of a single package.
+// Functions which neither return values nor pass their parameters to a sink
+// are left out.
+func (a *Analyzer) Summarize(prog *ssa.Program, srcFuncs []*ssa.Function) map[*ssa.Function]*FuncSummary {
+	if len(srcFuncs) == 0 {
+		return nil
+	}
+
+	a.prog = prog
+	if a.callGraph == nil {
+		a.callGraph = cha.CallGraph(prog)
+	}
+
+	sinkFuncs := make(map[*ssa.Function]bool)
+	for _, fn := range srcFuncs {
+		if a.hasSinkCall(fn) {
+			sinkFuncs[fn] = true
+		}
+	}
+
+	summaries := make(map[*ssa.Function]*FuncSummary)
+	for _, fn := range srcFuncs {
+		if !isSummarized(fn) {
+			continue
+		}
+		// The limits bound the summaries as well, but only the functions
+		// truncated while looking for issues are counted.
+		a.startFunction()
+		if summary := a.summarize(fn, a.reachableSinkFuncs(fn, sinkFuncs)); summary != nil {
+			summaries[fn] = summary
+		}
+	}
+	return summaries
+}
+
+// isSummarized reports whether the function can be called from another
+// package, and has something to summarize.
+func isSummarized(fn *ssa.Function) bool {
+	if fn == nil || fn.Blocks == nil || fn.Object() == nil || !token.IsExported(fn.Name()) {
+		return false
+	}
+	return len(fn.Params) > 0 || fn.Signature.Results().Len() > 0
+}
+
+// summarize computes the summary of a single function. Sinks are looked up in
+// the function itself and in the functions of sinkFuncs it may call.
+func (a *Analyzer) summarize(fn *ssa.Function, sinkFuncs []*ssa.Function) *FuncSummary {
+	summary := &FuncSummary{}
+	hasResults := fn.Signature.Results().Len() > 0
+
+	// Sources only taint the results: the parameters are left to the callers,
+	// which know what they pass.
+	if hasResults {
+		summary.ReturnsSource = a.withSeed(fn, nil, func() bool {
+			return a.returnsTaint(fn)
+		})
+	}
+
+	// Parameters of a source type are tainted in their own package already,
+	// which reports the sinks they reach.
+	sourceTyped := make(map[int]bool)
+	for idx, param := range fn.Params {
+		sourceTyped[idx] = a.isSourceType(param.Type())
+	}
+
+	// The flows of each parameter are checked with the sources disabled, so
+	// that only the data of that parameter is followed.
+	sources, funcSrcs := a.sources, a.funcSrcs
+	a.sources, a.funcSrcs = map[string]Source{}, map[string]Source{}
+	defer func() { a.sources, a.funcSrcs = sources, funcSrcs }()
+
+	for idx, param := range fn.Params {
+		if isContextType(param.Type()) {
+			continue
+		}
+		seed := map[int]bool{idx: true}
+		if hasResults && !summary.ReturnsSource && a.withSeed(fn, seed, func() bool { return a.returnsTaint(fn) }) {
+			summary.ReturnParams = append(summary.ReturnParams, idx)
+		}
+		if sourceTyped[idx] {
+			continue
+		}
+		a.withSeed(fn, seed, func() bool {
+			for _, sinkFn := range sinkFuncs {
+				results := a.analyzeFunctionSinks(sinkFn)
+				if len(results) > 0 {
+					if summary.SinkParams == nil {
+						summary.SinkParams = make(map[int]string)
+					}
+					summary.SinkParams[idx] = results[0].sinkName
+					return true
+				}
+			}
+			return false
+		})
+	}
+
+	if !hasResults && len(summary.SinkParams) == 0 {
+		return nil
+	}
+	return summary
+}
+
+// withSeed runs check while the parameters of fn are tainted only at the
+// positions of seed, whatever their callers pass.
+func (a *Analyzer) withSeed(fn *ssa.Function, seed map[int]bool, check func() bool) bool {
+	if seed == nil {
+		seed = map[int]bool{}
+	}
+	a.summaryFn, a.summarySeed = fn, seed
+	a.paramTaintCache = make(map[paramKey]bool)
+	defer func() {
+		a.summaryFn, a.summarySeed = nil, nil
+		a.paramTaintCache = nil
+	}()
+	return check()
+}
+
+// returnsTaint reports whether a value returned by fn is tainted.
+func (a *Analyzer) returnsTaint(fn *ssa.Function) bool {
+	for _, block := range fn.Blocks {
+		ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return)
+		if !ok {
+			continue
+		}
+		a.useBlock = block
+		for _, result := range ret.Results {
+			a.trace = a.trace[:0]
+			if a.isTainted(result, fn, make(map[ssa.Value]bool), 0) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasSinkCall reports whether fn calls a sink.
+func (a *Analyzer) hasSinkCall(fn *ssa.Function) bool {
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			if call, ok := instr.(*ssa.Call); ok {
+				if _, isSink := a.isSinkCall(call); isSink {
+					return true
+				}
+				if _, _, isSink := a.summarySink(call); isSink {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// reachableSinkFuncs returns the functions calling a sink which fn may call,
+// directly or not, within its own package.
+func (a *Analyzer) reachableSinkFuncs(fn *ssa.Function, sinkFuncs map[*ssa.Function]bool) []*ssa.Function {
+	var reached []*ssa.Function
+	seen := map[*ssa.Function]bool{fn: true}
+	queue := []*ssa.Function{fn}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if sinkFuncs[current] {
+			reached = append(reached, current)
+		}
+		node := a.callGraph.Nodes[current]
+		if node == nil {
+			continue
+		}
+		for _, out := range node.Out {
+			callee := out.Callee.Func
+			if callee == nil || seen[callee] || callee.Pkg != fn.Pkg {
+				continue
+			}
+			seen[callee] = true
+			queue = append(queue, callee)
+		}
+	}
+	return slices.Clip(reached)
+}
@@ -11,16 +11,22 @@
 package taint
 
 import (
+	"fmt"
 	"go/token"
 	"go/types"
+	"slices"
 	"strings"
+	"time"
 
 	"golang.org/x/tools/go/callgraph"
 	"golang.org/x/tools/go/callgraph/cha"
 	"golang.org/x/tools/go/ssa"
+
+	"github.com/securego/gosec/v2/internal/ssautil"
 )
 
-// maxTaintDepth limits recursion depth to prevent stack overflow on large codebases
+// maxTaintDepth limits recursion depth to prevent stack overflow on large
+// codebases, unless Limits.MaxDepth overrides it
 const maxTaintDepth = 50
 
 // maxCallerEdges caps the number of incoming call graph edges examined per function
@@ -55,42 +61,66 @@ func isContextType(t types.Type) bool {
 // Format: "package/path.TypeOrFunc" or "*package/path.Type" for pointer types.
 type Source struct {
 	// Package is the import path of the package containing the source (e.g., "net/http")
-	Package string
+	Package string `json:"package"`
 	// Name is the type or function name that produces tainted data (e.g., "Request" for type, "Get" for function)
-	Name string
-	// Pointer indicates whether the source is a pointer type (true for *Type)
-	Pointer bool
+	Name string `json:"name"`
+	// Pointer indicates whether the source is a pointer type (true for *Type),
+	// or for a method source whether its receiver is a pointer
+	Pointer bool `json:"pointer,omitempty"`
 	// IsFunc marks this source as a function/method that returns tainted data
 	// (e.g., os.Getenv, os.ReadFile). When false, Source is treated as a type
 	// that is only tainted when received as a function parameter from external callers.
-	IsFunc bool
+	IsFunc bool `json:"is_func,omitempty"`
+	// Receiver is the type name of a method source (e.g., "Rows"). Without it,
+	// a function source matches the functions and methods named Name.
+	Receiver string `json:"receiver,omitempty"`
+	// Args lists the positions of the pointer arguments that a function
+	// source fills with tainted data, such as the destinations of
+	// (*sql.Rows).Scan. For methods, position 0 is the receiver, and a
+	// variadic parameter covers all the arguments it receives. The results of
+	// a source with Args are not tainted.
+	Args []int `json:"args,omitempty"`
+}
+
+// taintsArg reports whether the source fills the argument at position idx of
+// a call to a function with the given signature.
+func (s Source) taintsArg(idx int, sig *types.Signature) bool {
+	if sig.Variadic() {
+		last := sig.Params().Len() - 1
+		if sig.Recv() != nil {
+			last++
+		}
+		idx = min(idx, last)
+	}
+	return slices.Contains(s.Args, idx)
 }
 
 // Sink defines a dangerous function that should not receive tainted data.
 // Format: "(*package/path.Type).Method" or "package/path.Func"
 type Sink struct {
 	// Package is the import path of the package containing the sink (e.g., "database/sql")
-	Package string
+	Package string `json:"package"`
 	// Receiver is the type name for methods (e.g., "DB"), or empty for package-level functions
-	Receiver string
+	Receiver string `json:"receiver,omitempty"`
 	// Method is the function or method name that represents the sink (e.g., "Query")
-	Method string
+	Method string `json:"method"`
 	// Pointer indicates whether the receiver is a pointer type (true for *Type methods)
-	Pointer bool
+	Pointer bool `json:"pointer,omitempty"`
 	// CheckArgs specifies which argument positions to check for taint (0-indexed).
 	// For method calls, Args[0] is the receiver.
 	// If nil or empty, all arguments are checked.
 	// Examples:
 	//   - SQL methods: [1] - only check query string (Args[1]), skip receiver
 	//   - fmt.Fprintf: [1,2,3,...] - skip writer (Args[0]), check format and data
-	CheckArgs []int
+	CheckArgs []int `json:"check_args,omitempty"`
 
 	// ArgTypeGuards constrains argument types before treating a call as a sink.
 	// Key is the zero-based argument index; value is the required type expressed
-	// as "import/path.TypeName" (e.g. "net/http.ResponseWriter").
+	// as "import/path.TypeName" (e.g. "net/http.ResponseWriter"), or as the name
+	// of a predeclared type (e.g. "string").
 	// The sink only fires when every guarded argument's type implements (or equals)
 	// the named interface/type. When empty, no type constraint is applied.
-	ArgTypeGuards map[int]string
+	ArgTypeGuards map[int]string `json:"arg_type_guards,omitempty"`
 }
 
 // resolveOriginalType traces back through SSA interface-conversion instructions
@@ -113,7 +143,8 @@ func resolveOriginalType(v ssa.Value) types.Type {
 // satisfied by the concrete SSA argument types present in args.
 //
 // Interface guards are checked with types.Implements (handles pointer receivers
-// and embedding). Concrete-type guards require exact types.Identical match.
+// and embedding). Concrete-type guards require exact types.Identical match,
+// except for predeclared types, which also accept the types defined on them.
 // When sink.ArgTypeGuards is nil or empty the function always returns true.
 //
 // Argument types are resolved through ChangeInterface/MakeInterface so that
@@ -144,6 +175,11 @@ func guardsSatisfied(args []ssa.Value, sink Sink, prog *ssa.Program) bool {
 				!types.Implements(types.NewPointer(argType), iface) {
 				return false
 			}
+		} else if _, isBasic := required.(*types.Basic); isBasic {
+			// Predeclared-type guard: e.g. a string passed as an interface{}.
+			if !types.Identical(argType.Underlying(), required) {
+				return false
+			}
 		} else {
 			// Concrete-type guard: require exact named-type identity.
 			if !types.Identical(argType, required) &&
@@ -156,11 +192,15 @@ func guardsSatisfied(args []ssa.Value, sink Sink, prog *ssa.Program) bool {
 }
 
 // lookupNamedType resolves a fully-qualified type string of the form
-// "import/path.TypeName" to a types.Type using the SSA program's package set.
+// "import/path.TypeName", or the name of a predeclared type, to a types.Type
+// using the SSA program's package set.
 // Returns nil when the package or type name is not found.
 func lookupNamedType(typePath string, prog *ssa.Program) types.Type {
 	lastDot := strings.LastIndex(typePath, ".")
 	if lastDot < 0 {
+		if tn, ok := types.Universe.Lookup(typePath).(*types.TypeName); ok {
+			return tn.Type()
+		}
 		return nil
 	}
 	pkgPath := typePath[:lastDot]
@@ -185,13 +225,76 @@ func lookupNamedType(typePath string, prog *ssa.Program) types.Type {
 // When tainted data passes through a sanitizer, it is no longer considered tainted.
 type Sanitizer struct {
 	// Package is the import path (e.g., "path/filepath")
-	Package string
+	Package string `json:"package"`
 	// Receiver is the type name for methods, or empty for package-level functions
-	Receiver string
+	Receiver string `json:"receiver,omitempty"`
 	// Method is the function or method name (e.g., "Clean")
-	Method string
+	Method string `json:"method"`
 	// Pointer indicates whether the receiver is a pointer type
-	Pointer bool
+	Pointer bool `json:"pointer,omitempty"`
+	// Args lists the argument positions whose data the sanitizer cleans
+	// (for methods, position 0 is the receiver). Data from the other
+	// arguments still taints the output. Empty means all arguments.
+	Args []int `json:"args,omitempty"`
+	// Results lists the result positions which carry the cleaned value. The
+	// other results are tainted by the arguments as for any other call.
+	// Empty means all results.
+	Results []int `json:"results,omitempty"`
+	// Classes lists the vulnerability classes the sanitizer neutralizes
+	// (e.g. "xss"). It only applies to configurations of these classes.
+	// Empty means all classes.
+	Classes []string `json:"classes,omitempty"`
+}
+
+// Guard defines a predicate function that validates data. A value passed to
+// a guard is no longer considered tainted in the code which only runs when
+// the guard accepted it, e.g. after `if !re.MatchString(id) { return }`.
+type Guard struct {
+	// Package is the import path (e.g., "path/filepath")
+	Package string `json:"package"`
+	// Receiver is the type name for methods, or empty for package-level functions
+	Receiver string `json:"receiver,omitempty"`
+	// Method is the function or method name (e.g., "IsLocal")
+	Method string `json:"method"`
+	// Pointer indicates whether the receiver is a pointer type
+	Pointer bool `json:"pointer,omitempty"`
+	// Arg is the position of the validated argument (for methods, position
+	// 0 is the receiver)
+	Arg int `json:"arg"`
+	// OnFalse indicates that the value is valid when the guard returns false
+	// (e.g. a strings.Contains(p, "..") check) instead of true
+	OnFalse bool `json:"on_false,omitempty"`
+	// Classes lists the vulnerability classes the guard protects against.
+	// Empty means all classes.
+	Classes []string `json:"classes,omitempty"`
+}
+
+// cleansArg reports whether the sanitizer cleans the argument at position idx.
+func (s Sanitizer) cleansArg(idx int) bool {
+	return len(s.Args) == 0 || slices.Contains(s.Args, idx)
+}
+
+// cleansResult reports whether the result at position idx carries the cleaned value.
+func (s Sanitizer) cleansResult(idx int) bool {
+	return len(s.Results) == 0 || slices.Contains(s.Results, idx)
+}
+
+// appliesTo reports whether the sanitizer neutralizes the vulnerability class.
+func (s Sanitizer) appliesTo(class string) bool {
+	if len(s.Classes) == 0 {
+		return true
+	}
+	for _, c := range s.Classes {
+		if strings.EqualFold(c, class) {
+			return true
+		}
+	}
+	return false
+}
+
+// appliesTo reports whether the guard protects against the vulnerability class.
+func (g Guard) appliesTo(class string) bool {
+	return Sanitizer{Classes: g.Classes}.appliesTo(class)
 }
 
 // Result represents a detected taint flow from source to sink.
@@ -204,16 +307,66 @@ type Result struct {
 	SinkPos token.Pos
 	// Path is the sequence of functions from entry point to the sink
 	Path []*ssa.Function
+	// Trace is the ordered sequence of steps the tainted value went through,
+	// from the source to the sink call
+	Trace []TraceStep
+
+	sinkName string // the configured sink reached, behind a summarized call
+}
+
+// TraceStep is a single step of a taint flow.
+type TraceStep struct {
+	// Pos is the source code position of the step
+	Pos token.Pos
+	// Function is the function in which the step occurs
+	Function *ssa.Function
+	// Description tells what happens to the tainted value at this step
+	// (e.g. "parameter r *http.Request", "field access URL")
+	Description string
+}
+
+// tracePoint records a value found to carry taint while the analyzer walks
+// back from a sink argument.
+type tracePoint struct {
+	value ssa.Value
+	fn    *ssa.Function
 }
 
 // Config holds taint analysis configuration.
 type Config struct {
+	// Class is the vulnerability class detected with this configuration
+	// (e.g. "sql"). It selects the sanitizers which apply.
+	Class string `json:"class,omitempty"`
 	// Sources is the list of data origins that produce tainted values
-	Sources []Source
+	Sources []Source `json:"sources,omitempty"`
 	// Sinks is the list of dangerous functions that should not receive tainted data
-	Sinks []Sink
+	Sinks []Sink `json:"sinks,omitempty"`
 	// Sanitizers is the list of functions that neutralize taint (optional)
-	Sanitizers []Sanitizer
+	Sanitizers []Sanitizer `json:"sanitizers,omitempty"`
+	// Guards is the list of predicates that validate data (optional)
+	Guards []Guard `json:"guards,omitempty"`
+}
+
+// Merge returns a new configuration holding the sources, sinks, sanitizers and
+// guards of both c and other. Neither configuration is modified. A sink in other which
+// matches an existing sink replaces it when the analyzer indexes the sinks,
+// which allows e.g. narrowing its CheckArgs.
+func (c Config) Merge(other Config) Config {
+	merged := Config{
+		Class:      c.Class,
+		Sources:    make([]Source, 0, len(c.Sources)+len(other.Sources)),
+		Sinks:      make([]Sink, 0, len(c.Sinks)+len(other.Sinks)),
+		Sanitizers: make([]Sanitizer, 0, len(c.Sanitizers)+len(other.Sanitizers)),
+		Guards:     make([]Guard, 0, len(c.Guards)+len(other.Guards)),
+	}
+	merged.Sources = append(append(merged.Sources, c.Sources...), other.Sources...)
+	merged.Sinks = append(append(merged.Sinks, c.Sinks...), other.Sinks...)
+	merged.Sanitizers = append(append(merged.Sanitizers, c.Sanitizers...), other.Sanitizers...)
+	merged.Guards = append(append(merged.Guards, c.Guards...), other.Guards...)
+	if merged.Class == "" {
+		merged.Class = other.Class
+	}
+	return merged
 }
 
 // Analyzer performs taint analysis on SSA programs.
@@ -225,13 +378,26 @@ type paramKey struct {
 
 type Analyzer struct {
 	config          *Config
-	sources         map[string]Source   // keyed by full type string
-	funcSrcs        map[string]Source   // function sources keyed by "pkg.Func"
-	sinks           map[string]Sink     // keyed by full function string
-	sanitizers      map[string]struct{} // keyed by full function string
+	sources         map[string]Source    // keyed by full type string
+	funcSrcs        map[string]Source    // function sources keyed by "pkg.Func"
+	argSources      bool                 // whether a function source fills its arguments
+	sinks           map[string]Sink      // keyed by full function string
+	sanitizers      map[string]Sanitizer // keyed by full function string
+	guards          map[string]Guard     // keyed by full function string
 	callGraph       *callgraph.Graph
 	prog            *ssa.Program      // set at Analyze time for ArgTypeGuards resolution
 	paramTaintCache map[paramKey]bool // caches true results from isParameterTainted
+	trace           []tracePoint      // tainted values of the flow being traced, source first
+	useBlock        *ssa.BasicBlock   // block using the values being traced, for guards
+	summaryLookup   SummaryLookup     // summaries of the functions of imported packages
+	summaryCache    map[*ssa.Function]*FuncSummary
+	summaryFn       *ssa.Function // function being summarized
+	summarySeed     map[int]bool  // tainted parameters of summaryFn
+	limits          Limits
+	truncatedFuncs  map[*ssa.Function]ssautil.TruncationLimit // functions whose analysis hit a limit
+	truncated       truncation                                // limits hit by the function being analyzed
+	visitedCount    int                                       // values visited for the function being analyzed
+	deadline        time.Time                                 // end of the time budget of the function being analyzed
 }
 
 // SetCallGraph injects a precomputed call graph.
@@ -246,7 +412,8 @@ func New(config *Config) *Analyzer {
 		sources:    make(map[string]Source),
 		funcSrcs:   make(map[string]Source),
 		sinks:      make(map[string]Sink),
-		sanitizers: make(map[string]struct{}),
+		sanitizers: make(map[string]Sanitizer),
+		guards:     make(map[string]Guard),
 	}
 
 	// Index sources for fast lookup, separating type sources from function sources
@@ -255,6 +422,7 @@ func New(config *Config) *Analyzer {
 		a.sources[key] = src
 		if src.IsFunc {
 			a.funcSrcs[key] = src
+			a.argSources = a.argSources || len(src.Args) > 0
 		}
 	}
 
@@ -264,17 +432,38 @@ func New(config *Config) *Analyzer {
 		a.sinks[key] = sink
 	}
 
-	// Index sanitizers for fast lookup
+	// Index the sanitizers which neutralize the configured vulnerability class
 	for _, san := range config.Sanitizers {
+		if !san.appliesTo(config.Class) {
+			continue
+		}
 		key := formatSanitizerKey(san)
-		a.sanitizers[key] = struct{}{}
+		a.sanitizers[key] = san
+	}
+
+	// Index the guards which protect against the configured vulnerability class
+	for _, guard := range config.Guards {
+		if !guard.appliesTo(config.Class) {
+			continue
+		}
+		key := formatSanitizerKey(Sanitizer{
+			Package:  guard.Package,
+			Receiver: guard.Receiver,
+			Method:   guard.Method,
+			Pointer:  guard.Pointer,
+		})
+		a.guards[key] = guard
 	}
 
 	return a
 }
 
-// formatSourceKey creates a lookup key for a source.
+// formatSourceKey creates a lookup key for a source. Method sources use the
+// key format of the sanitizers.
 func formatSourceKey(src Source) string {
+	if src.Receiver != "" {
+		return formatSanitizerKey(Sanitizer{Package: src.Package, Receiver: src.Receiver, Method: src.Name, Pointer: src.Pointer})
+	}
 	key := src.Package + "." + src.Name
 	if src.Pointer {
 		key = "*" + key
@@ -328,7 +517,9 @@ func (a *Analyzer) Analyze(prog *ssa.Program, srcFuncs []*ssa.Function) []Result
 
 	// Find all sink calls in the program
 	for _, fn := range srcFuncs {
+		a.startFunction()
 		results = append(results, a.analyzeFunctionSinks(fn)...)
+		a.endFunction(fn)
 	}
 
 	a.paramTaintCache = nil
@@ -351,8 +542,14 @@ func (a *Analyzer) analyzeFunctionSinks(fn *ssa.Function) []Result {
 				continue
 			}
 
-			// Check if this call is a sink
+			// Check if this call is a sink, or a call to a function of
+			// another package which passes some of its arguments to a sink
 			sink, isSink := a.isSinkCall(call)
+			reached, via := formatSinkKey(sink), (*ssa.Function)(nil)
+			if !isSink {
+				sink, reached, isSink = a.summarySink(call)
+				via = call.Call.StaticCallee()
+			}
 			if !isSink {
 				continue
 			}
@@ -379,13 +576,21 @@ func (a *Analyzer) analyzeFunctionSinks(fn *ssa.Function) []Result {
 			}
 
 			// Check if any of the specified arguments are tainted
+			a.useBlock = call.Block()
 			for _, arg := range argsToCheck {
+				a.trace = a.trace[:0]
 				if a.isTainted(arg, fn, make(map[ssa.Value]bool), 0) {
-					results = append(results, Result{
-						Sink:    sink,
-						SinkPos: call.Pos(),
-						Path:    a.buildPath(fn),
-					})
+					result := Result{
+						Sink:     sink,
+						SinkPos:  call.Pos(),
+						Path:     a.buildPath(fn),
+						Trace:    a.buildTrace(call, fn, reached, via),
+						sinkName: reached,
+					}
+					if len(a.trace) > 0 {
+						result.Source, _ = a.sourceOf(a.trace[0].value)
+					}
+					results = append(results, result)
 					break
 				}
 			}
@@ -473,13 +678,42 @@ func (a *Analyzer) isSinkCall(call *ssa.Call) (Sink, bool) {
 
 // isSanitizerCall checks if a call instruction is a sanitizer.
 func (a *Analyzer) isSanitizerCall(call *ssa.Call) bool {
+	_, ok := a.sanitizerFor(call)
+	return ok
+}
+
+// sanitizerFor returns the sanitizer invoked by a call instruction.
+func (a *Analyzer) sanitizerFor(call *ssa.Call) (Sanitizer, bool) {
 	if len(a.sanitizers) == 0 {
-		return false
+		return Sanitizer{}, false
 	}
+	key, ok := staticCalleeKey(call)
+	if !ok {
+		return Sanitizer{}, false
+	}
+	san, found := a.sanitizers[key]
+	return san, found
+}
+
+// guardFor returns the guard invoked by a call instruction.
+func (a *Analyzer) guardFor(call *ssa.Call) (Guard, bool) {
+	if len(a.guards) == 0 {
+		return Guard{}, false
+	}
+	key, ok := staticCalleeKey(call)
+	if !ok {
+		return Guard{}, false
+	}
+	guard, found := a.guards[key]
+	return guard, found
+}
 
+// staticCalleeKey returns the lookup key of the function statically called by
+// a call instruction, in the format of the sanitizer keys.
+func staticCalleeKey(call *ssa.Call) (string, bool) {
 	callee := call.Call.StaticCallee()
 	if callee == nil {
-		return false
+		return "", false
 	}
 
 	var pkg, receiverName, methodName string
@@ -510,8 +744,85 @@ func (a *Analyzer) isSanitizerCall(call *ssa.Call) bool {
 		Method:   methodName,
 		Pointer:  isPointer,
 	})
-	_, found := a.sanitizers[key]
-	return found
+	return key, true
+}
+
+// isGuarded checks whether a value was validated by a guard on every path
+// leading to the block which uses the values being traced.
+func (a *Analyzer) isGuarded(v ssa.Value) bool {
+	if len(a.guards) == 0 || a.useBlock == nil || v.Parent() != a.useBlock.Parent() {
+		return false
+	}
+	refs := v.Referrers()
+	if refs == nil {
+		return false
+	}
+	for _, ref := range *refs {
+		call, ok := ref.(*ssa.Call)
+		if !ok {
+			continue
+		}
+		guard, ok := a.guardFor(call)
+		if !ok || guard.Arg >= len(call.Call.Args) || call.Call.Args[guard.Arg] != v {
+			continue
+		}
+		if a.guardDominatesUse(call, !guard.OnFalse) {
+			return true
+		}
+	}
+	return false
+}
+
+// guardDominatesUse checks whether the use block is only reachable through the
+// branch taken when cond has the valid value.
+func (a *Analyzer) guardDominatesUse(cond ssa.Value, valid bool) bool {
+	refs := cond.Referrers()
+	if refs == nil {
+		return false
+	}
+	for _, ref := range *refs {
+		switch instr := ref.(type) {
+		case *ssa.If:
+			succs := instr.Block().Succs
+			succ := succs[1]
+			if valid {
+				succ = succs[0]
+			}
+			// The successor must only be entered from the branch, otherwise
+			// it can be reached without passing the guard.
+			if len(succ.Preds) == 1 && succ.Dominates(a.useBlock) {
+				return true
+			}
+		case *ssa.UnOp:
+			if instr.Op == token.NOT && a.guardDominatesUse(instr, !valid) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isSanitizedCallTainted checks whether the output of a sanitizer call is still
+// tainted. result is the position of the output being checked, or -1 when the
+// call result is used as a whole. Outputs which do not carry the cleaned value,
+// and data from arguments the sanitizer does not clean, propagate taint.
+func (a *Analyzer) isSanitizedCallTainted(call *ssa.Call, san Sanitizer, result int, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	cleaned := result < 0 || san.cleansResult(result)
+	if cleaned && len(san.Args) == 0 {
+		return false
+	}
+	for i, arg := range call.Call.Args {
+		if cleaned && san.cleansArg(i) {
+			continue
+		}
+		if isContextType(arg.Type()) {
+			continue
+		}
+		if a.isTainted(arg, fn, visited, depth) {
+			return true
+		}
+	}
+	return false
 }
 
 // isTainted recursively checks if a value is tainted (originates from a source).
@@ -521,13 +832,38 @@ func (a *Analyzer) isSanitizerCall(call *ssa.Call) bool {
 // constructed values of source types (e.g., http.NewRequest with a hardcoded
 // URL) are NOT automatically considered tainted — their taintedness depends
 // on whether the data flowing into them is tainted.
+//
+// Every value found to be tainted is recorded in the analyzer trace, so that
+// once a sink argument is found to be tainted the trace holds the flow from
+// the source to that argument.
 func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	mark := len(a.trace)
+	if a.isValueTainted(v, fn, visited, depth) {
+		a.trace = append(a.trace, tracePoint{value: v, fn: fn})
+		return true
+	}
+	// Drop the steps recorded for operands which did not taint this value.
+	a.trace = a.trace[:mark]
+	return false
+}
+
+// isTaintedAt checks if a value used in the given block is tainted. It is used
+// when the trace moves to another function, e.g. to the caller of a function.
+func (a *Analyzer) isTaintedAt(v ssa.Value, fn *ssa.Function, block *ssa.BasicBlock, visited map[ssa.Value]bool, depth int) bool {
+	useBlock := a.useBlock
+	a.useBlock = block
+	defer func() { a.useBlock = useBlock }()
+	return a.isTainted(v, fn, visited, depth)
+}
+
+// isValueTainted traces v back through the SSA graph to a taint source.
+func (a *Analyzer) isValueTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
 	if v == nil {
 		return false
 	}
 
 	// Prevent stack overflow on large codebases
-	if depth > maxTaintDepth {
+	if a.limitReached(depth) {
 		return false
 	}
 
@@ -536,6 +872,9 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 		return false
 	}
 	visited[v] = true
+	if a.countVisit() {
+		return false
+	}
 
 	// Constants are compile-time literals and can never carry attacker-controlled
 	// data. Short-circuit immediately — no taint possible.
@@ -543,6 +882,11 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 		return false
 	}
 
+	// Values validated by a guard are clean where the guard accepted them
+	if a.isGuarded(v) {
+		return false
+	}
+
 	// Trace back through SSA instructions
 	switch val := v.(type) {
 	case *ssa.Parameter:
@@ -553,8 +897,13 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 
 	case *ssa.Call:
 		// FIRST: Check if this call is a sanitizer — sanitizers break the taint chain
-		if a.isSanitizerCall(val) {
-			return false
+		// for the arguments and results they clean
+		if san, ok := a.sanitizerFor(val); ok {
+			result := 0
+			if _, isTuple := val.Type().(*types.Tuple); isTuple {
+				result = -1
+			}
+			return a.isSanitizedCallTainted(val, san, result, fn, visited, depth+1)
 		}
 
 		// Check if this is a known source function (e.g., os.Getenv, os.ReadFile)
@@ -562,6 +911,11 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 			return true
 		}
 
+		// Functions of other packages are resolved with their summary if known
+		if summary, ok := a.summaryFor(val.Call.StaticCallee()); ok {
+			return a.isSummarizedCallTainted(val, summary, fn, visited, depth+1)
+		}
+
 		// For method calls, check if the receiver carries taint.
 		// This handles patterns like: req.URL.Query().Get("param")
 		// where req is a tainted *http.Request parameter.
@@ -668,6 +1022,12 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 		}
 
 	case *ssa.Extract:
+		// Extract from the results of a sanitizer - check whether this result is cleaned
+		if call, ok := val.Tuple.(*ssa.Call); ok {
+			if san, ok := a.sanitizerFor(call); ok {
+				return a.isSanitizedCallTainted(call, san, val.Index, fn, visited, depth+1)
+			}
+		}
 		// Extract from tuple - check the tuple
 		return a.isTainted(val.Tuple, fn, visited, depth+1)
 
@@ -692,6 +1052,10 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 		return a.isTainted(val.X, fn, visited, depth+1)
 
 	case *ssa.Alloc:
+		// Allocation filled by a source, e.g. rows.Scan(&name)
+		if a.isFilledBySource(val, fn) {
+			return true
+		}
 		// Allocation - check referrers for assignments
 		for _, ref := range *val.Referrers() {
 			// Direct stores to the allocation
@@ -777,15 +1141,21 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 // isSourceType checks if a type matches any configured source type.
 // This is used specifically for parameter checking, NOT for general value checking.
 func (a *Analyzer) isSourceType(t types.Type) bool {
+	_, ok := a.sourceForType(t)
+	return ok
+}
+
+// sourceForType returns the configured source matching a type.
+func (a *Analyzer) sourceForType(t types.Type) (Source, bool) {
 	if t == nil {
-		return false
+		return Source{}, false
 	}
 
 	typeStr := t.String()
 
 	// Direct match
-	if _, ok := a.sources[typeStr]; ok {
-		return true
+	if src, ok := a.sources[typeStr]; ok {
+		return src, true
 	}
 
 	// Check underlying type for named types
@@ -793,22 +1163,22 @@ func (a *Analyzer) isSourceType(t types.Type) bool {
 		obj := named.Obj()
 		if obj != nil && obj.Pkg() != nil {
 			key := obj.Pkg().Path() + "." + obj.Name()
-			if _, ok := a.sources[key]; ok {
-				return true
+			if src, ok := a.sources[key]; ok {
+				return src, true
 			}
 			// Check pointer variant
-			if _, ok := a.sources["*"+key]; ok {
-				return true
+			if src, ok := a.sources["*"+key]; ok {
+				return src, true
 			}
 		}
 	}
 
 	// Check pointer types
 	if ptr, ok := t.(*types.Pointer); ok {
-		return a.isSourceType(ptr.Elem())
+		return a.sourceForType(ptr.Elem())
 	}
 
-	return false
+	return Source{}, false
 }
 
 // mayHaveExternalCallers reports whether fn could be invoked by code outside
@@ -840,21 +1210,86 @@ func mayHaveExternalCallers(fn *ssa.Function) bool {
 
 // isSourceFuncCall checks if a call invokes a known source function
 // (a function explicitly configured as producing tainted data, e.g., os.Getenv).
+// Sources filling their arguments do not taint the results of the call.
 func (a *Analyzer) isSourceFuncCall(call *ssa.Call) bool {
+	src, ok := a.funcSourceFor(call)
+	return ok && len(src.Args) == 0
+}
+
+// funcSourceFor returns the function source statically called by a call
+// instruction. Method sources are matched on their receiver first.
+func (a *Analyzer) funcSourceFor(call *ssa.Call) (Source, bool) {
 	callee := call.Call.StaticCallee()
-	if callee == nil {
+	if callee == nil || callee.Pkg == nil || callee.Pkg.Pkg == nil {
+		return Source{}, false
+	}
+	if callee.Signature.Recv() != nil {
+		if key, ok := staticCalleeKey(call); ok {
+			if src, ok := a.funcSrcs[key]; ok {
+				return src, true
+			}
+		}
+	}
+	src, ok := a.funcSrcs[callee.Pkg.Pkg.Path()+"."+callee.Name()]
+	return src, ok
+}
+
+// isFilledBySource checks if the memory at ptr is filled with tainted data by
+// a source, e.g. the destinations of (*sql.Rows).Scan, and records the source
+// call in the trace.
+func (a *Analyzer) isFilledBySource(ptr ssa.Value, fn *ssa.Function) bool {
+	if !a.argSources {
 		return false
 	}
+	call, ok := filledBy(ptr, func(call *ssa.Call, idx int) bool {
+		src, ok := a.funcSourceFor(call)
+		return ok && src.taintsArg(idx, call.Call.StaticCallee().Signature)
+	})
+	if ok {
+		a.trace = append(a.trace, tracePoint{value: call, fn: fn})
+	}
+	return ok
+}
 
-	if callee.Pkg != nil && callee.Pkg.Pkg != nil {
-		pkg := callee.Pkg.Pkg.Path()
-		funcKey := pkg + "." + callee.Name()
-		if src, ok := a.sources[funcKey]; ok && src.IsFunc {
-			return true
+// filledBy returns the first call receiving ptr as an argument for which
+// fills reports true. The pointer may be passed directly, boxed in an
+// interface, or among the variadic arguments of the call.
+func filledBy(ptr ssa.Value, fills func(call *ssa.Call, idx int) bool) (*ssa.Call, bool) {
+	seen := make(map[ssa.Value]bool)
+	queue := []ssa.Value{ptr}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if seen[v] || v.Referrers() == nil {
+			continue
+		}
+		seen[v] = true
+		for _, ref := range *v.Referrers() {
+			switch r := ref.(type) {
+			case *ssa.Call:
+				for idx, arg := range r.Call.Args {
+					if arg == v && r.Call.StaticCallee() != nil && fills(r, idx) {
+						return r, true
+					}
+				}
+			case *ssa.MakeInterface:
+				queue = append(queue, r)
+			case *ssa.ChangeInterface:
+				queue = append(queue, r)
+			case *ssa.ChangeType:
+				queue = append(queue, r)
+			case *ssa.Slice:
+				// The array of the variadic arguments passed as a slice
+				queue = append(queue, r)
+			case *ssa.Store:
+				// A variadic argument stored in the array of the arguments
+				if ia, ok := r.Addr.(*ssa.IndexAddr); ok && r.Val == v {
+					queue = append(queue, ia.X)
+				}
+			}
 		}
 	}
-
-	return false
+	return nil, false
 }
 
 // isParameterTainted checks if a function parameter receives tainted data.
@@ -864,7 +1299,7 @@ func (a *Analyzer) isSourceFuncCall(call *ssa.Call) bool {
 // 2. Any caller passes tainted data to the corresponding argument position
 func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
 	// Prevent stack overflow
-	if depth > maxTaintDepth {
+	if a.limitReached(depth) {
 		return false
 	}
 
@@ -877,6 +1312,12 @@ func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, vi
 		}
 	}
 
+	// The parameters of a function being summarized are tainted only at the
+	// seeded positions.
+	if fn == a.summaryFn {
+		return a.summarySeed[paramIdx]
+	}
+
 	// Check memoization cache (only true results are cached).
 	if paramIdx >= 0 && a.paramTaintCache != nil {
 		key := paramKey{fn: fn, paramIdx: paramIdx}
@@ -963,7 +1404,7 @@ func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, vi
 
 		if adjustedIdx < len(callArgs) {
 			edgesChecked++
-			if a.isTainted(callArgs[adjustedIdx], inEdge.Caller.Func, visited, depth+1) {
+			if a.isTaintedAt(callArgs[adjustedIdx], inEdge.Caller.Func, site.Block(), visited, depth+1) {
 				if a.paramTaintCache != nil {
 					a.paramTaintCache[paramKey{fn: fn, paramIdx: paramIdx}] = true
 				}
@@ -978,7 +1419,7 @@ func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, vi
 // isFreeVarTainted checks if a closure's free variable is tainted.
 // Free variables are captured from the enclosing function's scope.
 func (a *Analyzer) isFreeVarTainted(fv *ssa.FreeVar, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
-	if depth > maxTaintDepth {
+	if a.limitReached(depth) {
 		return false
 	}
 
@@ -1002,7 +1443,7 @@ func (a *Analyzer) isFreeVarTainted(fv *ssa.FreeVar, fn *ssa.Function, visited m
 			// mc.Bindings correspond to fn.FreeVars in the same order
 			for i, binding := range mc.Bindings {
 				if i < len(fn.FreeVars) && fn.FreeVars[i] == fv {
-					return a.isTainted(binding, parent, visited, depth+1)
+					return a.isTaintedAt(binding, parent, mc.Block(), visited, depth+1)
 				}
 			}
 		}
@@ -1017,7 +1458,7 @@ func (a *Analyzer) isFreeVarTainted(fv *ssa.FreeVar, fn *ssa.Function, visited m
 // the entire struct as tainted when any field is tainted, we trace the
 // specific field to see if IT was assigned tainted data.
 func (a *Analyzer) isFieldAccessTainted(fa *ssa.FieldAddr, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
-	if depth > maxTaintDepth {
+	if a.limitReached(depth) {
 		return false
 	}
 
@@ -1025,6 +1466,7 @@ func (a *Analyzer) isFieldAccessTainted(fa *ssa.FieldAddr, fn *ssa.Function, vis
 	// ALL fields of externally-supplied source types are considered tainted.
 	if a.isSourceType(fa.X.Type()) {
 		if _, ok := fa.X.(*ssa.Parameter); ok {
+			a.trace = append(a.trace, tracePoint{value: fa.X, fn: fn})
 			return true
 		}
 		// If not a parameter but still a source type, trace the struct origin
@@ -1087,7 +1529,7 @@ func (a *Analyzer) isFieldAccessTainted(fa *ssa.FieldAddr, fn *ssa.Function, vis
 
 // isFieldTaintedOnValue checks if a specific field of a value is tainted.
 func (a *Analyzer) isFieldTaintedOnValue(v ssa.Value, fieldIdx int, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
-	if v == nil || depth > maxTaintDepth {
+	if v == nil || a.limitReached(depth) {
 		return false
 	}
 
@@ -1128,11 +1570,18 @@ func (a *Analyzer) isFieldOfAllocTainted(alloc *ssa.Alloc, fieldIdx int, fn *ssa
 	if alloc.Referrers() == nil {
 		return false
 	}
+	// The whole struct, or this field, filled by a source, e.g. dec.Decode(&v)
+	if a.isFilledBySource(alloc, fn) {
+		return true
+	}
 	for _, ref := range *alloc.Referrers() {
 		fa, ok := ref.(*ssa.FieldAddr)
 		if !ok || fa.Field != fieldIdx {
 			continue
 		}
+		if a.isFilledBySource(fa, fn) {
+			return true
+		}
 
 		if fa.Referrers() == nil {
 			continue
@@ -1162,7 +1611,7 @@ func (a *Analyzer) isFieldAccessOnPointerTainted(unop *ssa.UnOp, fieldIdx int, f
 // It looks inside the callee to find the returned struct allocation and checks
 // whether the specific field was assigned data derived from tainted arguments.
 func (a *Analyzer) isFieldTaintedViaCall(call *ssa.Call, fieldIdx int, callee *ssa.Function, callerFn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
-	if depth > maxTaintDepth || callee == nil {
+	if a.limitReached(depth) || callee == nil {
 		return false
 	}
 
@@ -1210,7 +1659,7 @@ func (a *Analyzer) isFieldTaintedViaCall(call *ssa.Call, fieldIdx int, callee *s
 // isFieldOfAllocTaintedInCallee checks if a specific field of an allocated struct
 // (inside a callee function) receives tainted data from the caller's arguments.
 func (a *Analyzer) isFieldOfAllocTaintedInCallee(alloc *ssa.Alloc, fieldIdx int, callee *ssa.Function, call *ssa.Call, callerFn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
-	if alloc.Referrers() == nil || depth > maxTaintDepth {
+	if alloc.Referrers() == nil || a.limitReached(depth) {
 		return false
 	}
 
@@ -1244,7 +1693,7 @@ func (a *Analyzer) isFieldOfAllocTaintedInCallee(alloc *ssa.Alloc, fieldIdx int,
 // isCalleValueTainted checks if a value inside a callee is tainted, mapping
 // callee parameters back to the actual caller arguments for interprocedural analysis.
 func (a *Analyzer) isCalleValueTainted(v ssa.Value, callee *ssa.Function, call *ssa.Call, callerFn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
-	if v == nil || depth > maxTaintDepth {
+	if v == nil || a.limitReached(depth) {
 		return false
 	}
 
@@ -1271,14 +1720,19 @@ func (a *Analyzer) isCalleValueTainted(v ssa.Value, callee *ssa.Function, call *
 
 	// For calls within the callee, check if any tainted param flows in
 	if innerCall, ok := v.(*ssa.Call); ok {
-		// Check if it's a sanitizer
-		if a.isSanitizerCall(innerCall) {
+		// Check if it's a sanitizer; scoped sanitizers only stop the taint
+		// of the arguments they clean
+		san, isSanitizer := a.sanitizerFor(innerCall)
+		if isSanitizer && len(san.Args) == 0 {
 			return false
 		}
 		if a.isSourceFuncCall(innerCall) {
 			return true
 		}
-		for _, arg := range innerCall.Call.Args {
+		for i, arg := range innerCall.Call.Args {
+			if isSanitizer && san.cleansArg(i) {
+				continue
+			}
 			if a.isCalleValueTainted(arg, callee, call, callerFn, visited, depth+1) {
 				return true
 			}
@@ -1336,7 +1790,7 @@ func (a *Analyzer) isCalleValueTainted(v ssa.Value, callee *ssa.Function, call *
 // where only some arguments flow into the return struct, while others are stored
 // in fields that don't affect the data being tracked.
 func (a *Analyzer) doTaintedArgsFlowToReturn(call *ssa.Call, callee *ssa.Function, callerFn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
-	if depth > maxTaintDepth {
+	if a.limitReached(depth) {
 		return false
 	}
 
@@ -1543,3 +1997,97 @@ func (a *Analyzer) buildPath(fn *ssa.Function) []*ssa.Function {
 
 	return path
 }
+
+// sourceOf returns the configured source which produced a tainted value, if
+// the value is a source itself.
+func (a *Analyzer) sourceOf(v ssa.Value) (Source, bool) {
+	switch val := v.(type) {
+	case *ssa.Parameter:
+		return a.sourceForType(val.Type())
+	case *ssa.FieldAddr:
+		return a.sourceForType(val.X.Type())
+	case *ssa.Call:
+		return a.funcSourceFor(val)
+	case *ssa.Global:
+		if val.Pkg != nil && val.Pkg.Pkg != nil {
+			src, ok := a.sources[val.Pkg.Pkg.Path()+"."+val.Name()]
+			return src, ok
+		}
+	}
+	return Source{}, false
+}
+
+// buildTrace converts the tainted values recorded while checking a sink
+// argument into trace steps, ending with the sink call itself, or with the
+// call to via when the sink is reached through a function of another package. Values which
+// only move data around (loads, conversions, phis, ...) are left out.
+func (a *Analyzer) buildTrace(call *ssa.Call, fn *ssa.Function, sinkName string, via *ssa.Function) []TraceStep {
+	steps := make([]TraceStep, 0, len(a.trace)+1)
+	add := func(pos token.Pos, fn *ssa.Function, desc string) {
+		if !pos.IsValid() {
+			pos = fn.Pos()
+		}
+		if last := len(steps) - 1; last >= 0 && steps[last].Pos == pos && steps[last].Description == desc {
+			return
+		}
+		steps = append(steps, TraceStep{Pos: pos, Function: fn, Description: desc})
+	}
+	for _, point := range a.trace {
+		if desc, ok := a.describeTraceValue(point.value); ok {
+			add(point.value.Pos(), point.fn, desc)
+		}
+	}
+	if via != nil {
+		add(call.Pos(), fn, fmt.Sprintf("passed to %s, which passes it to sink %s", via, sinkName))
+	} else {
+		add(call.Pos(), fn, "passed to sink "+sinkName)
+	}
+	return steps
+}
+
+// describeTraceValue returns a short description of a tainted value for the
+// trace, or false if the value is not worth a step.
+func (a *Analyzer) describeTraceValue(v ssa.Value) (string, bool) {
+	qualifier := func(p *types.Package) string { return p.Name() }
+	switch val := v.(type) {
+	case *ssa.Parameter:
+		return fmt.Sprintf("parameter %s %s", val.Name(), types.TypeString(val.Type(), qualifier)), true
+	case *ssa.FreeVar:
+		return "captured variable " + val.Name(), true
+	case *ssa.Global:
+		return "global " + val.String(), true
+	case *ssa.FieldAddr:
+		if st, ok := derefStruct(val.X.Type()); ok && val.Field < st.NumFields() {
+			return "field access " + st.Field(val.Field).Name(), true
+		}
+		return "field access", true
+	case *ssa.Call:
+		if _, ok := val.Call.Value.(*ssa.Builtin); ok {
+			return "", false
+		}
+		if val.Call.IsInvoke() {
+			return "call to method " + val.Call.Method.Name(), true
+		}
+		callee := val.Call.StaticCallee()
+		if callee == nil {
+			return "call to function value", true
+		}
+		if _, ok := a.funcSourceFor(val); ok {
+			return "source " + callee.String(), true
+		}
+		if len(callee.Blocks) > 0 {
+			return "passed to callee " + callee.String(), true
+		}
+		return "call to " + callee.String(), true
+	}
+	return "", false
+}
+
+// derefStruct returns the struct type of t or of the type t points to.
+func derefStruct(t types.Type) (*types.Struct, bool) {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	return st, ok
+}
@@ -569,5 +569,78 @@ func main() {
 	}
 	defer rows.Close()
 }
+`}, 0, gosec.NewConfig()},
+
+	// Database libraries
+	{[]string{`
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"gorm.io/gorm"
+)
+
+type user struct {
+	Name string
+}
+
+func main() {
+	db, err := gorm.Open(nil)
+	if err != nil {
+		panic(err)
+	}
+	q := fmt.Sprintf("SELECT * FROM users WHERE name = '%s'", os.Args[1])
+	var users []user
+	db.Raw(q).Scan(&users)
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func main() {
+	pool, err := pgxpool.New(context.Background(), "")
+	if err != nil {
+		panic(err)
+	}
+	q := fmt.Sprintf("SELECT * FROM users WHERE name = '%s'", os.Args[1])
+	rows, err := pool.Query(context.Background(), q)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func main() {
+	db, err := sqlx.Connect("postgres", "")
+	if err != nil {
+		panic(err)
+	}
+	q := fmt.Sprintf("SELECT * FROM users WHERE id = %d", len(os.Args))
+	rows, err := db.Queryx(q)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+}
 `}, 0, gosec.NewConfig()},
 }
@@ -552,4 +552,104 @@ func main() {
 	defer rows.Close()
 }
 `}, 1, gosec.NewConfig()},
+
+	// Database libraries
+	{[]string{`
+package main
+
+import (
+	"os"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type user struct {
+	Name string
+}
+
+func main() {
+	db, err := sqlx.Connect("postgres", "")
+	if err != nil {
+		panic(err)
+	}
+	var users []user
+	_ = db.Select(&users, "SELECT * FROM users WHERE name = '"+os.Args[1]+"'")
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func main() {
+	conn, err := pgx.Connect(context.Background(), "")
+	if err != nil {
+		panic(err)
+	}
+	_, _ = conn.Exec(context.Background(), "DELETE FROM users WHERE name = '"+os.Args[1]+"'")
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"os"
+
+	"gorm.io/gorm"
+)
+
+type user struct {
+	Name string
+}
+
+func main() {
+	db, err := gorm.Open(nil)
+	if err != nil {
+		panic(err)
+	}
+	var users []user
+	db.Where("name = '" + os.Args[1] + "'").Find(&users)
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"os"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+func main() {
+	query, _, _ := sq.Select("*").From("users").Where("name = '" + os.Args[1] + "'").ToSql()
+	_ = query
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"os"
+
+	"gorm.io/gorm"
+)
+
+type user struct {
+	Name string
+}
+
+func main() {
+	db, err := gorm.Open(nil)
+	if err != nil {
+		panic(err)
+	}
+	var users []user
+	db.Where("name = ?", os.Args[1]).Find(&users)
+}
+`}, 0, gosec.NewConfig()},
 }
@@ -402,4 +402,32 @@ func main() {
 	}
 }
 `}, 0, gosec.NewConfig()},
+	{[]string{`
+// TLS 1.2 is too low for the modern level
+package main
+
+import "crypto/tls"
+
+func main() {
+	_ = &tls.Config{MinVersion: tls.VersionTLS12}
+}
+`}, 1, func() gosec.Config {
+		cfg := gosec.NewConfig()
+		cfg.Set("G402", map[string]interface{}{"level": "modern"})
+		return cfg
+	}()},
+	{[]string{`
+// TLS 1.0 is accepted by the old level
+package main
+
+import "crypto/tls"
+
+func main() {
+	_ = &tls.Config{MinVersion: tls.VersionTLS10}
+}
+`}, 0, func() gosec.Config {
+		cfg := gosec.NewConfig()
+		cfg.Set("G402", map[string]interface{}{"level": "old"})
+		return cfg
+	}()},
 }
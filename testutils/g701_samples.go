@@ -2195,5 +2195,280 @@ func handler(db *sql.DB, _ *http.Request) {
 	query := "SELECT * FROM t WHERE host = '" + svc.cfg.Host + "'"
 	db.Query(query)
 }
+`}, 0, gosec.NewConfig()},
+
+	// Framework path parameters flowing into a query.
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+var db *sql.DB
+
+func getUser(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	_, _ = db.Query("SELECT * FROM users WHERE id = " + id)
+}
+
+func main() {
+	r := chi.NewRouter()
+	r.Get("/users/{id}", getUser)
+	_ = http.ListenAndServe(":8080", r)
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+var db *sql.DB
+
+func getUser(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	_, _ = db.Query("SELECT * FROM users WHERE id = " + id)
+}
+
+func main() {
+	r := mux.NewRouter()
+	r.HandleFunc("/users/{id}", getUser)
+	_ = http.ListenAndServe(":8080", r)
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+)
+
+var db *sql.DB
+
+func main() {
+	r := gin.Default()
+	r.GET("/users/:id", func(c *gin.Context) {
+		_, _ = db.Query("SELECT * FROM users WHERE id = " + c.Param("id"))
+	})
+	_ = r.Run()
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+)
+
+var db *sql.DB
+
+func main() {
+	r := gin.Default()
+	r.GET("/users/:id", func(c *gin.Context) {
+		_, _ = db.Query("SELECT * FROM users WHERE id = $1", c.Param("id"))
+	})
+	_ = r.Run()
+}
+`}, 0, gosec.NewConfig()},
+
+	// Database libraries: only the SQL string is checked, not the bound parameters.
+	{[]string{`
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func handler(conn *pgx.Conn, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	_, _ = conn.Query(context.Background(), "SELECT * FROM users WHERE name = '"+name+"'")
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func handler(pool *pgxpool.Pool, tx pgx.Tx, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	_, _ = pool.Query(context.Background(), "SELECT * FROM users WHERE name = $1", name)
+	_ = tx.QueryRow(context.Background(), "SELECT * FROM users WHERE name = $1", name)
+}
+`}, 0, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func handler(tx pgx.Tx, r *http.Request) {
+	id := r.FormValue("id")
+	_, _ = tx.Exec(context.Background(), "DELETE FROM users WHERE id = "+id)
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type user struct {
+	Name string
+}
+
+func handler(db *sqlx.DB, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	var users []user
+	_ = db.Select(&users, "SELECT * FROM users WHERE name = '"+name+"'")
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type user struct {
+	Name string
+}
+
+func handler(db *sqlx.DB, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	var u user
+	_ = db.Get(&u, "SELECT * FROM users WHERE name = $1", name)
+	_, _ = db.NamedExec("INSERT INTO users (name) VALUES (:name)", user{Name: name})
+}
+`}, 0, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func handler(db *sqlx.DB, r *http.Request) {
+	table := r.FormValue("table")
+	_, _ = db.NamedExec("INSERT INTO "+table+" (name) VALUES (:name)", map[string]any{"name": "x"})
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+type user struct {
+	Name string
+}
+
+func handler(db *gorm.DB, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	var users []user
+	db.Raw("SELECT * FROM users WHERE name = '" + name + "'").Scan(&users)
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+type user struct {
+	Name string
+}
+
+func handler(db *gorm.DB, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	var users []user
+	db.Where("name = '" + name + "'").Find(&users)
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+type user struct {
+	Name string
+}
+
+func handler(db *gorm.DB, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	var users []user
+	db.Where("name = ?", name).Find(&users)
+	db.Where(&user{Name: name}).Find(&users)
+	db.Where(map[string]any{"name": name}).Find(&users)
+}
+`}, 0, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"net/http"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+func handler(r *http.Request) (string, []any, error) {
+	name := r.URL.Query().Get("name")
+	return sq.Select("*").From("users").Where("name = '" + name + "'").ToSql()
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"net/http"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+func handler(r *http.Request) (string, []any, error) {
+	name := r.URL.Query().Get("name")
+	return sq.Select("*").From("users").Where(sq.Eq{"name": name}).Where("age > ?", name).ToSql()
+}
 `}, 0, gosec.NewConfig()},
 }
@@ -185,4 +185,131 @@ func handler(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, "static/index.html")
 }
 `}, 0, gosec.NewConfig()},
+	// True negative: path validated with filepath.IsLocal before use
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	name := r.FormValue("name")
+	if !filepath.IsLocal(name) {
+		http.Error(w, "invalid name", http.StatusBadRequest)
+		return
+	}
+	f, _ := os.Open(name)
+	defer f.Close()
+}
+`}, 0, gosec.NewConfig()},
+	// True negative: path validated against an allow-list pattern
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"regexp"
+)
+
+var validName = regexp.MustCompile("^[a-z0-9_-]+\\.txt$")
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	name := r.FormValue("name")
+	if validName.MatchString(name) {
+		os.ReadFile(name)
+	}
+}
+`}, 0, gosec.NewConfig()},
+	// True positive: the path is used whether or not the guard accepted it
+	{[]string{`
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	name := r.FormValue("name")
+	if !filepath.IsLocal(name) {
+		log.Printf("non-local path requested")
+	}
+	os.Remove(name)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Framework request data used as a file path.
+	{[]string{`
+package main
+
+import (
+	"os"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func main() {
+	app := fiber.New()
+	app.Get("/files/:name", func(c *fiber.Ctx) error {
+		data, err := os.ReadFile(c.Params("name"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(data)
+	})
+	_ = app.Listen(":8080")
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"context"
+	"os"
+
+	"google.golang.org/grpc/metadata"
+)
+
+type server struct{}
+
+func (s *server) Download(ctx context.Context) ([]byte, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return os.ReadFile(md.Get("x-file")[0])
+}
+
+func main() {
+	_, _ = (&server{}).Download(context.Background())
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"os"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+type DownloadRequest struct {
+	Name string
+}
+
+func localRequestDownload(pathParams map[string]string) ([]byte, error) {
+	var protoReq DownloadRequest
+	if err := runtime.PopulateFieldFromPath(&protoReq, "name", pathParams["name"]); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(protoReq.Name)
+}
+
+func main() {
+	_, _ = localRequestDownload(map[string]string{"name": "report.txt"})
+}
+`}, 1, gosec.NewConfig()},
 }
@@ -194,4 +194,97 @@ func main() {
 	_ = http.ListenAndServe(":8080", nil)
 }
 `}, 1, gosec.NewConfig()},
+
+	// Framework handlers: request data written back with the framework helpers.
+	{[]string{`
+package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	r.GET("/hello", func(c *gin.Context) {
+		c.String(200, "<h1>Hello %s</h1>", c.Query("name"))
+	})
+	_ = r.Run()
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import "github.com/labstack/echo/v4"
+
+func hello(c echo.Context) error {
+	return c.HTML(200, "<h1>Hello "+c.QueryParam("name")+"</h1>")
+}
+
+func main() {
+	e := echo.New()
+	e.GET("/hello", hello)
+	_ = e.Start(":8080")
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import "github.com/gofiber/fiber/v2"
+
+func main() {
+	app := fiber.New()
+	app.Get("/hello/:name", func(c *fiber.Ctx) error {
+		return c.SendString("Hello " + c.Params("name"))
+	})
+	_ = app.Listen(":8080")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Only the data rendered by the template is checked, not its name.
+	{[]string{`
+package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	r.GET("/hello", func(c *gin.Context) {
+		c.HTML(200, "hello.tmpl", c.Query("name"))
+	})
+	_ = r.Run()
+}
+`}, 1, gosec.NewConfig()},
+
+	// The status code is not written to the body.
+	{[]string{`
+package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	r.GET("/hello", func(c *gin.Context) {
+		c.HTML(200, c.Query("theme")+".tmpl", nil)
+		c.String(200, "done")
+	})
+	_ = r.Run()
+}
+`}, 0, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"html"
+
+	"github.com/labstack/echo/v4"
+)
+
+func hello(c echo.Context) error {
+	return c.HTML(200, "<h1>Hello "+html.EscapeString(c.QueryParam("name"))+"</h1>")
+}
+
+func main() {
+	e := echo.New()
+	e.GET("/hello", hello)
+	_ = e.Start(":8080")
+}
+`}, 0, gosec.NewConfig()},
 }
@@ -64,5 +64,63 @@ func handler(w http.ResponseWriter, r *http.Request) {
 	}
 	http.Redirect(w, r, fmt.Sprintf("/users/%d", id), http.StatusFound)
 }
+`}, 0, gosec.NewConfig()},
+
+	// Framework handlers redirecting to a request parameter.
+	{[]string{`
+package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	r.GET("/login", func(c *gin.Context) {
+		c.Redirect(302, c.Query("next"))
+	})
+	_ = r.Run()
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import "github.com/labstack/echo/v4"
+
+func login(c echo.Context) error {
+	return c.Redirect(302, c.QueryParam("next"))
+}
+
+func main() {
+	e := echo.New()
+	e.GET("/login", login)
+	_ = e.Start(":8080")
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import "github.com/gofiber/fiber/v2"
+
+func main() {
+	app := fiber.New()
+	app.Get("/login", func(c *fiber.Ctx) error {
+		return c.Redirect(c.Query("next"))
+	})
+	_ = app.Listen(":8080")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: fixed redirect target, the status code is not checked.
+	{[]string{`
+package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	r.GET("/login", func(c *gin.Context) {
+		c.Redirect(302, "/home")
+	})
+	_ = r.Run()
+}
 `}, 0, gosec.NewConfig()},
 }
@@ -68,6 +68,9 @@ func (p *TestPackage) write() error {
 			return e
 		}
 	}
+	if err := p.writeModuleStubs(); err != nil {
+		return err
+	}
 	p.onDisk = true
 	return nil
 }
@@ -85,6 +88,9 @@ func (p *TestPackage) Build(opts ...Option) error {
 		Mode:  gosec.LoadMode,
 		Tests: false,
 	}
+	if _, err := os.Stat(path.Join(p.Path, "go.mod")); err == nil {
+		conf.Dir = p.Path
+	}
 	for _, opt := range opts {
 		opt(conf)
 	}
@@ -0,0 +1,411 @@
+package testutils
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// moduleStub is a minimal stand-in for a third-party module, which lets the
+// samples use its API without downloading it.
+type moduleStub struct {
+	// version is the version required by the sample module
+	version string
+	// packages maps the import path of the stubbed packages to their source
+	packages map[string]string
+}
+
+// moduleStubs holds the stubbed modules by module path. A sample importing one
+// of their packages is built as a module which replaces them with the stubs.
+var moduleStubs = map[string]moduleStub{
+	"github.com/gin-gonic/gin": {"v0.0.0", map[string]string{
+		"github.com/gin-gonic/gin": `
+package gin
+
+import "net/http"
+
+type Context struct {
+	Request *http.Request
+	Writer  http.ResponseWriter
+}
+
+func (c *Context) Query(key string) string                      { return "" }
+func (c *Context) DefaultQuery(key, defaultValue string) string { return defaultValue }
+func (c *Context) Param(key string) string                      { return "" }
+func (c *Context) PostForm(key string) string                   { return "" }
+func (c *Context) GetHeader(key string) string                  { return "" }
+func (c *Context) Cookie(name string) (string, error)           { return "", nil }
+func (c *Context) String(code int, format string, values ...any) {}
+func (c *Context) HTML(code int, name string, obj any)          {}
+func (c *Context) Data(code int, contentType string, data []byte) {}
+func (c *Context) JSON(code int, obj any)                       {}
+func (c *Context) Redirect(code int, location string)           {}
+
+type HandlerFunc func(*Context)
+
+type Engine struct{}
+
+func Default() *Engine                                   { return &Engine{} }
+func (e *Engine) GET(path string, handlers ...HandlerFunc)  {}
+func (e *Engine) POST(path string, handlers ...HandlerFunc) {}
+func (e *Engine) Run(addr ...string) error               { return nil }
+`,
+	}},
+	"github.com/labstack/echo/v4": {"v4.0.0", map[string]string{
+		"github.com/labstack/echo/v4": `
+package echo
+
+type Context interface {
+	QueryParam(name string) string
+	Param(name string) string
+	FormValue(name string) string
+	String(code int, s string) error
+	HTML(code int, html string) error
+	HTMLBlob(code int, b []byte) error
+	JSON(code int, i any) error
+	Redirect(code int, url string) error
+}
+
+type HandlerFunc func(c Context) error
+
+type Echo struct{}
+
+func New() *Echo                                 { return &Echo{} }
+func (e *Echo) GET(path string, h HandlerFunc)   {}
+func (e *Echo) Start(address string) error       { return nil }
+`,
+	}},
+	"github.com/go-chi/chi/v5": {"v5.0.0", map[string]string{
+		"github.com/go-chi/chi/v5": `
+package chi
+
+import "net/http"
+
+type Mux struct{}
+
+func NewRouter() *Mux                                              { return &Mux{} }
+func (m *Mux) Get(pattern string, h http.HandlerFunc)              {}
+func (m *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request)    {}
+func URLParam(r *http.Request, key string) string                  { return "" }
+`,
+	}},
+	"github.com/gorilla/mux": {"v0.0.0", map[string]string{
+		"github.com/gorilla/mux": `
+package mux
+
+import "net/http"
+
+type Router struct{}
+
+func NewRouter() *Router { return &Router{} }
+func (r *Router) HandleFunc(path string, f func(http.ResponseWriter, *http.Request)) {}
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request)              {}
+func Vars(r *http.Request) map[string]string                                      { return nil }
+`,
+	}},
+	"github.com/gofiber/fiber/v2": {"v2.0.0", map[string]string{
+		"github.com/gofiber/fiber/v2": `
+package fiber
+
+type Ctx struct{}
+
+func (c *Ctx) Params(key string, defaultValue ...string) string    { return "" }
+func (c *Ctx) Query(key string, defaultValue ...string) string     { return "" }
+func (c *Ctx) FormValue(key string, defaultValue ...string) string { return "" }
+func (c *Ctx) Get(key string, defaultValue ...string) string       { return "" }
+func (c *Ctx) Body() []byte                                        { return nil }
+func (c *Ctx) SendString(body string) error                        { return nil }
+func (c *Ctx) JSON(data any) error                                 { return nil }
+func (c *Ctx) Redirect(location string, status ...int) error       { return nil }
+
+type Handler func(*Ctx) error
+
+type App struct{}
+
+func New() *App                                      { return &App{} }
+func (a *App) Get(path string, handlers ...Handler)  {}
+func (a *App) Listen(addr string) error              { return nil }
+`,
+	}},
+	"google.golang.org/grpc": {"v1.0.0", map[string]string{
+		"google.golang.org/grpc/metadata": `
+package metadata
+
+import "context"
+
+type MD map[string][]string
+
+func (md MD) Get(k string) []string                          { return md[k] }
+func FromIncomingContext(ctx context.Context) (MD, bool)     { return nil, false }
+`,
+	}},
+	"github.com/grpc-ecosystem/grpc-gateway/v2": {"v2.0.0", map[string]string{
+		"github.com/grpc-ecosystem/grpc-gateway/v2/runtime": `
+package runtime
+
+import (
+	"net/url"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+)
+
+type Message interface{}
+
+func PopulateFieldFromPath(msg Message, fieldPathString string, value string) error { return nil }
+func PopulateQueryParameters(msg Message, values url.Values, filter *utilities.DoubleArray) error {
+	return nil
+}
+`,
+		"github.com/grpc-ecosystem/grpc-gateway/v2/utilities": `
+package utilities
+
+type DoubleArray struct{}
+
+func NewDoubleArray(seqs [][]string) *DoubleArray { return &DoubleArray{} }
+`,
+	}},
+	"github.com/jackc/pgx/v5": {"v5.0.0", map[string]string{
+		"github.com/jackc/pgx/v5": `
+package pgx
+
+import "context"
+
+type Rows interface {
+	Close()
+	Next() bool
+	Scan(dest ...any) error
+}
+
+type Row interface {
+	Scan(dest ...any) error
+}
+
+type CommandTag struct{}
+
+type Tx interface {
+	Query(ctx context.Context, sql string, args ...any) (Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) Row
+	Exec(ctx context.Context, sql string, args ...any) (CommandTag, error)
+	Commit(ctx context.Context) error
+}
+
+type Conn struct{}
+
+func Connect(ctx context.Context, connString string) (*Conn, error)                      { return &Conn{}, nil }
+func (c *Conn) Query(ctx context.Context, sql string, args ...any) (Rows, error)         { return nil, nil }
+func (c *Conn) QueryRow(ctx context.Context, sql string, args ...any) Row                { return nil }
+func (c *Conn) Exec(ctx context.Context, sql string, args ...any) (CommandTag, error)    { return CommandTag{}, nil }
+func (c *Conn) Begin(ctx context.Context) (Tx, error)                                    { return nil, nil }
+`,
+		"github.com/jackc/pgx/v5/pgxpool": `
+package pgxpool
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type Pool struct{}
+
+func New(ctx context.Context, connString string) (*Pool, error)                             { return &Pool{}, nil }
+func (p *Pool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)        { return nil, nil }
+func (p *Pool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row               { return nil }
+func (p *Pool) Exec(ctx context.Context, sql string, args ...any) (pgx.CommandTag, error)   { return pgx.CommandTag{}, nil }
+`,
+	}},
+	"github.com/jmoiron/sqlx": {"v0.0.0", map[string]string{
+		"github.com/jmoiron/sqlx": `
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+)
+
+type DB struct {
+	*sql.DB
+}
+
+type Tx struct {
+	*sql.Tx
+}
+
+type Rows struct {
+	*sql.Rows
+}
+
+func Connect(driverName, dataSourceName string) (*DB, error)                                  { return &DB{}, nil }
+func (db *DB) Select(dest any, query string, args ...any) error                              { return nil }
+func (db *DB) SelectContext(ctx context.Context, dest any, query string, args ...any) error  { return nil }
+func (db *DB) Get(dest any, query string, args ...any) error                                 { return nil }
+func (db *DB) NamedExec(query string, arg any) (sql.Result, error)                           { return nil, nil }
+func (db *DB) Queryx(query string, args ...any) (*Rows, error)                               { return nil, nil }
+func (db *DB) MustExec(query string, args ...any) sql.Result                                 { return nil }
+func (db *DB) Beginx() (*Tx, error)                                                          { return &Tx{}, nil }
+func (tx *Tx) Select(dest any, query string, args ...any) error                              { return nil }
+func (tx *Tx) Get(dest any, query string, args ...any) error                                 { return nil }
+func (tx *Tx) NamedExec(query string, arg any) (sql.Result, error)                           { return nil, nil }
+`,
+	}},
+	"gorm.io/gorm": {"v0.0.0", map[string]string{
+		"gorm.io/gorm": `
+package gorm
+
+type Dialector interface{}
+
+type DB struct {
+	Error error
+}
+
+func Open(dialector Dialector, opts ...any) (*DB, error)     { return &DB{}, nil }
+func (db *DB) Raw(sql string, values ...any) *DB            { return db }
+func (db *DB) Exec(sql string, values ...any) *DB           { return db }
+func (db *DB) Where(query any, args ...any) *DB             { return db }
+func (db *DB) Or(query any, args ...any) *DB                { return db }
+func (db *DB) Not(query any, args ...any) *DB               { return db }
+func (db *DB) Order(value any) *DB                          { return db }
+func (db *DB) Find(dest any, conds ...any) *DB              { return db }
+func (db *DB) First(dest any, conds ...any) *DB             { return db }
+func (db *DB) Scan(dest any) *DB                            { return db }
+`,
+	}},
+	"github.com/Masterminds/squirrel": {"v0.0.0", map[string]string{
+		"github.com/Masterminds/squirrel": `
+package squirrel
+
+type Sqlizer interface {
+	ToSql() (string, []any, error)
+}
+
+type Eq map[string]any
+
+func (eq Eq) ToSql() (string, []any, error) { return "", nil, nil }
+
+type expr struct{}
+
+func (e expr) ToSql() (string, []any, error) { return "", nil, nil }
+
+func Expr(sql string, args ...any) Sqlizer { return expr{} }
+
+type SelectBuilder struct{}
+
+func Select(columns ...string) SelectBuilder                           { return SelectBuilder{} }
+func (b SelectBuilder) From(from string) SelectBuilder                 { return b }
+func (b SelectBuilder) Where(pred any, args ...any) SelectBuilder      { return b }
+func (b SelectBuilder) OrderBy(orderBys ...string) SelectBuilder       { return b }
+func (b SelectBuilder) Prefix(sql string, args ...any) SelectBuilder   { return b }
+func (b SelectBuilder) Suffix(sql string, args ...any) SelectBuilder   { return b }
+func (b SelectBuilder) ToSql() (string, []any, error)                  { return "", nil, nil }
+
+type DeleteBuilder struct{}
+
+func Delete(from string) DeleteBuilder                                 { return DeleteBuilder{} }
+func (b DeleteBuilder) Where(pred any, args ...any) DeleteBuilder      { return b }
+func (b DeleteBuilder) ToSql() (string, []any, error)                  { return "", nil, nil }
+`,
+	}},
+	"github.com/redis/go-redis/v9": {"v9.0.0", map[string]string{
+		"github.com/redis/go-redis/v9": `
+package redis
+
+import "context"
+
+type Options struct {
+	Addr string
+}
+
+type Cmder interface{}
+
+type StringCmd struct{ val string }
+
+func (cmd *StringCmd) Val() string             { return cmd.val }
+func (cmd *StringCmd) Result() (string, error) { return cmd.val, nil }
+
+type MapStringStringCmd struct{ val map[string]string }
+
+func (cmd *MapStringStringCmd) Val() map[string]string { return cmd.val }
+
+type cmdable func(ctx context.Context, cmd Cmder) error
+
+func (c cmdable) Get(ctx context.Context, key string) *StringCmd         { return &StringCmd{} }
+func (c cmdable) HGet(ctx context.Context, key, field string) *StringCmd { return &StringCmd{} }
+func (c cmdable) HGetAll(ctx context.Context, key string) *MapStringStringCmd {
+	return &MapStringStringCmd{}
+}
+func (c cmdable) Set(ctx context.Context, key string, value any, expiration int64) *StringCmd {
+	return &StringCmd{}
+}
+
+type Client struct {
+	cmdable
+}
+
+func NewClient(opt *Options) *Client { return &Client{} }
+`,
+	}},
+}
+
+// usedModuleStubs returns the paths of the stubbed modules imported by the
+// package files, in a stable order.
+func (p *TestPackage) usedModuleStubs() []string {
+	var used []string
+	for modulePath, stub := range moduleStubs {
+		if p.importsAny(stub.packages) {
+			used = append(used, modulePath)
+		}
+	}
+	sort.Strings(used)
+	return used
+}
+
+func (p *TestPackage) importsAny(packages map[string]string) bool {
+	for pkgPath := range packages {
+		for _, content := range p.Files {
+			if strings.Contains(content, fmt.Sprintf("%q", pkgPath)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeModuleStubs turns the package into a module requiring the stubbed
+// modules it imports, each replaced by its stub. It is a no-op when no stubbed
+// module is imported.
+func (p *TestPackage) writeModuleStubs() error {
+	used := p.usedModuleStubs()
+	if len(used) == 0 {
+		return nil
+	}
+
+	var goMod strings.Builder
+	goMod.WriteString("module gosec.test/sample\n\ngo 1.22\n")
+	for idx, modulePath := range used {
+		stub := moduleStubs[modulePath]
+		dir := fmt.Sprintf("stubs/%d", idx)
+		fmt.Fprintf(&goMod, "\nrequire %s %s\n", modulePath, stub.version)
+		fmt.Fprintf(&goMod, "replace %s => ./%s\n", modulePath, dir)
+
+		stubMod := fmt.Sprintf("module %s\n\ngo 1.22\n", modulePath)
+		if err := writeFile(path.Join(p.Path, dir, "go.mod"), stubMod); err != nil {
+			return err
+		}
+		for pkgPath, content := range stub.packages {
+			pkgDir := path.Join(p.Path, dir, strings.TrimPrefix(pkgPath, modulePath))
+			if err := writeFile(path.Join(pkgDir, path.Base(pkgPath)+".go"), content); err != nil {
+				return err
+			}
+		}
+	}
+	return writeFile(path.Join(p.Path, "go.mod"), goMod.String())
+}
+
+func writeFile(filename, content string) error {
+	if err := os.MkdirAll(path.Dir(filename), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, []byte(content), 0o644) /* #nosec G306 */
+}
@@ -0,0 +1,291 @@
+package gosec
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/securego/gosec/v2/issue"
+)
+
+// UnusedNoSecRuleID is the rule ID of the diagnostics of the nosec directives
+// which suppress no issue. Like a rule, they can be disabled with the include
+// and exclude global options.
+const UnusedNoSecRuleID = "nosec"
+
+// noSecDirective is a #nosec or //gosec:disable directive, whose uses are
+// tracked to report the ones which suppress no issue.
+type noSecDirective struct {
+	file    *token.File
+	comment *ast.Comment
+	// tagEnd is the offset in the comment text of the end of the tag.
+	tagEnd int
+	// rules are the rule IDs listed by the directive, or aliasOfAllRules.
+	rules []string
+	// used marks the rules which suppressed an issue. The map is not
+	// modified after its creation, so that the issues of the analyzers can
+	// be marked concurrently.
+	used map[string]*atomic.Bool
+}
+
+// newNoSecDirective tracks the directive of the comment group suppressing the
+// given rules, or nil if its comment cannot be found.
+func newNoSecDirective(file *token.File, group *ast.CommentGroup, rules map[string]issue.SuppressionInfo, tags ...string) *noSecDirective {
+	comment, tagEnd := findNoSecComment(group, tags...)
+	if file == nil || comment == nil {
+		return nil
+	}
+	d := &noSecDirective{
+		file:    file,
+		comment: comment,
+		tagEnd:  tagEnd,
+		used:    make(map[string]*atomic.Bool, len(rules)),
+	}
+	for ruleID := range rules {
+		d.rules = append(d.rules, ruleID)
+		d.used[ruleID] = &atomic.Bool{}
+	}
+	sort.Strings(d.rules)
+	return d
+}
+
+// findNoSecComment returns the comment of the group holding the directive,
+// and the offset of the end of its tag in the comment text.
+func findNoSecComment(group *ast.CommentGroup, tags ...string) (*ast.Comment, int) {
+	for _, c := range group.List {
+		if strings.HasPrefix(c.Text, directivePrefix) {
+			return c, len(directivePrefix)
+		}
+		for _, tag := range tags {
+			if idx := strings.Index(c.Text, tag); idx >= 0 {
+				return c, idx + len(tag)
+			}
+		}
+	}
+	return nil, 0
+}
+
+// markUsed records that the directive suppressed an issue of the rule.
+func (d *noSecDirective) markUsed(ruleID string) {
+	if used, ok := d.used[aliasOfAllRules]; ok {
+		used.Store(true)
+	} else if used, ok := d.used[ruleID]; ok {
+		used.Store(true)
+	}
+}
+
+func (d *noSecDirective) offset() int {
+	return d.file.Offset(d.comment.Pos())
+}
+
+// unusedNoSecIssues reports the directives which suppressed no issue, and the
+// rules listed by the directives which reported no issue on their lines. The
+// ignores are those of the variants of a package, which share their files: a
+// directive is used if it is used in one of them. The rules disabled in the
+// package are skipped, since they could not report anything. The diagnostics
+// are only reported when the NoSecReportUnused global option is enabled.
+func (gosec *Analyzer) unusedNoSecIssues(allIgnores []ignores, scope *packageScope) []*issue.Issue {
+	if enabled, _ := scope.config.IsGlobalEnabled(NoSecReportUnused); !enabled || !ruleSelector(scope.config)(UnusedNoSecRuleID) {
+		return nil
+	}
+
+	type location struct {
+		file   string
+		offset int
+	}
+	directives := make(map[location]*noSecDirective)
+	var locations []location
+	for _, fileIgnores := range allIgnores {
+		for _, entries := range fileIgnores {
+			for _, entry := range entries {
+				for _, d := range entry.directives {
+					loc := location{file: d.file.Name(), offset: d.offset()}
+					merged, ok := directives[loc]
+					if !ok {
+						directives[loc] = d
+						locations = append(locations, loc)
+						continue
+					}
+					for ruleID, used := range d.used {
+						if used.Load() {
+							merged.markUsed(ruleID)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].file != locations[j].file {
+			return locations[i].file < locations[j].file
+		}
+		return locations[i].offset < locations[j].offset
+	})
+
+	var issues []*issue.Issue
+	for _, loc := range locations {
+		d := directives[loc]
+		var unused []string
+		for _, ruleID := range d.rules {
+			if !d.used[ruleID].Load() && gosec.canReport(ruleID, scope) {
+				unused = append(unused, ruleID)
+			}
+		}
+		if len(unused) > 0 {
+			issues = append(issues, d.unusedIssue(unused))
+		}
+	}
+	return issues
+}
+
+// canReport tells whether the issues of a rule could have been suppressed in
+// the package, i.e. whether the rule is loaded and enabled. The directives
+// without rule IDs are only checked when all the rules are enabled, as they
+// may be meant for the disabled ones.
+func (gosec *Analyzer) canReport(ruleID string, scope *packageScope) bool {
+	if ruleID == aliasOfAllRules {
+		return gosec.allRulesEnabled(scope)
+	}
+	_, isRule := gosec.ruleset.RuleSuppressedMap[ruleID]
+	_, isAnalyzer := gosec.analyzerSet.AnalyzerSuppressedMap[ruleID]
+	return (isRule || isAnalyzer) && !scope.deselected(ruleID)
+}
+
+// allRulesEnabled tells whether no rule is disabled by the include and
+// exclude global options, apart from the diagnostics of the directives.
+func (gosec *Analyzer) allRulesEnabled(scope *packageScope) bool {
+	for _, option := range []GlobalOption{IncludeRules, ExcludeRules} {
+		value, _ := scope.config.GetGlobal(option)
+		for _, id := range strings.Split(value, ",") {
+			if id = strings.TrimSpace(id); id != "" && id != UnusedNoSecRuleID {
+				return false
+			}
+		}
+	}
+	for _, suppressed := range gosec.ruleset.RuleSuppressedMap {
+		if suppressed {
+			return false
+		}
+	}
+	for _, suppressed := range gosec.analyzerSet.AnalyzerSuppressedMap {
+		if suppressed {
+			return false
+		}
+	}
+	return true
+}
+
+// unusedIssue returns the diagnostic of the directive for its unused rules,
+// with the fix removing them.
+func (d *noSecDirective) unusedIssue(unused []string) *issue.Issue {
+	what := "Unused nosec directive: no issue to suppress"
+	description := "Remove the unused nosec directive"
+	if unused[0] != aliasOfAllRules {
+		what = fmt.Sprintf("Unused nosec directive: no %s issue to suppress", strings.Join(unused, ", "))
+		if len(unused) < len(d.rules) {
+			description = fmt.Sprintf("Remove %s from the nosec directive", strings.Join(unused, ", "))
+		}
+	}
+
+	iss := issue.New(d.file, d.comment, UnusedNoSecRuleID, what, issue.Low, issue.High)
+	if fix, ok := d.fix(unused, description); ok {
+		iss.Fixes = []issue.Fix{fix}
+	}
+	return iss
+}
+
+// fix removes the unused rules from the directive, or the whole comment when
+// none of its rules is used. A comment alone on its line is removed with its
+// line, and a comment following some code with the spaces before it.
+func (d *noSecDirective) fix(unused []string, description string) (issue.Fix, bool) {
+	content, err := os.ReadFile(d.file.Name())
+	if err != nil || d.file.Size() != len(content) {
+		return issue.Fix{}, false
+	}
+	start := d.offset()
+	end := d.file.Offset(d.comment.End())
+	text := ""
+
+	if len(unused) < len(d.rules) {
+		var ok bool
+		if text, ok = d.withoutRules(unused); !ok {
+			return issue.Fix{}, false
+		}
+	} else {
+		lineStart := start
+		for lineStart > 0 && (content[lineStart-1] == ' ' || content[lineStart-1] == '\t') {
+			lineStart--
+		}
+		lineEnd := end
+		for lineEnd < len(content) && (content[lineEnd] == ' ' || content[lineEnd] == '\t' || content[lineEnd] == '\r') {
+			lineEnd++
+		}
+		switch {
+		case (lineStart == 0 || content[lineStart-1] == '\n') && (lineEnd == len(content) || content[lineEnd] == '\n'):
+			start = lineStart
+			end = min(lineEnd+1, len(content))
+		case lineEnd == len(content) || content[lineEnd] == '\n':
+			start = lineStart
+		}
+	}
+
+	startPos := d.file.Position(d.file.Pos(start))
+	endPos := d.file.Position(d.file.Pos(end))
+	return issue.Fix{
+		Description: description,
+		Replacements: []issue.Replacement{{
+			StartLine:   startPos.Line,
+			StartColumn: startPos.Column,
+			EndLine:     endPos.Line,
+			EndColumn:   endPos.Column,
+			Text:        text,
+		}},
+	}, true
+}
+
+// withoutRules returns the text of the comment without the given rule IDs in
+// the list following its tag, which keeps its separator.
+func (d *noSecDirective) withoutRules(ruleIDs []string) (string, bool) {
+	comment := d.comment.Text
+	listEnd := len(comment)
+	if idx := strings.Index(comment[d.tagEnd:], "--"); idx >= 0 {
+		listEnd = d.tagEnd + idx
+	} else if strings.HasPrefix(comment, "/*") {
+		listEnd = len(comment) - len("*/")
+	}
+	list := comment[d.tagEnd:listEnd]
+
+	removed := make(map[string]bool, len(ruleIDs))
+	for _, ruleID := range ruleIDs {
+		removed[ruleID] = true
+	}
+	var kept []string
+	found := 0
+	for _, field := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		if removed[field] {
+			found++
+			continue
+		}
+		kept = append(kept, field)
+	}
+	if found != len(ruleIDs) {
+		return "", false
+	}
+
+	separator := " "
+	if strings.Contains(list, ", ") {
+		separator = ", "
+	} else if strings.Contains(list, ",") {
+		separator = ","
+	}
+	text := comment[:d.tagEnd] + " " + strings.Join(kept, separator)
+	if rest := comment[listEnd:]; rest != "" {
+		text += " " + rest
+	}
+	return text, true
+}